@@ -1,25 +1,53 @@
 package http
 
 import (
+	"bytes"
 	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	gohttp "net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/xraph/go-utils/val"
 )
 
+// errBindBody wraps the errors of decoding the body in BindRequest, so
+// Handle can tell malformed requests from internal failures.
+var errBindBody = errors.New("failed to bind body")
+
 // BindRequest binds and validates request data from all sources (path, query, header, body).
 // This method provides comprehensive request binding that:
 //   - Binds path parameters from URL path segments (path:"name")
 //   - Binds query parameters from URL query string (query:"name")
+//   - Collects bracketed query parameters (filter[status]=active) into map[string]string fields
 //   - Binds headers from HTTP headers (header:"name")
+//   - Decodes []byte parameters as base64url, or as set by the encoding tag
+//     (encoding:"base64", encoding:"base64url" or encoding:"hex"); the tag
+//     also applies to top-level []byte fields of a JSON body
 //   - Binds body fields from request body (json:"name" or body:"")
+//   - Binds untagged fields from query parameters named by the naming
+//     strategy set with WithDefaultNamingStrategy, if any
 //   - Validates all fields using validation tags (required, minLength, etc.)
 //
+// When a field is tagged with several sources, the sources are tried in the
+// order path, query, header and body, and the first one with a non-empty
+// value wins; for example, with
+//
+//	ID string `path:"id" query:"id" json:"id"`
+//
+// the path parameter is used if present, then the query parameter, and the
+// body value only if neither is. A field that no parameter source provides
+// keeps a non-zero value decoded from the body; otherwise its first source
+// applies its default or reports it as missing.
+//
 // Example:
 //
 //	type CreateUserRequest struct {
@@ -54,19 +82,21 @@ func (c *Ctx) BindRequest(v any) error {
 	// Track validation errors
 	ValidationError := val.NewValidationError()
 
-	// Bind struct fields recursively (handles embedded structs)
-	if err := c.bindStructFields(rv, rt, ValidationError); err != nil {
-		return err
-	}
-
-	// Bind body fields (if any) - this handles json/body tagged fields
-	if err := c.bindBodyFields(v, rt); err != nil {
+	// Bind body fields (if any) - this handles json/body tagged fields. The
+	// body is bound first so that path, query and header values take
+	// precedence over it.
+	if err := c.bindBodyFields(v, rt, ValidationError); err != nil {
 		// Don't fail on body binding for GET requests without body
 		if c.request.Method != gohttp.MethodGet && c.request.Method != gohttp.MethodHead && c.request.Method != gohttp.MethodDelete {
-			return fmt.Errorf("failed to bind body: %w", err)
+			return fmt.Errorf("%w: %w", errBindBody, err)
 		}
 	}
 
+	// Bind struct fields recursively (handles embedded structs)
+	if err := c.bindStructFields(rv, rt, "", ValidationError); err != nil {
+		return err
+	}
+
 	// Validate all fields using their validation tags
 	if err := c.validateStruct(v, rt, ValidationError); err != nil {
 		return err
@@ -80,8 +110,199 @@ func (c *Ctx) BindRequest(v any) error {
 	return nil
 }
 
+// BindAndValidate binds v like BindRequest and reports validation failures as
+// a map of field names to messages, ready to be written as a 422 body:
+//
+//	fields, err := ctx.BindAndValidate(&req)
+//	if fields != nil {
+//	    return ctx.JSON(http.StatusUnprocessableEntity, fields)
+//	}
+//	if err != nil {
+//	    return err
+//	}
+//
+// The underlying *val.ValidationError is returned alongside the map. Errors
+// that are not validation failures, such as malformed JSON or a nil pointer,
+// are returned with a nil map.
+func (c *Ctx) BindAndValidate(v any) (map[string][]string, error) {
+	err := c.BindRequest(v)
+	if err == nil {
+		return nil, nil
+	}
+
+	var validationErr *val.ValidationError
+	if errors.As(err, &validationErr) && validationErr.HasErrors() {
+		return validationErr.FieldMessages(), err
+	}
+
+	return nil, err
+}
+
+// BindQuery binds and validates only the query:"name" tagged fields of v.
+// Fields bound from other sources (path, header, body) are neither bound nor
+// validated, so a pagination struct can be bound on its own:
+//
+//	var page BaseParams
+//	if err := ctx.BindQuery(&page); err != nil {
+//	    return err
+//	}
+func (c *Ctx) BindQuery(v any) error {
+	return c.bindSource(v, "query")
+}
+
+// BindHeader binds and validates only the header:"name" tagged fields of v.
+// Fields bound from other sources (path, query, body) are neither bound nor
+// validated.
+func (c *Ctx) BindHeader(v any) error {
+	return c.bindSource(v, "header")
+}
+
+// bindSource binds and validates the fields of v tagged with a single
+// source tag such as "query" or "header".
+func (c *Ctx) bindSource(v any, source string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding %s parameters requires non-nil pointer to struct", source)
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	ValidationError := val.NewValidationError()
+
+	if err := c.bindStructFields(rv, rt, source, ValidationError); err != nil {
+		return err
+	}
+
+	if err := c.validateStructSource(v, rt, source, ValidationError); err != nil {
+		return err
+	}
+
+	if ValidationError.HasErrors() {
+		return ValidationError
+	}
+
+	return nil
+}
+
+// BindMap binds all query and form values into a map, coercing values
+// whose type is unambiguous. This is useful for dynamic endpoints where the
+// schema isn't known at compile time.
+//
+// Coercion rules:
+//   - "true" and "false" (case-insensitive) become bool
+//   - base-10 integers without leading zeros or a plus sign become int64
+//   - plain decimal numbers (e.g. "1.5", "-2e3") become float64
+//   - everything else, including "", "007", "NaN", and "0x1F", stays a string
+//
+// Keys with a single value map to the coerced value; keys with multiple
+// values map to a []any of coerced values. Form values from the request body
+// come before query values for the same key.
+func (c *Ctx) BindMap() (map[string]any, error) {
+	contentType := c.request.Header.Get("Content-Type")
+
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		if err := c.request.ParseMultipartForm(32 << 20); err != nil {
+			return nil, fmt.Errorf("failed to parse multipart form: %w", err)
+		}
+	} else if err := c.request.ParseForm(); err != nil {
+		return nil, fmt.Errorf("failed to parse form: %w", err)
+	}
+
+	result := make(map[string]any, len(c.request.Form))
+
+	for key, values := range c.request.Form {
+		switch len(values) {
+		case 0:
+			continue
+		case 1:
+			result[key] = coerceValue(values[0])
+		default:
+			coerced := make([]any, len(values))
+			for i, value := range values {
+				coerced[i] = coerceValue(value)
+			}
+
+			result[key] = coerced
+		}
+	}
+
+	return result, nil
+}
+
+// coerceValue converts a string to a bool, int64, or float64 when the
+// conversion is unambiguous, and returns the string unchanged otherwise.
+func coerceValue(value string) any {
+	switch strings.ToLower(value) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if !isPlainNumber(value) {
+		return value
+	}
+
+	digits := strings.TrimPrefix(value, "-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		return value // Leading zeros suggest an identifier such as a zip code
+	}
+
+	if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return intVal
+	}
+
+	if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+		return floatVal
+	}
+
+	return value
+}
+
+// isPlainNumber reports whether s looks like a decimal number: an optional
+// leading minus, digits, an optional fraction, and an optional exponent.
+func isPlainNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	s = strings.TrimPrefix(s, "-")
+
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(s), "e")
+	if hasExponent {
+		exponent = strings.TrimPrefix(strings.TrimPrefix(exponent, "-"), "+")
+		if !isDigits(exponent) {
+			return false
+		}
+	}
+
+	whole, fraction, hasFraction := strings.Cut(mantissa, ".")
+	if !isDigits(whole) {
+		return false
+	}
+
+	return !hasFraction || isDigits(fraction)
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
 // bindStructFields recursively binds struct fields, handling embedded structs.
-func (c *Ctx) bindStructFields(rv reflect.Value, rt reflect.Type, errors *val.ValidationError) error {
+// A non-empty source restricts binding to fields with that tag.
+func (c *Ctx) bindStructFields(rv reflect.Value, rt reflect.Type, source string, errors *val.ValidationError) error {
 	for i := range rt.NumField() {
 		field := rt.Field(i)
 		fieldValue := rv.Field(i)
@@ -115,7 +336,7 @@ func (c *Ctx) bindStructFields(rv reflect.Value, rt reflect.Type, errors *val.Va
 
 				// Only recurse if it's a struct
 				if embeddedType.Kind() == reflect.Struct {
-					if err := c.bindStructFields(embeddedValue, embeddedType, errors); err != nil {
+					if err := c.bindStructFields(embeddedValue, embeddedType, source, errors); err != nil {
 						return err
 					}
 
@@ -124,7 +345,15 @@ func (c *Ctx) bindStructFields(rv reflect.Value, rt reflect.Type, errors *val.Va
 			}
 		}
 
-		// Bind based on tag priority: path -> query -> header -> form -> body/json
+		if source != "" {
+			if err := c.bindSourceField(field, fieldValue, source, errors); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Bind based on tag priority: path -> query -> header -> body/json
 		if err := c.bindField(field, fieldValue, errors); err != nil {
 			return err
 		}
@@ -133,22 +362,212 @@ func (c *Ctx) bindStructFields(rv reflect.Value, rt reflect.Type, errors *val.Va
 	return nil
 }
 
-// bindField binds a single struct field from the appropriate source.
+// paramSources are the parameter tags in binding precedence order.
+var paramSources = []string{"path", "query", "header"}
+
+// bindField binds a single struct field from the first of its tagged
+// parameter sources that has a value. See BindRequest for the precedence.
 func (c *Ctx) bindField(field reflect.StructField, fieldValue reflect.Value, errors *val.ValidationError) error {
-	// Check tags in priority order
-	if pathTag := field.Tag.Get("path"); pathTag != "" {
-		return c.bindPathParam(field, fieldValue, pathTag, errors)
+	var sources []string
+
+	for _, source := range paramSources {
+		if field.Tag.Get(source) != "" {
+			sources = append(sources, source)
+		}
 	}
 
-	if queryTag := field.Tag.Get("query"); queryTag != "" {
-		return c.bindQueryParam(field, fieldValue, queryTag, errors)
+	// Form and body fields are handled separately in bindBodyFields
+	if len(sources) == 0 {
+		return c.bindUntaggedField(field, fieldValue, errors)
 	}
 
-	if headerTag := field.Tag.Get("header"); headerTag != "" {
-		return c.bindHeaderParam(field, fieldValue, headerTag, errors)
+	for _, source := range sources {
+		if c.hasParam(field, source) {
+			return c.bindSourceField(field, fieldValue, source, errors)
+		}
+	}
+
+	// Keep a value decoded from the body
+	if hasBodyTag(field) && !fieldValue.IsZero() {
+		return nil
+	}
+
+	// Let the first source apply its default or report the missing value
+	return c.bindSourceField(field, fieldValue, sources[0], errors)
+}
+
+// NamingStrategy derives the query parameter name of a struct field that has
+// no source tag. See WithDefaultNamingStrategy.
+type NamingStrategy int
+
+const (
+	// NamingNone leaves untagged fields unbound. This is the default.
+	NamingNone NamingStrategy = iota
+
+	// NamingSnakeCase binds PageSize from page_size and UserID from user_id.
+	NamingSnakeCase
+
+	// NamingCamelCase binds PageSize from pageSize and UserID from userID.
+	NamingCamelCase
+
+	// NamingAsIs binds PageSize from PageSize.
+	NamingAsIs
+)
+
+// paramName returns the parameter name of the Go field name, or "" for
+// NamingNone.
+func (s NamingStrategy) paramName(fieldName string) string {
+	switch s {
+	case NamingSnakeCase:
+		return toSnakeCase(fieldName)
+	case NamingCamelCase:
+		return toLowerCamelCase(fieldName)
+	case NamingAsIs:
+		return fieldName
+	default:
+		return ""
+	}
+}
+
+// bindUntaggedField binds a field without source tags from the query
+// parameter named by the context's naming strategy. The field is left as is
+// when the parameter is absent, unless it is tagged required:"true".
+func (c *Ctx) bindUntaggedField(field reflect.StructField, fieldValue reflect.Value, errors *val.ValidationError) error {
+	name, ok := c.defaultParamName(field)
+	if !ok {
+		return nil
+	}
+
+	value := c.Query(name)
+	if value == "" {
+		if field.Tag.Get("required") == "true" {
+			errors.AddWithCode(name, "query parameter is required", val.ErrCodeRequired, nil)
+		}
+
+		return nil
+	}
+
+	return setBoundFieldValue(field, fieldValue, value, name, errors)
+}
+
+// defaultParamName returns the query parameter name the naming strategy
+// derives for field, and false if field is not bound that way: it has a
+// source tag, is bound from the body or is excluded with json:"-", or no
+// naming strategy is set.
+func (c *Ctx) defaultParamName(field reflect.StructField) (string, bool) {
+	if val.IsParameterField(field) || hasBodyTag(field) || field.Tag.Get("json") == "-" {
+		return "", false
+	}
+
+	name := c.namingStrategy.paramName(field.Name)
+
+	return name, name != ""
+}
+
+// toSnakeCase converts a Go identifier to snake_case, keeping acronyms
+// together: "PageSize" becomes "page_size" and "HTTPServerID" "http_server_id".
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a word at a lower-to-upper change, or at the last
+			// capital of an acronym that is followed by a lower case word
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// toLowerCamelCase lower-cases the leading word of a Go identifier, including
+// a leading acronym: "PageSize" becomes "pageSize" and "HTTPServer"
+// "httpServer".
+func toLowerCamelCase(name string) string {
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if !unicode.IsUpper(r) {
+			break
+		}
+
+		// The last capital of a leading acronym starts the next word
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+
+		runes[i] = unicode.ToLower(r)
+	}
+
+	return string(runes)
+}
+
+// hasParam reports whether the request has a non-empty value for field in
+// the parameter source.
+func (c *Ctx) hasParam(field reflect.StructField, source string) bool {
+	name := parseTagName(field.Tag.Get(source))
+	if name == "" {
+		name = field.Name
+	}
+
+	switch source {
+	case "path":
+		return c.Param(name) != ""
+	case "query":
+		if isStringMap(field.Type) {
+			for key := range c.request.URL.Query() {
+				if strings.HasPrefix(key, name+"[") {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		return c.Query(name) != ""
+	case "header":
+		return c.Header(name) != ""
+	}
+
+	return false
+}
+
+// hasBodyTag reports whether field is also bound from the body.
+func hasBodyTag(field reflect.StructField) bool {
+	for _, key := range []string{"json", "body"} {
+		if tag := field.Tag.Get(key); tag != "" && tag != "-" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bindSourceField binds a single struct field if it is tagged with source.
+func (c *Ctx) bindSourceField(field reflect.StructField, fieldValue reflect.Value, source string, errors *val.ValidationError) error {
+	tag := field.Tag.Get(source)
+	if tag == "" {
+		return nil
+	}
+
+	switch source {
+	case "path":
+		return c.bindPathParam(field, fieldValue, tag, errors)
+	case "query":
+		return c.bindQueryParam(field, fieldValue, tag, errors)
+	case "header":
+		return c.bindHeaderParam(field, fieldValue, tag, errors)
 	}
 
-	// Form and body fields are handled separately in bindBodyFields
 	return nil
 }
 
@@ -168,7 +587,7 @@ func (c *Ctx) bindPathParam(field reflect.StructField, fieldValue reflect.Value,
 		return nil
 	}
 
-	return setFieldValue(fieldValue, value, paramName, errors)
+	return setBoundFieldValue(field, fieldValue, value, paramName, errors)
 }
 
 // bindQueryParam binds a query parameter.
@@ -178,6 +597,10 @@ func (c *Ctx) bindQueryParam(field reflect.StructField, fieldValue reflect.Value
 		paramName = field.Name
 	}
 
+	if isStringMap(field.Type) {
+		return c.bindQueryMap(field, fieldValue, tag, paramName, errors)
+	}
+
 	value := c.Query(paramName)
 
 	// Determine if field is required using consistent precedence:
@@ -202,12 +625,58 @@ func (c *Ctx) bindQueryParam(field reflect.StructField, fieldValue reflect.Value
 	}
 
 	if value != "" {
-		return setFieldValue(fieldValue, value, paramName, errors)
+		return setBoundFieldValue(field, fieldValue, value, paramName, errors)
+	}
+
+	return nil
+}
+
+// bindQueryMap binds the bracketed query parameters of paramName into a
+// map[string]string field, using the bracket notation common for filters:
+//
+//	Filters map[string]string `query:"filter" optional:"true"`
+//
+//	?filter[status]=active&filter[type]=user
+//	=> map[string]string{"status": "active", "type": "user"}
+//
+// Keys are URL-decoded, so filter%5Bcreated%20at%5D=today binds "created at".
+// Only the first value of a repeated key is used, and empty keys such as
+// filter[] are ignored. A required map fails validation when no bracketed
+// parameter is present; an optional one is set to an empty map.
+func (c *Ctx) bindQueryMap(field reflect.StructField, fieldValue reflect.Value, tag, paramName string, errors *val.ValidationError) error {
+	values := reflect.MakeMap(field.Type)
+
+	for key, vals := range c.request.URL.Query() {
+		rest, ok := strings.CutPrefix(key, paramName+"[")
+		if !ok || len(vals) == 0 {
+			continue
+		}
+
+		name, ok := strings.CutSuffix(rest, "]")
+		if !ok || name == "" {
+			continue
+		}
+
+		values.SetMapIndex(reflect.ValueOf(name).Convert(field.Type.Key()),
+			reflect.ValueOf(vals[0]).Convert(field.Type.Elem()))
+	}
+
+	if values.Len() == 0 && isBindFieldRequired(field, tag) {
+		errors.AddWithCode(paramName, "query parameter is required", val.ErrCodeRequired, nil)
+
+		return nil
 	}
 
+	fieldValue.Set(values)
+
 	return nil
 }
 
+// isStringMap reports whether t is a map with string keys and values.
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.String
+}
+
 // bindHeaderParam binds a header parameter.
 func (c *Ctx) bindHeaderParam(field reflect.StructField, fieldValue reflect.Value, tag string, errors *val.ValidationError) error {
 	headerName := parseTagName(tag)
@@ -239,14 +708,16 @@ func (c *Ctx) bindHeaderParam(field reflect.StructField, fieldValue reflect.Valu
 	}
 
 	if value != "" {
-		return setFieldValue(fieldValue, value, headerName, errors)
+		return setBoundFieldValue(field, fieldValue, value, headerName, errors)
 	}
 
 	return nil
 }
 
-// bindBodyFields binds body/json tagged fields.
-func (c *Ctx) bindBodyFields(v any, rt reflect.Type) error {
+// bindBodyFields binds body/json tagged fields. Encoded []byte fields of a
+// JSON body are decoded by bindEncodedJSON, reporting malformed values to
+// errors.
+func (c *Ctx) bindBodyFields(v any, rt reflect.Type, errors *val.ValidationError) error {
 	// Check if struct has body fields
 	hasBodyFields := false
 
@@ -274,10 +745,108 @@ func (c *Ctx) bindBodyFields(v any, rt reflect.Type) error {
 		return nil
 	}
 
+	contentType := c.request.Header.Get("Content-Type")
+	if encoded := encodedBodyFields(rt); len(encoded) > 0 && (contentType == "application/json" || contentType == "") {
+		return c.bindEncodedJSON(v, encoded, errors)
+	}
+
 	// Bind body content using existing Bind method
 	return c.Bind(v)
 }
 
+// encodedBodyFields returns the indexes of the top-level fields of rt that
+// are decoded from the JSON body and have an encoding tag.
+func encodedBodyFields(rt reflect.Type) []int {
+	var fields []int
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+
+		tag := field.Tag.Get("json")
+		if field.Tag.Get("encoding") != "" && tag != "-" && field.IsExported() {
+			fields = append(fields, i)
+		}
+	}
+
+	return fields
+}
+
+// bindEncodedJSON binds a JSON body into v, decoding the string values of
+// the encoded fields with setEncodedFieldValue instead of encoding/json's
+// standard base64 for []byte. Malformed values are added to errors.
+// JSON object keys are matched case-insensitively, as encoding/json does.
+func (c *Ctx) bindEncodedJSON(v any, encoded []int, errors *val.ValidationError) error {
+	if c.request.Body == nil {
+		return fmt.Errorf("request body is nil")
+	}
+	defer c.request.Body.Close()
+
+	data, err := io.ReadAll(c.limitBody())
+	if err != nil {
+		return c.bodyDecodeError("JSON", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return c.bodyDecodeError("JSON", err)
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	values := make(map[int]json.RawMessage, len(encoded))
+
+	for _, i := range encoded {
+		name := jsonFieldName(rt.Field(i))
+		for key, value := range raw {
+			if strings.EqualFold(key, name) {
+				values[i] = value
+				delete(raw, key)
+			}
+		}
+	}
+
+	// Decode the remaining fields as usual, strict mode included
+	rest, err := json.Marshal(raw)
+	if err != nil {
+		return c.bodyDecodeError("JSON", err)
+	}
+
+	if err := c.decodeJSON(bytes.NewReader(rest), v); err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		field := rt.Field(i)
+		name := jsonFieldName(field)
+
+		if string(value) == "null" {
+			continue
+		}
+
+		var str string
+		if err := json.Unmarshal(value, &str); err != nil {
+			errors.AddWithCode(name, "encoded value must be a string", val.ErrCodeInvalidType, string(value))
+
+			continue
+		}
+
+		if err := setEncodedFieldValue(rv.Field(i), str, field.Tag.Get("encoding"), name, errors); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonFieldName returns the JSON object key of a field.
+func jsonFieldName(field reflect.StructField) string {
+	if name := parseTagName(field.Tag.Get("json")); name != "" {
+		return name
+	}
+
+	return field.Name
+}
+
 // parseTagName extracts the parameter name from a tag value
 // Handles formats like: "paramName", "paramName,omitempty".
 func parseTagName(tag string) string {
@@ -333,9 +902,161 @@ func isBindFieldRequired(field reflect.StructField, tag string) bool {
 	return true
 }
 
+// setBoundFieldValue sets a path, query, or header value on a field.
+// Fields with an encoding tag are decoded as binary data, as are []byte and
+// *[]byte fields without one, using base64url; all others are converted by
+// setFieldValue.
+func setBoundFieldValue(field reflect.StructField, fieldValue reflect.Value, value string, fieldName string, errors *val.ValidationError) error {
+	enc := field.Tag.Get("encoding")
+	if enc == "" && isBytesField(field.Type) {
+		enc = "base64url"
+	}
+
+	if enc != "" {
+		return setEncodedFieldValue(fieldValue, value, enc, fieldName, errors)
+	}
+
+	return setFieldValue(fieldValue, value, fieldName, errors)
+}
+
+// isBytesField reports whether t is []byte or *[]byte. Named byte slice
+// types such as net.IP are left to their own conversions.
+func isBytesField(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t == reflect.TypeFor[[]byte]()
+}
+
+// setEncodedFieldValue decodes a base64, base64url or hex string into a
+// []byte field. Padding is optional for base64url, since URL-safe values are
+// commonly sent without it. Malformed input is reported as a validation
+// error rather than returned.
+func setEncodedFieldValue(fieldValue reflect.Value, value string, enc string, fieldName string, errors *val.ValidationError) error {
+	if fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+		}
+
+		return setEncodedFieldValue(fieldValue.Elem(), value, enc, fieldName, errors)
+	}
+
+	if fieldValue.Kind() != reflect.Slice || fieldValue.Type().Elem().Kind() != reflect.Uint8 {
+		errors.AddWithCode(fieldName, fmt.Sprintf("encoding tag requires a []byte field, got %s", fieldValue.Type()), val.ErrCodeInvalidType, value)
+
+		return nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch enc {
+	case "base64":
+		data, err = base64.StdEncoding.DecodeString(value)
+	case "base64url":
+		data, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	case "hex":
+		data, err = hex.DecodeString(value)
+	default:
+		errors.AddWithCode(fieldName, "unsupported encoding: "+enc, val.ErrCodeInvalidType, value)
+
+		return nil
+	}
+
+	if err != nil {
+		errors.AddWithCode(fieldName, fmt.Sprintf("invalid %s value", enc), val.ErrCodeInvalidFormat, value)
+
+		return nil
+	}
+
+	fieldValue.SetBytes(data)
+
+	return nil
+}
+
+var (
+	bindConvertersMu sync.RWMutex
+	bindConverters   = map[reflect.Type]func(string) (any, error){}
+)
+
+// RegisterBindConverter registers fn to convert path, query, and header values
+// into fields of type t, replacing any converter previously registered for t.
+// Converters take precedence over encoding.TextUnmarshaler and the built-in
+// conversions, and apply to pointers to t as well. The value returned by fn
+// must be assignable to t; an error returned by fn is reported
+// as a validation error for the field.
+//
+// Example:
+//
+//	http.RegisterBindConverter(reflect.TypeFor[uuid.UUID](), func(s string) (any, error) {
+//	    return uuid.Parse(s)
+//	})
+func RegisterBindConverter(t reflect.Type, fn func(string) (any, error)) {
+	if t == nil || fn == nil {
+		return
+	}
+
+	bindConvertersMu.Lock()
+	defer bindConvertersMu.Unlock()
+
+	bindConverters[t] = fn
+}
+
+// bindConverter returns the converter registered for t, if any.
+func bindConverter(t reflect.Type) (func(string) (any, error), bool) {
+	bindConvertersMu.RLock()
+	defer bindConvertersMu.RUnlock()
+
+	fn, ok := bindConverters[t]
+
+	return fn, ok
+}
+
+// tryBindConverter converts value with the converter registered for the
+// field's type. Returns true if a converter was found, in which case any
+// conversion failure has been added to errors.
+func tryBindConverter(fieldValue reflect.Value, value string, fieldName string, errors *val.ValidationError) bool {
+	convert, ok := bindConverter(fieldValue.Type())
+	if !ok {
+		return false
+	}
+
+	result, err := convert(value)
+	if err != nil {
+		errors.AddWithCode(fieldName, fmt.Sprintf("invalid value: %v", err), val.ErrCodeInvalidType, value)
+
+		return true
+	}
+
+	if result == nil {
+		fieldValue.SetZero()
+
+		return true
+	}
+
+	rv := reflect.ValueOf(result)
+	if !rv.Type().AssignableTo(fieldValue.Type()) {
+		errors.AddWithCode(fieldName, fmt.Sprintf("converter returned %s, want %s", rv.Type(), fieldValue.Type()), val.ErrCodeInvalidType, value)
+
+		return true
+	}
+
+	fieldValue.Set(rv)
+
+	return true
+}
+
 // setFieldValue sets a field value from a string, converting to the appropriate type.
-// Supports types that implement encoding.TextUnmarshaler (e.g., xid.ID, uuid.UUID).
+// Supports converters registered with RegisterBindConverter and types that
+// implement encoding.TextUnmarshaler (e.g., xid.ID, uuid.UUID).
 func setFieldValue(fieldValue reflect.Value, value string, fieldName string, errors *val.ValidationError) error {
+	if tryBindConverter(fieldValue, value, fieldName, errors) {
+		return nil
+	}
+
 	// Handle pointer types first - create the value if nil, then recurse
 	if fieldValue.Kind() == reflect.Ptr {
 		if fieldValue.IsNil() {
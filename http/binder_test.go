@@ -2,10 +2,14 @@ package http
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/rs/xid"
@@ -591,6 +595,273 @@ func TestBindRequest_EmbeddedOptional_NoRequired(t *testing.T) {
 	assert.Equal(t, "Test", bindReq.Name)
 }
 
+type BindAndValidateRequest struct {
+	Page  int    `query:"page"`
+	Name  string `json:"name"  minLength:"3"`
+	Email string `format:"email" json:"email"`
+}
+
+func TestBindAndValidate_MultipleFieldErrors(t *testing.T) {
+	body := `{"name": "Al", "email": "not-an-email"}`
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq BindAndValidateRequest
+
+	fields, err := ctx.BindAndValidate(&bindReq)
+	require.Error(t, err)
+	assert.True(t, val.IsValidationError(err))
+
+	assert.Equal(t, map[string][]string{
+		"page":  {"query parameter is required"},
+		"name":  {"must be at least 3 characters"},
+		"email": {"must be a valid email address"},
+	}, fields)
+}
+
+func TestBindAndValidate_Success(t *testing.T) {
+	body := `{"name": "Alice", "email": "alice@example.com"}`
+	req := httptest.NewRequest(http.MethodPost, "/test?page=2", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq BindAndValidateRequest
+
+	fields, err := ctx.BindAndValidate(&bindReq)
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+	assert.Equal(t, 2, bindReq.Page)
+	assert.Equal(t, "Alice", bindReq.Name)
+}
+
+func TestBindAndValidate_NonValidationError(t *testing.T) {
+	t.Run("malformed JSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test?page=1", bytes.NewReader([]byte(`{"name":`)))
+		req.Header.Set("Content-Type", "application/json")
+
+		ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+		var bindReq BindAndValidateRequest
+
+		fields, err := ctx.BindAndValidate(&bindReq)
+		require.Error(t, err)
+		assert.False(t, val.IsValidationError(err))
+		assert.Nil(t, fields)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+		fields, err := ctx.BindAndValidate(nil)
+		require.Error(t, err)
+		assert.Nil(t, fields)
+	})
+}
+
+func TestBindQuery_BaseParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?limit=25&sort=name", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var params BaseParams
+
+	err := ctx.BindQuery(&params)
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, params.Limit)
+	assert.Equal(t, 0, params.Offset)
+	assert.Equal(t, "name", params.Sort)
+}
+
+type QueryWithOtherSourcesRequest struct {
+	BaseParams
+
+	TenantID string `path:"tenantId"`
+	APIKey   string `header:"X-API-Key" required:"true"`
+	Name     string `json:"name"      minLength:"1"`
+	Status   string `enum:"active,archived" optional:"true" query:"status"`
+}
+
+func TestBindQuery_IgnoresOtherSources(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?limit=5&status=active", nil)
+	req.Header.Set("X-API-Key", "secret")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq QueryWithOtherSourcesRequest
+
+	// Missing path, header-bound and body fields must not fail query binding
+	err := ctx.BindQuery(&bindReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, bindReq.Limit)
+	assert.Equal(t, "active", bindReq.Status)
+	assert.Empty(t, bindReq.APIKey)
+	assert.Empty(t, bindReq.TenantID)
+}
+
+func TestBindQuery_Validation(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?status=deleted", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq QueryWithOtherSourcesRequest
+
+	err := ctx.BindQuery(&bindReq)
+	require.Error(t, err)
+
+	var validationErr *val.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "status", validationErr.Errors[0].Field)
+}
+
+type QueryMapRequest struct {
+	Filters map[string]string `optional:"true" query:"filter"`
+	Sort    string            `optional:"true" query:"sort"`
+}
+
+type RequiredQueryMapRequest struct {
+	Filters map[string]string `query:"filter"`
+}
+
+func TestBindRequest_QueryMap(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet,
+		"/users?filter[status]=active&filter[type]=user&filter%5Bcreated%20at%5D=today&filter[]=ignored&filters[x]=other&sort=name", nil)
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq QueryMapRequest
+
+	require.NoError(t, ctx.BindRequest(&bindReq))
+
+	assert.Equal(t, map[string]string{
+		"status":     "active",
+		"type":       "user",
+		"created at": "today",
+	}, bindReq.Filters)
+	assert.Equal(t, "name", bindReq.Sort)
+}
+
+func TestBindRequest_QueryMap_Absent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users?sort=name", nil)
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq QueryMapRequest
+
+	require.NoError(t, ctx.BindRequest(&bindReq))
+
+	assert.NotNil(t, bindReq.Filters)
+	assert.Empty(t, bindReq.Filters)
+
+	var required RequiredQueryMapRequest
+
+	err := ctx.BindRequest(&required)
+
+	var validationErr *val.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "filter", validationErr.Errors[0].Field)
+}
+
+func TestBindQuery_RequiresStructPointer(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var params BaseParams
+
+	require.Error(t, ctx.BindQuery(params))
+	require.Error(t, ctx.BindQuery(nil))
+
+	var s string
+
+	require.Error(t, ctx.BindQuery(&s))
+}
+
+type HeaderOnlyRequest struct {
+	APIKey    string `header:"X-API-Key"`
+	RequestID string `header:"X-Request-ID" optional:"true"`
+	Version   int    `default:"1"           header:"X-API-Version"`
+	Email     string `format:"email"        header:"X-User-Email" optional:"true"`
+	Page      int    `query:"page"`
+}
+
+func TestBindHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-API-Key", "secret")
+	req.Header.Set("X-Request-ID", "req-123")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq HeaderOnlyRequest
+
+	// The required query field is ignored
+	err := ctx.BindHeader(&bindReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", bindReq.APIKey)
+	assert.Equal(t, "req-123", bindReq.RequestID)
+	assert.Equal(t, 1, bindReq.Version)
+	assert.Equal(t, 0, bindReq.Page)
+}
+
+func TestBindHeader_Validation(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?page=2", nil)
+	req.Header.Set("X-User-Email", "not-an-email")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq HeaderOnlyRequest
+
+	err := ctx.BindHeader(&bindReq)
+	require.Error(t, err)
+
+	var validationErr *val.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	fields := make([]string, 0, len(validationErr.Errors))
+	for _, fieldErr := range validationErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+
+	assert.Contains(t, fields, "X-API-Key")
+	assert.Contains(t, fields, "X-User-Email")
+	assert.NotContains(t, fields, "page")
+}
+
+type HeaderValidateTagRequest struct {
+	Token string `header:"Authorization" validate:"startswith=Bearer "`
+	Name  string `json:"name"            validate:"required"`
+}
+
+func TestBindHeader_FiltersValidatorErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Basic abc")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq HeaderValidateTagRequest
+
+	err := ctx.BindHeader(&bindReq)
+	require.Error(t, err)
+
+	var validationErr *val.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "Authorization", validationErr.Errors[0].Field)
+}
+
 // Test that default tag alone (without optional) makes field not required and applies default.
 type DefaultTagOnlyRequest struct {
 	Name  string `query:"name"`
@@ -1269,3 +1540,577 @@ func TestBindRequest_JsonBodyEmptyStringBothRequired(t *testing.T) {
 	require.True(t, ok)
 	assert.True(t, valErrors.HasErrors())
 }
+
+// Test struct for encoded binary fields.
+type EncodedBytesRequest struct {
+	Signature []byte  `encoding:"base64" header:"X-Signature"`
+	Nonce     []byte  `encoding:"hex"    query:"nonce"`
+	Salt      *[]byte `encoding:"base64" query:"salt"`
+}
+
+func TestBindRequest_EncodedBytes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/verify?nonce=deadbeef&salt=c2FsdA%3D%3D", nil)
+	req.Header.Set("X-Signature", "c2lnbmVk")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq EncodedBytesRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("signed"), bindReq.Signature)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, bindReq.Nonce)
+	require.NotNil(t, bindReq.Salt)
+	assert.Equal(t, []byte("salt"), *bindReq.Salt)
+}
+
+func TestBindRequest_EncodedBytes_OptionalAbsent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/verify?nonce=00ff", nil)
+	req.Header.Set("X-Signature", "c2lnbmVk")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq EncodedBytesRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.NoError(t, err)
+
+	assert.Nil(t, bindReq.Salt)
+}
+
+func TestBindRequest_EncodedBytes_InvalidBase64(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/verify?nonce=00ff", nil)
+	req.Header.Set("X-Signature", "not*base64!")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq EncodedBytesRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.Error(t, err)
+
+	valErrors := &val.ValidationError{}
+	require.True(t, errors.As(err, &valErrors))
+
+	fieldErrs := valErrors.GetFieldErrors("X-Signature")
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, val.ErrCodeInvalidFormat, fieldErrs[0].Code)
+}
+
+func TestBindRequest_EncodedBytes_InvalidHex(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/verify?nonce=xyz", nil)
+	req.Header.Set("X-Signature", "c2lnbmVk")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil).(*Ctx)
+
+	var bindReq EncodedBytesRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.Error(t, err)
+
+	valErrors := &val.ValidationError{}
+	require.True(t, errors.As(err, &valErrors))
+	assert.True(t, valErrors.HasFieldError("nonce"))
+}
+
+// Test struct for encoded binary fields in a JSON body.
+type EncodedBodyRequest struct {
+	Signature []byte `encoding:"hex"       json:"signature"`
+	Nonce     []byte `encoding:"base64url" json:"nonce,omitempty"`
+	Payload   []byte `json:"payload"`
+	Name      string `json:"name"`
+}
+
+func TestBindRequest_EncodedBodyFields(t *testing.T) {
+	body := `{"signature":"deadbeef","Nonce":"-_8","payload":"cmF3","name":"ada"}`
+	req := httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil, WithStrictJSON(true)).(*Ctx)
+
+	var bindReq EncodedBodyRequest
+
+	require.NoError(t, ctx.BindRequest(&bindReq))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, bindReq.Signature)
+	assert.Equal(t, []byte{0xfb, 0xff}, bindReq.Nonce)
+	assert.Equal(t, []byte("raw"), bindReq.Payload, "untagged body fields keep encoding/json's base64")
+	assert.Equal(t, "ada", bindReq.Name)
+}
+
+func TestBindRequest_EncodedBodyFields_Invalid(t *testing.T) {
+	body := `{"signature":"not hex","nonce":42,"payload":"cmF3","name":"ada"}`
+	req := httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq EncodedBodyRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.Error(t, err)
+
+	valErrors := &val.ValidationError{}
+	require.True(t, errors.As(err, &valErrors))
+
+	fieldErrs := valErrors.GetFieldErrors("signature")
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, val.ErrCodeInvalidFormat, fieldErrs[0].Code)
+
+	fieldErrs = valErrors.GetFieldErrors("nonce")
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, val.ErrCodeInvalidType, fieldErrs[0].Code)
+}
+
+func TestBindRequest_EncodedBodyFields_StrictUnknownField(t *testing.T) {
+	body := `{"signature":"00","admin":true}`
+	req := httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil, WithStrictJSON(true)).(*Ctx)
+
+	var bindReq EncodedBodyRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.ErrorIs(t, err, ErrUnknownJSONField)
+}
+
+// Test struct for untagged and base64url binary fields.
+type Base64URLBytesRequest struct {
+	Token  []byte  `query:"token"`
+	Cursor *[]byte `query:"cursor"`
+	Key    []byte  `encoding:"base64url" header:"X-Key" optional:"true"`
+}
+
+func TestBindRequest_Base64URLBytes(t *testing.T) {
+	token := []byte{0xfb, 0xff, 0xfe, 0x01}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "unpadded", query: "token=" + base64.RawURLEncoding.EncodeToString(token)},
+		{name: "padded", query: "token=" + url.QueryEscape(base64.URLEncoding.EncodeToString(token))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+			req.Header.Set("X-Key", "a2V5")
+
+			ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+			var bindReq Base64URLBytesRequest
+
+			require.NoError(t, ctx.BindRequest(&bindReq))
+			assert.Equal(t, token, bindReq.Token)
+			assert.Equal(t, []byte("key"), bindReq.Key)
+			assert.Nil(t, bindReq.Cursor)
+		})
+	}
+}
+
+func TestBindRequest_Base64URLBytes_Invalid(t *testing.T) {
+	// Standard base64 characters are not part of the URL-safe alphabet
+	req := httptest.NewRequest(http.MethodGet, "/items?token=%2B%2F8B&cursor=AQ", nil)
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq Base64URLBytesRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.Error(t, err)
+
+	valErrors := &val.ValidationError{}
+	require.True(t, errors.As(err, &valErrors))
+
+	fieldErrs := valErrors.GetFieldErrors("token")
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, val.ErrCodeInvalidFormat, fieldErrs[0].Code)
+
+	require.NotNil(t, bindReq.Cursor)
+	assert.Equal(t, []byte{0x01}, *bindReq.Cursor)
+}
+
+// Test struct for fields with several sources.
+type OverlappingSourcesRequest struct {
+	ID      string `json:"id" path:"id" query:"id"`
+	Version string `header:"X-Version" optional:"true" query:"version"`
+	Owner   string `json:"owner" query:"owner"`
+	Name    string `json:"name"`
+}
+
+func TestBindRequest_SourcePrecedence(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		query   string
+		header  string
+		body    string
+		want    OverlappingSourcesRequest
+		wantErr string
+	}{
+		{
+			name:   "path wins over query and body",
+			path:   "from-path",
+			query:  "id=from-query&version=2&owner=bob",
+			header: "3",
+			body:   `{"id":"from-body","owner":"alice","name":"widget"}`,
+			want:   OverlappingSourcesRequest{ID: "from-path", Version: "2", Owner: "bob", Name: "widget"},
+		},
+		{
+			name:   "query wins over header and body",
+			query:  "id=from-query",
+			header: "3",
+			body:   `{"id":"from-body","owner":"alice","name":"widget"}`,
+			want:   OverlappingSourcesRequest{ID: "from-query", Version: "3", Owner: "alice", Name: "widget"},
+		},
+		{
+			name: "body is used when no parameter is present",
+			body: `{"id":"from-body","owner":"alice","name":"widget"}`,
+			want: OverlappingSourcesRequest{ID: "from-body", Owner: "alice", Name: "widget"},
+		},
+		{
+			name:    "missing everywhere",
+			body:    `{"owner":"alice","name":"widget"}`,
+			wantErr: "id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/items?"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			if tt.header != "" {
+				req.Header.Set("X-Version", tt.header)
+			}
+
+			ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+			if tt.path != "" {
+				ctx.setParam("id", tt.path)
+			}
+
+			var bindReq OverlappingSourcesRequest
+
+			err := ctx.BindRequest(&bindReq)
+
+			if tt.wantErr != "" {
+				valErrors := &val.ValidationError{}
+				require.ErrorAs(t, err, &valErrors)
+				assert.True(t, valErrors.HasFieldError(tt.wantErr))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, bindReq)
+		})
+	}
+}
+
+// TaglessListRequest mixes untagged fields with tagged ones.
+type TaglessListRequest struct {
+	PageSize int
+	UserID   string
+	Cursor   *string
+	Sort     string  `query:"order_by" optional:"true"`
+	Internal *string `json:"-"`
+}
+
+func TestBindRequest_DefaultNamingStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy NamingStrategy
+		query    string
+		want     TaglessListRequest
+		wantErr  string
+	}{
+		{
+			name:     "snake case",
+			strategy: NamingSnakeCase,
+			query:    "page_size=20&user_id=u1&order_by=name&internal=x",
+			want:     TaglessListRequest{PageSize: 20, UserID: "u1", Sort: "name"},
+		},
+		{
+			name:     "camel case",
+			strategy: NamingCamelCase,
+			query:    "pageSize=20&userID=u1&page_size=30",
+			want:     TaglessListRequest{PageSize: 20, UserID: "u1"},
+		},
+		{
+			name:     "as is",
+			strategy: NamingAsIs,
+			query:    "PageSize=20",
+			want:     TaglessListRequest{PageSize: 20},
+		},
+		{
+			// Untagged non-pointer strings are required body fields then
+			name:    "untagged fields unbound by default",
+			query:   "page_size=20&PageSize=20&user_id=u1&order_by=name",
+			wantErr: "UserID",
+		},
+		{
+			name:     "explicit tag wins over derived name",
+			strategy: NamingSnakeCase,
+			query:    "sort=ignored&order_by=name",
+			want:     TaglessListRequest{Sort: "name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+			ctx := NewContext(httptest.NewRecorder(), req, nil, WithDefaultNamingStrategy(tt.strategy))
+
+			var bindReq TaglessListRequest
+
+			err := ctx.BindRequest(&bindReq)
+
+			if tt.wantErr != "" {
+				valErrors := &val.ValidationError{}
+				require.ErrorAs(t, err, &valErrors)
+				assert.True(t, valErrors.HasFieldError(tt.wantErr))
+				assert.Zero(t, bindReq.PageSize)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, bindReq)
+		})
+	}
+}
+
+func TestBindRequest_DefaultNamingStrategyRequired(t *testing.T) {
+	type request struct {
+		TenantID string `required:"true"`
+		Limit    int    `maximum:"100"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/items?limit=500", nil)
+	ctx := NewContext(httptest.NewRecorder(), req, nil, WithDefaultNamingStrategy(NamingSnakeCase))
+
+	var bindReq request
+
+	valErrors := &val.ValidationError{}
+	require.ErrorAs(t, ctx.BindRequest(&bindReq), &valErrors)
+	assert.True(t, valErrors.HasFieldError("tenant_id"))
+	assert.True(t, valErrors.HasFieldError("limit"))
+	assert.Len(t, valErrors.FieldMessages()["tenant_id"], 1)
+
+	req = httptest.NewRequest(http.MethodGet, "/items?limit=lots", nil)
+	ctx = NewContext(httptest.NewRecorder(), req, nil, WithDefaultNamingStrategy(NamingSnakeCase))
+	require.Error(t, ctx.BindRequest(&bindReq))
+}
+
+func TestNamingStrategy_ParamName(t *testing.T) {
+	tests := []struct {
+		field string
+		snake string
+		camel string
+	}{
+		{field: "PageSize", snake: "page_size", camel: "pageSize"},
+		{field: "UserID", snake: "user_id", camel: "userID"},
+		{field: "ID", snake: "id", camel: "id"},
+		{field: "HTTPServerID", snake: "http_server_id", camel: "httpServerID"},
+		{field: "Page2Size", snake: "page2_size", camel: "page2Size"},
+		{field: "name", snake: "name", camel: "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.snake, NamingSnakeCase.paramName(tt.field))
+			assert.Equal(t, tt.camel, NamingCamelCase.paramName(tt.field))
+			assert.Equal(t, tt.field, NamingAsIs.paramName(tt.field))
+			assert.Empty(t, NamingNone.paramName(tt.field))
+		})
+	}
+}
+
+func TestBindMap_Query(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/search?name=alice&age=30&score=9.5&active=true&zip=02134&tag=a&tag=b&empty=", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	result, err := ctx.BindMap()
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", result["name"])
+	assert.Equal(t, int64(30), result["age"])
+	assert.InDelta(t, 9.5, result["score"], 0.0001)
+	assert.Equal(t, true, result["active"])
+	assert.Equal(t, "02134", result["zip"])
+	assert.Equal(t, []any{"a", "b"}, result["tag"])
+	assert.Equal(t, "", result["empty"])
+}
+
+func TestBindMap_Form(t *testing.T) {
+	body := bytes.NewBufferString("limit=10&enabled=FALSE")
+	req := httptest.NewRequest(http.MethodPost, "/filters?limit=20", body)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	result, err := ctx.BindMap()
+	require.NoError(t, err)
+
+	// Body values come before query values
+	assert.Equal(t, []any{int64(10), int64(20)}, result["limit"])
+	assert.Equal(t, false, result["enabled"])
+}
+
+func TestCoerceValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{input: "true", expected: true},
+		{input: "False", expected: false},
+		{input: "0", expected: int64(0)},
+		{input: "-42", expected: int64(-42)},
+		{input: "0.5", expected: 0.5},
+		{input: "-1.25", expected: -1.25},
+		{input: "2e3", expected: 2000.0},
+		{input: "99999999999999999999", expected: 1e20},
+		{input: "007", expected: "007"},
+		{input: "+5", expected: "+5"},
+		{input: "1.", expected: "1."},
+		{input: ".5", expected: ".5"},
+		{input: "NaN", expected: "NaN"},
+		{input: "Inf", expected: "Inf"},
+		{input: "0x1F", expected: "0x1F"},
+		{input: "1_000", expected: "1_000"},
+		{input: "yes", expected: "yes"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, coerceValue(tt.input))
+		})
+	}
+}
+
+// bindMoney is a custom type with no built-in binding support.
+type bindMoney struct {
+	Cents    int64
+	Currency string
+}
+
+// bindPlan is a domain enum whose converter normalizes case.
+type bindPlan string
+
+type ConverterBindRequest struct {
+	Price    bindMoney  `path:"price"`
+	Discount *bindMoney `query:"discount"`
+	Plan     bindPlan   `enum:"free,pro" optional:"true" query:"plan"`
+}
+
+func init() {
+	RegisterBindConverter(reflect.TypeFor[bindMoney](), func(s string) (any, error) {
+		amount, currency, ok := strings.Cut(s, ":")
+		if !ok {
+			return nil, errors.New("expected amount:currency")
+		}
+
+		cents, err := strconv.ParseInt(amount, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return bindMoney{Cents: cents, Currency: currency}, nil
+	})
+
+	RegisterBindConverter(reflect.TypeFor[bindPlan](), func(s string) (any, error) {
+		return bindPlan(strings.ToLower(s)), nil
+	})
+}
+
+func TestBindRequest_Converter(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/prices/1250:USD?discount=100:USD&plan=PRO", nil)
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+	ctx.setParam("price", "1250:USD")
+
+	var bindReq ConverterBindRequest
+
+	require.NoError(t, ctx.BindRequest(&bindReq))
+
+	assert.Equal(t, bindMoney{Cents: 1250, Currency: "USD"}, bindReq.Price)
+	require.NotNil(t, bindReq.Discount)
+	assert.Equal(t, bindMoney{Cents: 100, Currency: "USD"}, *bindReq.Discount)
+	assert.Equal(t, bindPlan("pro"), bindReq.Plan)
+}
+
+func TestBindRequest_Converter_Optional(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/prices/1250:USD", nil)
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+	ctx.setParam("price", "1250:USD")
+
+	var bindReq ConverterBindRequest
+
+	require.NoError(t, ctx.BindRequest(&bindReq))
+
+	assert.Nil(t, bindReq.Discount)
+	assert.Empty(t, bindReq.Plan)
+}
+
+func TestBindRequest_Converter_Error(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/prices/free?plan=enterprise", nil)
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+	ctx.setParam("price", "free")
+
+	var bindReq ConverterBindRequest
+
+	err := ctx.BindRequest(&bindReq)
+	require.Error(t, err)
+
+	valErrors := &val.ValidationError{}
+	require.True(t, errors.As(err, &valErrors))
+
+	// The converter error is reported for the path param
+	fieldErrs := valErrors.GetFieldErrors("price")
+	require.Len(t, fieldErrs, 1)
+	assert.Equal(t, val.ErrCodeInvalidType, fieldErrs[0].Code)
+	assert.Contains(t, fieldErrs[0].Message, "expected amount:currency")
+
+	// Converted values still go through validation
+	assert.True(t, valErrors.HasFieldError("plan"))
+}
+
+func TestBindRequest_Converter_WrongType(t *testing.T) {
+	type wrongType struct{ Value string }
+
+	RegisterBindConverter(reflect.TypeFor[wrongType](), func(s string) (any, error) {
+		return s, nil
+	})
+
+	type request struct {
+		Value wrongType `query:"value"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?value=x", nil)
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	var bindReq request
+
+	err := ctx.BindRequest(&bindReq)
+
+	valErrors := &val.ValidationError{}
+	require.True(t, errors.As(err, &valErrors))
+	assert.True(t, valErrors.HasFieldError("value"))
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the minimum response size in bytes before
+// JSONCompressed compresses the body. Smaller payloads are sent as-is since
+// the compression overhead outweighs the savings.
+const DefaultCompressionThreshold = 1024
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// SetCompressionThreshold sets the minimum response size in bytes before
+// JSONCompressed compresses the body. A value <= 0 restores the default.
+func (c *Ctx) SetCompressionThreshold(size int) {
+	c.compressionThreshold = size
+}
+
+// JSONCompressed sends a JSON response, compressing it with gzip or deflate
+// when the client advertises support via Accept-Encoding and the encoded
+// body is at least the compression threshold.
+// Response struct tags are processed the same way as in JSON.
+func (c *Ctx) JSONCompressed(code int, v any) error {
+	body := ProcessResponseValueWithPolicy(v, c.SetHeader, c.sensitivePolicy())
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	header := c.response.Header()
+	header.Set("Content-Type", "application/json")
+	addVary(header, "Accept-Encoding")
+
+	threshold := c.compressionThreshold
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+
+	encoding := negotiateEncoding(c.request.Header.Get("Accept-Encoding"))
+	if encoding == "" || buf.Len() < threshold {
+		c.response.WriteHeader(code)
+
+		if _, err := c.response.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+
+		return nil
+	}
+
+	header.Set("Content-Encoding", encoding)
+	header.Del("Content-Length")
+	c.response.WriteHeader(code)
+
+	var writer io.WriteCloser
+	if encoding == encodingGzip {
+		writer = gzip.NewWriter(c.response)
+	} else {
+		writer = zlib.NewWriter(c.response)
+	}
+
+	if _, err := writer.Write(buf.Bytes()); err != nil {
+		_ = writer.Close()
+
+		return fmt.Errorf("failed to write compressed JSON: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to flush compressed JSON: %w", err)
+	}
+
+	return nil
+}
+
+// negotiateEncoding picks a supported content encoding from an Accept-Encoding
+// header value. gzip is preferred over deflate when both are equally acceptable.
+// Returns an empty string when no supported encoding is acceptable.
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		quality := 1.0
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		qualities[name] = quality
+	}
+
+	best := ""
+	bestQuality := 0.0
+
+	for _, encoding := range []string{encodingGzip, encodingDeflate} {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+
+		if ok && quality > bestQuality {
+			best = encoding
+			bestQuality = quality
+		}
+	}
+
+	return best
+}
+
+// addVary adds value to the Vary header unless it is already listed.
+func addVary(header http.Header, value string) {
+	for _, entry := range header.Values("Vary") {
+		for token := range strings.SplitSeq(entry, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), value) {
+				return
+			}
+		}
+	}
+
+	header.Add("Vary", value)
+}
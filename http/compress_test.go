@@ -0,0 +1,149 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func largePayload() map[string]string {
+	return map[string]string{"data": strings.Repeat("compressible ", 200)}
+}
+
+func TestContext_JSONCompressed_Gzip(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.JSONCompressed(http.StatusOK, largePayload())
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	var result map[string]string
+
+	require.NoError(t, json.Unmarshal(decoded, &result))
+	assert.Equal(t, largePayload()["data"], result["data"])
+}
+
+func TestContext_JSONCompressed_Deflate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Encoding", "deflate")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.JSONCompressed(http.StatusOK, largePayload())
+	require.NoError(t, err)
+
+	assert.Equal(t, "deflate", rec.Header().Get("Content-Encoding"))
+
+	reader, err := zlib.NewReader(rec.Body)
+	require.NoError(t, err)
+
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	var result map[string]string
+
+	require.NoError(t, json.Unmarshal(decoded, &result))
+	assert.Equal(t, largePayload()["data"], result["data"])
+}
+
+func TestContext_JSONCompressed_BelowThreshold(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.JSONCompressed(http.StatusOK, map[string]string{"message": "hello"})
+	require.NoError(t, err)
+
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+	var result map[string]string
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, "hello", result["message"])
+}
+
+func TestContext_JSONCompressed_CustomThreshold(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+	ctx.SetCompressionThreshold(1)
+
+	err := ctx.JSONCompressed(http.StatusOK, map[string]string{"message": "hello"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+}
+
+func TestContext_JSONCompressed_NotAccepted(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.JSONCompressed(http.StatusCreated, largePayload())
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+
+	var result map[string]string
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, largePayload()["data"], result["data"])
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "empty", header: "", expected: ""},
+		{name: "gzip", header: "gzip", expected: "gzip"},
+		{name: "deflate", header: "deflate", expected: "deflate"},
+		{name: "prefers gzip", header: "deflate, gzip", expected: "gzip"},
+		{name: "quality wins", header: "gzip;q=0.5, deflate;q=0.8", expected: "deflate"},
+		{name: "gzip disabled", header: "gzip;q=0, deflate", expected: "deflate"},
+		{name: "wildcard", header: "*", expected: "gzip"},
+		{name: "unsupported", header: "br", expected: ""},
+		{name: "identity only", header: "identity", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateEncoding(tt.header))
+		})
+	}
+}
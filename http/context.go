@@ -2,12 +2,17 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +21,35 @@ import (
 	"github.com/xraph/go-utils/metrics"
 )
 
+// streamChunkSize is the buffer size used by Stream between flushes.
+const streamChunkSize = 32 * 1024
+
+// DefaultMaxBodySize is the maximum request body size in bytes that Bind,
+// BindJSON and BindXML will read unless the context is configured otherwise.
+const DefaultMaxBodySize int64 = 4 << 20
+
+// ErrBodyTooLarge is returned when a request body exceeds the maximum body size.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// ErrUnknownJSONField is returned by BindJSON in strict mode when the body
+// contains a field the target does not have.
+var ErrUnknownJSONField = errors.New("unknown JSON field")
+
+// ErrInvalidSSEField is returned by WriteSSEEvent when the event ID or type
+// contains a line break, which would let it inject other fields or events.
+var ErrInvalidSSEField = errors.New("invalid SSE field")
+
+// ErrFileTooLarge is returned when an uploaded file exceeds the allowed size.
+var ErrFileTooLarge = errors.New("uploaded file too large")
+
+// ErrFileTypeNotAllowed is returned when an uploaded file's content type is
+// not in the allowlist.
+var ErrFileTypeNotAllowed = errors.New("uploaded file type not allowed")
+
+// multipartFormOverhead is the room FormFileLimited leaves in the request
+// body, beyond the file itself, for part headers and other form fields.
+const multipartFormOverhead = 1 << 20
+
 type Metrics = metrics.Metrics
 type HealthManager = metrics.HealthManager
 
@@ -35,6 +69,48 @@ type Ctx struct {
 	healthManager HealthManager
 	session       Session
 	sessionStore  any // Will be SessionStore interface from security extension
+
+	compressionThreshold int   // Minimum body size for JSONCompressed; 0 uses the default
+	maxBodySize          int64 // Maximum request body size for binding; 0 uses the default, < 0 disables the limit
+	strictJSON           bool  // Reject unknown fields when decoding JSON bodies
+	namingStrategy       NamingStrategy
+}
+
+// ContextOption configures a context created by NewContext.
+type ContextOption func(*Ctx)
+
+// WithMaxBodySize sets the maximum request body size in bytes read by Bind,
+// BindJSON and BindXML. Zero uses DefaultMaxBodySize and a negative value
+// disables the limit.
+func WithMaxBodySize(n int64) ContextOption {
+	return func(c *Ctx) {
+		c.maxBodySize = n
+	}
+}
+
+// WithStrictJSON makes BindJSON, and the binders built on it, reject bodies
+// containing fields the target does not have with ErrUnknownJSONField, so
+// typos in client payloads are reported instead of silently ignored. JSON
+// decoding is lenient by default.
+func WithStrictJSON(strict bool) ContextOption {
+	return func(c *Ctx) {
+		c.strictJSON = strict
+	}
+}
+
+// WithDefaultNamingStrategy makes BindRequest bind struct fields that have no
+// path, query, header, json or body tag from the query parameter named by
+// strategy, so simple structs need no tags:
+//
+//	type ListParams struct {
+//	    PageSize int // bound from ?page_size=20 with NamingSnakeCase
+//	}
+//
+// Such fields are optional. By default untagged fields are not bound.
+func WithDefaultNamingStrategy(strategy NamingStrategy) ContextOption {
+	return func(c *Ctx) {
+		c.namingStrategy = strategy
+	}
 }
 
 // httpResponseBuilder provides fluent response building.
@@ -44,7 +120,7 @@ type httpResponseBuilder struct {
 }
 
 // NewContext creates a new context.
-func NewContext(w http.ResponseWriter, r *http.Request, container di.Container) Context {
+func NewContext(w http.ResponseWriter, r *http.Request, container di.Container, opts ...ContextOption) Context {
 	var scope di.Scope
 	if container != nil {
 		scope = container.BeginScope()
@@ -59,7 +135,7 @@ func NewContext(w http.ResponseWriter, r *http.Request, container di.Container)
 		}
 	}
 
-	return &Ctx{
+	c := &Ctx{
 		request:   r,
 		response:  w,
 		params:    params,
@@ -67,6 +143,12 @@ func NewContext(w http.ResponseWriter, r *http.Request, container di.Container)
 		scope:     scope,
 		container: container,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Request returns the HTTP request.
@@ -210,40 +292,239 @@ func (c *Ctx) Bind(v any) error {
 }
 
 // BindJSON binds JSON request body.
+// Bodies larger than the maximum body size fail with ErrBodyTooLarge. With
+// WithStrictJSON, unknown fields fail with ErrUnknownJSONField naming the
+// field.
 func (c *Ctx) BindJSON(v any) error {
 	if c.request.Body == nil {
 		return errors.New("request body is nil")
 	}
 	defer c.request.Body.Close()
 
-	decoder := json.NewDecoder(c.request.Body)
+	return c.decodeJSON(c.limitBody(), v)
+}
+
+// decodeJSON decodes JSON from r into v, honoring WithStrictJSON.
+func (c *Ctx) decodeJSON(r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	if c.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+
 	if err := decoder.Decode(v); err != nil {
-		return fmt.Errorf("failed to decode JSON: %w", err)
+		// encoding/json reports unknown fields with an unexported error type
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return fmt.Errorf("%w: %s", ErrUnknownJSONField, field)
+		}
+
+		return c.bodyDecodeError("JSON", err)
 	}
 
 	return nil
 }
 
 // BindXML binds XML request body.
+// Bodies larger than the maximum body size fail with ErrBodyTooLarge.
 func (c *Ctx) BindXML(v any) error {
 	if c.request.Body == nil {
 		return errors.New("request body is nil")
 	}
 	defer c.request.Body.Close()
 
-	decoder := xml.NewDecoder(c.request.Body)
+	decoder := xml.NewDecoder(c.limitBody())
 	if err := decoder.Decode(v); err != nil {
-		return fmt.Errorf("failed to decode XML: %w", err)
+		return c.bodyDecodeError("XML", err)
 	}
 
 	return nil
 }
 
+// SetMaxBodySize sets the maximum request body size in bytes read by Bind,
+// BindJSON and BindXML. Zero restores DefaultMaxBodySize and a negative
+// value disables the limit.
+func (c *Ctx) SetMaxBodySize(n int64) {
+	c.maxBodySize = n
+}
+
+// limitBody wraps the request body so reads fail past the maximum body size.
+func (c *Ctx) limitBody() io.Reader {
+	limit := c.maxBodySize
+	if limit < 0 {
+		return c.request.Body
+	}
+
+	if limit == 0 {
+		limit = DefaultMaxBodySize
+	}
+
+	c.request.Body = http.MaxBytesReader(c.response, c.request.Body, limit)
+
+	return c.request.Body
+}
+
+// bodyDecodeError wraps a body decoding error, reporting oversized bodies
+// as ErrBodyTooLarge.
+func (c *Ctx) bodyDecodeError(format string, err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBytesErr.Limit)
+	}
+
+	return fmt.Errorf("failed to decode %s: %w", format, err)
+}
+
 // FormFile retrieves a file from a multipart form.
 func (c *Ctx) FormFile(name string) (multipart.File, *multipart.FileHeader, error) {
 	return c.request.FormFile(name)
 }
 
+// FormFileLimited retrieves a file from a multipart form, rejecting it with
+// ErrFileTooLarge if its declared size exceeds maxBytes. The returned file
+// also fails with ErrFileTooLarge when reading past maxBytes, in case the
+// declared size is wrong.
+//
+// If allowedTypes is not empty, the part's Content-Type must match one of
+// them or ErrFileTypeNotAllowed is returned. Types are compared without
+// parameters and case-insensitively; "image/*" matches any image type.
+//
+// If the form has not been parsed yet, the request body is limited to
+// maxBytes plus 1 MiB for the rest of the form before parsing, so an
+// oversized upload is never spooled to disk in full.
+func (c *Ctx) FormFileLimited(name string, maxBytes int64, allowedTypes ...string) (multipart.File, *multipart.FileHeader, error) {
+	if c.request.MultipartForm == nil {
+		c.request.Body = http.MaxBytesReader(c.response, c.request.Body, maxBytes+multipartFormOverhead)
+
+		if err := c.request.ParseMultipartForm(32 << 20); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return nil, nil, fmt.Errorf("%w: limit is %d bytes", ErrFileTooLarge, maxBytes)
+			}
+
+			return nil, nil, fmt.Errorf("failed to parse multipart form: %w", err)
+		}
+	}
+
+	file, header, err := c.request.FormFile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if header.Size > maxBytes {
+		_ = file.Close()
+
+		return nil, nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", ErrFileTooLarge, header.Filename, header.Size, maxBytes)
+	}
+
+	if len(allowedTypes) > 0 {
+		contentType := header.Header.Get("Content-Type")
+		if !contentTypeAllowed(contentType, allowedTypes) {
+			_ = file.Close()
+
+			return nil, nil, fmt.Errorf("%w: %q", ErrFileTypeNotAllowed, contentType)
+		}
+	}
+
+	return newLimitedFile(file, maxBytes), header, nil
+}
+
+// contentTypeAllowed reports whether contentType matches one of allowed.
+func contentTypeAllowed(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+
+			continue
+		}
+
+		if mediaType == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limitedFile is a multipart.File that fails with ErrFileTooLarge once more
+// than limit bytes are read from it.
+type limitedFile struct {
+	file    multipart.File
+	section *io.SectionReader
+	limit   int64
+}
+
+func newLimitedFile(file multipart.File, limit int64) *limitedFile {
+	return &limitedFile{
+		file:    file,
+		section: io.NewSectionReader(file, 0, limit+1),
+		limit:   limit,
+	}
+}
+
+func (f *limitedFile) Read(p []byte) (int, error) {
+	n, err := f.section.Read(p)
+
+	if pos, _ := f.section.Seek(0, io.SeekCurrent); pos > f.limit {
+		return n - int(pos-f.limit), ErrFileTooLarge
+	}
+
+	return n, err
+}
+
+func (f *limitedFile) ReadAt(p []byte, off int64) (int, error) {
+	n, err := f.section.ReadAt(p, off)
+
+	if end := off + int64(n); end > f.limit {
+		return max(int(f.limit-off), 0), ErrFileTooLarge
+	}
+
+	return n, err
+}
+
+func (f *limitedFile) Seek(offset int64, whence int) (int64, error) {
+	return f.section.Seek(offset, whence)
+}
+
+func (f *limitedFile) Close() error {
+	return f.file.Close()
+}
+
+// sniffLen is the number of leading bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// DetectFileContentType returns the content type of an uploaded file as
+// sniffed from its first 512 bytes by http.DetectContentType, ignoring the
+// client-supplied Content-Type header. The file is opened from fileHeader and
+// closed again, so it can still be opened and read from the start afterwards.
+func (c *Ctx) DetectFileContentType(fileHeader *multipart.FileHeader) (string, error) {
+	if fileHeader == nil {
+		return "", errors.New("file header is nil")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	buf := make([]byte, sniffLen)
+
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("failed to read %s: %w", fileHeader.Filename, err)
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
 // FormFiles retrieves multiple files with the same field name from a multipart form.
 func (c *Ctx) FormFiles(name string) ([]*multipart.FileHeader, error) {
 	if c.request.MultipartForm == nil {
@@ -295,16 +576,68 @@ func (c *Ctx) ParseMultipartForm(maxMemory int64) error {
 	return nil
 }
 
+// MultipartReader returns a reader over the parts of a multipart/form-data or
+// multipart/mixed body, for processing large uploads as they arrive instead
+// of buffering them with ParseMultipartForm. The body is read as-is, without
+// the maximum body size applied by the binders.
+//
+// Streaming and the form helpers are mutually exclusive: once the body is
+// read as a stream, FormValue, FormFile, FormFiles and ParseMultipartForm
+// find no form, and after they have parsed the form MultipartReader fails.
+func (c *Ctx) MultipartReader() (*multipart.Reader, error) {
+	reader, err := c.request.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multipart body: %w", err)
+	}
+
+	return reader, nil
+}
+
+// EachPart calls fn for every part of a multipart body, in order, without
+// buffering them, so a file can be streamed straight to its destination:
+//
+//	err := ctx.EachPart(func(part *multipart.Part) error {
+//	    if part.FormName() != "file" {
+//	        return nil
+//	    }
+//	    return bucket.Upload(ctx.Context(), part.FileName(), part)
+//	})
+//
+// Each part is only readable until fn returns; unread data is skipped.
+// Iteration stops at the first error from fn, which is returned. Like
+// MultipartReader, EachPart cannot be combined with the form helpers.
+func (c *Ctx) EachPart(fn func(part *multipart.Part) error) error {
+	reader, err := c.MultipartReader()
+	if err != nil {
+		return err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read multipart part: %w", err)
+		}
+
+		err = fn(part)
+		_ = part.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // JSON sends JSON response.
 // If v is a struct with header:"..." tags, those headers are set automatically.
 // If v has a field with body:"" tag, that field's value is serialized instead of the whole struct.
 // If the route has sensitive field cleaning enabled, fields with sensitive:"..." tags are processed.
 func (c *Ctx) JSON(code int, v any) error {
-	// Check if sensitive field cleaning is enabled for this route
-	cleanSensitive := c.shouldCleanSensitiveFields()
-
 	// Process response to handle header, body, and sensitive tags
-	body := ProcessResponseValueWithSensitive(v, c.SetHeader, cleanSensitive)
+	body := ProcessResponseValueWithPolicy(v, c.SetHeader, c.sensitivePolicy())
 
 	c.response.Header().Set("Content-Type", "application/json")
 	c.response.WriteHeader(code)
@@ -317,19 +650,56 @@ func (c *Ctx) JSON(code int, v any) error {
 	return nil
 }
 
-// shouldCleanSensitiveFields checks if sensitive field cleaning is enabled for this route.
-// It checks both the forge context values and the request context for the flag.
-func (c *Ctx) shouldCleanSensitiveFields() bool {
-	// Check forge context values first
-	if val := c.Get("forge:sensitive_field_cleaning"); val != nil {
-		if enabled, ok := val.(bool); ok && enabled {
-			return true
-		}
+// JSONWithETag sends a JSON response like JSON, with a weak ETag computed
+// from a hash of the encoded body. If the request is a GET or HEAD whose
+// If-None-Match matches the ETag and code is a 2xx status, 304 Not Modified is
+// sent with no body instead.
+func (c *Ctx) JSONWithETag(code int, v any) error {
+	body := ProcessResponseValueWithPolicy(v, c.SetHeader, c.sensitivePolicy())
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
-	// Check request context (for cases where handler creates a new forge context)
-	if val := c.request.Context().Value(ContextKeyForSensitiveCleaning); val != nil {
-		if enabled, ok := val.(bool); ok && enabled {
+	// Match the output of JSON, which ends with a newline
+	data = append(data, '\n')
+
+	sum := sha256.Sum256(data)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.response.Header().Set("ETag", etag)
+
+	if code >= 200 && code < 300 &&
+		(c.request.Method == http.MethodGet || c.request.Method == http.MethodHead) &&
+		etagMatches(c.request.Header.Get("If-None-Match"), etag) {
+		c.response.WriteHeader(http.StatusNotModified)
+
+		return nil
+	}
+
+	c.response.Header().Set("Content-Type", "application/json")
+	c.response.WriteHeader(code)
+
+	if _, err := c.response.Write(data); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+
+	return nil
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// the weak comparison RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
 			return true
 		}
 	}
@@ -337,6 +707,20 @@ func (c *Ctx) shouldCleanSensitiveFields() bool {
 	return false
 }
 
+// sensitivePolicy returns the sensitive field policy for this route.
+// It checks both the forge context values and the request context, accepting
+// either a SensitivePolicy or a boolean flag (true means redact). A policy set
+// on the forge context wins, even when it turns cleaning off.
+func (c *Ctx) sensitivePolicy() SensitivePolicy {
+	// Check forge context values first
+	if v, ok := c.values["forge:sensitive_field_cleaning"]; ok {
+		return sensitivePolicyFromValue(v)
+	}
+
+	// Check request context (for cases where handler creates a new forge context)
+	return sensitivePolicyFromValue(c.request.Context().Value(ContextKeyForSensitiveCleaning))
+}
+
 // XML sends XML response.
 func (c *Ctx) XML(code int, v any) error {
 	c.response.Header().Set("Content-Type", "application/xml")
@@ -375,6 +759,64 @@ func (c *Ctx) Bytes(code int, data []byte) error {
 	return nil
 }
 
+// Attachment sends data as a file download.
+// Content-Disposition is set with the given filename (RFC 5987 encoded when it
+// contains non-ASCII characters), Content-Type is inferred from the file
+// extension, and Content-Length is set from the data size.
+func (c *Ctx) Attachment(code int, filename string, data []byte) error {
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.response.Header().Set("Content-Type", contentType)
+	c.response.Header().Set("Content-Disposition", contentDisposition("attachment", filename))
+	c.response.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	c.response.WriteHeader(code)
+
+	_, err := c.response.Write(data)
+	if err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+
+	return nil
+}
+
+// Stream copies the reader to the response with the given content type.
+// The response is flushed after each chunk when the writer supports flushing,
+// so clients receive data as it is read.
+func (c *Ctx) Stream(code int, contentType string, r io.Reader) error {
+	if contentType != "" {
+		c.response.Header().Set("Content-Type", contentType)
+	}
+
+	c.response.WriteHeader(code)
+
+	flusher, canFlush := c.response.(http.Flusher)
+	buf := make([]byte, streamChunkSize)
+
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			if _, err := c.response.Write(buf[:n]); err != nil {
+				return fmt.Errorf("failed to write stream: %w", err)
+			}
+
+			if canFlush {
+				flusher.Flush()
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+
+		if readErr != nil {
+			return fmt.Errorf("failed to read stream: %w", readErr)
+		}
+	}
+}
+
 // NoContent sends no content response.
 func (c *Ctx) NoContent(code int) error {
 	c.response.WriteHeader(code)
@@ -393,21 +835,56 @@ func (c *Ctx) Redirect(code int, url string) error {
 	return nil
 }
 
+// SSEEvent is a Server-Sent Event with optional resumption and reconnection fields.
+type SSEEvent struct {
+	// ID sets the event ID clients send back in Last-Event-ID when reconnecting.
+	ID string
+	// Event is the event type. Empty means the default "message" type.
+	Event string
+	// Data is the event payload. Strings and byte slices are sent as-is;
+	// other types are marshaled to JSON.
+	Data any
+	// Retry tells the client how long to wait before reconnecting.
+	// Sent in milliseconds; zero omits the field.
+	Retry time.Duration
+}
+
+// sseLineBreaks normalizes the line breaks of the SSE spec to "\n".
+var sseLineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
 // WriteSSE writes a Server-Sent Event with automatic content type detection.
 // For string data, sends as-is. For other types, marshals to JSON.
 // Automatically flushes after writing.
 func (c *Ctx) WriteSSE(event string, data any) error {
+	return c.WriteSSEEvent(SSEEvent{Event: event, Data: data})
+}
+
+// WriteSSEEvent writes a Server-Sent Event including optional id and retry fields.
+// Fields are written in the order id, event, retry, data. Multi-line data is
+// split into one data line per line as required by the SSE spec, treating
+// "\r\n", "\r" and "\n" as line breaks. An ID or event type containing a
+// line break, or an ID containing NUL, is rejected with ErrInvalidSSEField.
+// Automatically flushes after writing.
+func (c *Ctx) WriteSSEEvent(ev SSEEvent) error {
+	if strings.ContainsAny(ev.ID, "\r\n\x00") {
+		return fmt.Errorf("%w: id %q", ErrInvalidSSEField, ev.ID)
+	}
+
+	if strings.ContainsAny(ev.Event, "\r\n") {
+		return fmt.Errorf("%w: event %q", ErrInvalidSSEField, ev.Event)
+	}
+
 	var dataStr string
 
 	// Auto-detect data type
-	switch v := data.(type) {
+	switch v := ev.Data.(type) {
 	case string:
 		dataStr = v
 	case []byte:
 		dataStr = string(v)
 	default:
 		// Marshal to JSON for non-string types
-		jsonData, err := json.Marshal(data)
+		jsonData, err := json.Marshal(ev.Data)
 		if err != nil {
 			return fmt.Errorf("failed to marshal SSE data to JSON: %w", err)
 		}
@@ -417,15 +894,32 @@ func (c *Ctx) WriteSSE(event string, data any) error {
 
 	// Format SSE event
 	var builder strings.Builder
-	if event != "" {
+	if ev.ID != "" {
+		builder.WriteString("id: ")
+		builder.WriteString(ev.ID)
+		builder.WriteString("\n")
+	}
+
+	if ev.Event != "" {
 		builder.WriteString("event: ")
-		builder.WriteString(event)
+		builder.WriteString(ev.Event)
+		builder.WriteString("\n")
+	}
+
+	if ev.Retry > 0 {
+		builder.WriteString("retry: ")
+		builder.WriteString(strconv.FormatInt(ev.Retry.Milliseconds(), 10))
+		builder.WriteString("\n")
+	}
+
+	dataStr = sseLineBreaks.Replace(dataStr)
+	for line := range strings.SplitSeq(dataStr, "\n") {
+		builder.WriteString("data: ")
+		builder.WriteString(line)
 		builder.WriteString("\n")
 	}
 
-	builder.WriteString("data: ")
-	builder.WriteString(dataStr)
-	builder.WriteString("\n\n")
+	builder.WriteString("\n")
 
 	// Write to response
 	_, err := c.response.Write([]byte(builder.String()))
@@ -460,6 +954,14 @@ func (c *Ctx) SetHeader(key, value string) {
 	c.response.Header().Set(key, value)
 }
 
+// SetHeadersFrom sets response headers from the header:"..." tagged fields of
+// a struct, using the same rules as JSON responses. Zero-valued fields are
+// skipped and non-struct values are ignored. Useful before bodiless responses
+// such as NoContent.
+func (c *Ctx) SetHeadersFrom(v any) {
+	ProcessResponseValue(v, c.SetHeader)
+}
+
 // processResponseValue handles response struct tags using shared logic.
 // - Sets header:"..." fields as HTTP response headers
 // - Unwraps body:"" fields to return just the body content
@@ -488,6 +990,24 @@ func (c *Ctx) MustGet(key string) any {
 	return val
 }
 
+// SetTyped stores v in the context under key. It is Set with the type of
+// the value checked at compile time, to be read back with GetTyped:
+//
+//	http.SetTyped(ctx, "user", user) // in middleware
+//
+//	user, ok := http.GetTyped[*User](ctx, "user") // in the handler
+func SetTyped[T any](c Context, key string, v T) {
+	c.Set(key, v)
+}
+
+// GetTyped returns the value stored under key as a T. It returns the zero
+// value and false if no value is stored under key or it is not a T.
+func GetTyped[T any](c Context, key string) (T, bool) {
+	v, ok := c.Get(key).(T)
+
+	return v, ok
+}
+
 // Context returns the request context.
 func (c *Ctx) Context() context.Context {
 	return c.request.Context()
@@ -774,11 +1294,8 @@ func (c *Ctx) Status(code int) ResponseBuilder {
 
 // JSON sends a JSON response with the configured status.
 func (rb *httpResponseBuilder) JSON(v any) error {
-	// Check if sensitive field cleaning is enabled for this route
-	cleanSensitive := rb.ctx.shouldCleanSensitiveFields()
-
 	// Process response to handle header, body, and sensitive tags
-	body := ProcessResponseValueWithSensitive(v, rb.ctx.SetHeader, cleanSensitive)
+	body := ProcessResponseValueWithPolicy(v, rb.ctx.SetHeader, rb.ctx.sensitivePolicy())
 
 	rb.ctx.response.Header().Set("Content-Type", "application/json")
 	rb.ctx.response.WriteHeader(rb.status)
@@ -842,3 +1359,68 @@ func (rb *httpResponseBuilder) NoContent() error {
 
 	return nil
 }
+
+// contentDisposition builds a Content-Disposition header value for filename.
+// ASCII names are sent as a quoted filename parameter. Names with non-ASCII
+// characters also get an RFC 5987 filename* parameter, with an ASCII fallback
+// for clients that don't support it.
+func contentDisposition(dispositionType, filename string) string {
+	filename = filepath.Base(filename)
+
+	var fallback strings.Builder
+
+	ascii := true
+
+	for _, r := range filename {
+		switch {
+		case r > 0x7e || r < 0x20:
+			ascii = false
+
+			fallback.WriteByte('_')
+		case r == '"' || r == '\\':
+			fallback.WriteByte('\\')
+			fallback.WriteRune(r)
+		default:
+			fallback.WriteRune(r)
+		}
+	}
+
+	value := dispositionType + `; filename="` + fallback.String() + `"`
+	if !ascii {
+		value += "; filename*=UTF-8''" + encodeRFC5987(filename)
+	}
+
+	return value
+}
+
+// encodeRFC5987 percent-encodes s for use in an RFC 5987 ext-value.
+func encodeRFC5987(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var builder strings.Builder
+
+	for i := range len(s) {
+		b := s[i]
+		if isRFC5987AttrChar(b) {
+			builder.WriteByte(b)
+
+			continue
+		}
+
+		builder.WriteByte('%')
+		builder.WriteByte(hexDigits[b>>4])
+		builder.WriteByte(hexDigits[b&0x0f])
+	}
+
+	return builder.String()
+}
+
+// isRFC5987AttrChar reports whether b may appear unencoded in an RFC 5987 value.
+func isRFC5987AttrChar(b byte) bool {
+	switch {
+	case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9':
+		return true
+	}
+
+	return strings.IndexByte("!#$&+-.^_`|~", b) >= 0
+}
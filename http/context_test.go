@@ -5,10 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"runtime"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -138,6 +145,120 @@ func TestContext_BindJSON_NilBody(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// jsonBodyOfSize returns a JSON object body of exactly size bytes.
+func jsonBodyOfSize(size int) []byte {
+	const wrapper = `{"name":""}`
+
+	return []byte(`{"name":"` + strings.Repeat("a", size-len(wrapper)) + `"}`)
+}
+
+func TestContext_BindJSON_MaxBodySize(t *testing.T) {
+	const limit = 1024
+
+	t.Run("just under the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(jsonBodyOfSize(limit)))
+		ctx := NewContext(httptest.NewRecorder(), req, nil, WithMaxBodySize(limit))
+
+		var data map[string]string
+
+		require.NoError(t, ctx.BindJSON(&data))
+		assert.Len(t, data["name"], limit-len(`{"name":""}`))
+	})
+
+	t.Run("just over the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(jsonBodyOfSize(limit+1)))
+		ctx := NewContext(httptest.NewRecorder(), req, nil, WithMaxBodySize(limit))
+
+		var data map[string]string
+
+		err := ctx.BindJSON(&data)
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+		assert.Contains(t, err.Error(), "request body too large")
+	})
+
+	t.Run("SetMaxBodySize", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(jsonBodyOfSize(limit+1)))
+		ctx := NewContext(httptest.NewRecorder(), req, nil)
+		ctx.SetMaxBodySize(limit)
+
+		var data map[string]string
+
+		require.ErrorIs(t, ctx.Bind(&data), ErrBodyTooLarge)
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(jsonBodyOfSize(int(DefaultMaxBodySize)+1)))
+		ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+		var data map[string]string
+
+		require.ErrorIs(t, ctx.BindJSON(&data), ErrBodyTooLarge)
+	})
+
+	t.Run("negative limit disables the check", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(jsonBodyOfSize(int(DefaultMaxBodySize)+1)))
+		ctx := NewContext(httptest.NewRecorder(), req, nil, WithMaxBodySize(-1))
+
+		var data map[string]string
+
+		require.NoError(t, ctx.BindJSON(&data))
+	})
+}
+
+func TestContext_BindJSON_StrictJSON(t *testing.T) {
+	type TestRequest struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+
+	const body = `{"name":"John","emial":"john@example.com"}`
+
+	t.Run("lenient by default", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+		var tr TestRequest
+
+		require.NoError(t, ctx.BindJSON(&tr))
+		assert.Equal(t, "John", tr.Name)
+		assert.Empty(t, tr.Email)
+	})
+
+	t.Run("strict rejects unknown fields", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		ctx := NewContext(httptest.NewRecorder(), req, nil, WithStrictJSON(true))
+
+		var tr TestRequest
+
+		err := ctx.BindJSON(&tr)
+		require.ErrorIs(t, err, ErrUnknownJSONField)
+		assert.Contains(t, err.Error(), `"emial"`)
+	})
+
+	t.Run("strict accepts known fields", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := NewContext(httptest.NewRecorder(), req, nil, WithStrictJSON(true))
+
+		var tr TestRequest
+
+		require.NoError(t, ctx.Bind(&tr))
+		assert.Equal(t, "John", tr.Name)
+	})
+}
+
+func TestContext_BindXML_MaxBodySize(t *testing.T) {
+	body := `<request><name>` + strings.Repeat("a", 2048) + `</name></request>`
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+	ctx := NewContext(httptest.NewRecorder(), req, nil, WithMaxBodySize(1024))
+
+	var data struct {
+		Name string `xml:"name"`
+	}
+
+	require.ErrorIs(t, ctx.BindXML(&data), ErrBodyTooLarge)
+}
+
 func TestContext_BindXML(t *testing.T) {
 	type TestRequest struct {
 		XMLName xml.Name `xml:"request"`
@@ -238,6 +359,76 @@ func TestContext_JSON(t *testing.T) {
 	assert.Equal(t, "hello", result["message"])
 }
 
+func TestContext_JSONWithETag(t *testing.T) {
+	data := map[string]string{"message": "hello"}
+
+	// Cache miss: full response with an ETag
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, NewContext(rec, req, nil).JSONWithETag(http.StatusOK, data))
+
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(etag, `W/"`), etag)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"message":"hello"}`, rec.Body.String())
+
+	// The body matches what JSON would have sent
+	plain := httptest.NewRecorder()
+	require.NoError(t, NewContext(plain, httptest.NewRequest(http.MethodGet, "/test", nil), nil).JSON(http.StatusOK, data))
+	assert.Equal(t, plain.Body.String(), rec.Body.String())
+
+	// Matching If-None-Match: 304 without a body
+	req = httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("If-None-Match", etag)
+
+	rec = httptest.NewRecorder()
+
+	require.NoError(t, NewContext(rec, req, nil).JSONWithETag(http.StatusOK, data))
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestContext_JSONWithETag_Conditions(t *testing.T) {
+	data := map[string]string{"message": "hello"}
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, NewContext(rec, httptest.NewRequest(http.MethodGet, "/test", nil), nil).JSONWithETag(http.StatusOK, data))
+
+	etag := rec.Header().Get("ETag")
+	strong := strings.TrimPrefix(etag, "W/")
+
+	tests := []struct {
+		name        string
+		method      string
+		code        int
+		ifNoneMatch string
+		wantCode    int
+	}{
+		{name: "different etag", method: http.MethodGet, code: http.StatusOK, ifNoneMatch: `W/"other"`, wantCode: http.StatusOK},
+		{name: "etag in list", method: http.MethodGet, code: http.StatusOK, ifNoneMatch: `"other", ` + etag, wantCode: http.StatusNotModified},
+		{name: "strong form matches weakly", method: http.MethodGet, code: http.StatusOK, ifNoneMatch: strong, wantCode: http.StatusNotModified},
+		{name: "wildcard", method: http.MethodHead, code: http.StatusOK, ifNoneMatch: "*", wantCode: http.StatusNotModified},
+		{name: "unsafe method", method: http.MethodPost, code: http.StatusOK, ifNoneMatch: etag, wantCode: http.StatusOK},
+		{name: "error status", method: http.MethodGet, code: http.StatusNotFound, ifNoneMatch: etag, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/test", nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, NewContext(rec, req, nil).JSONWithETag(tt.code, data))
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
 func TestContext_XML(t *testing.T) {
 	type TestResponse struct {
 		XMLName xml.Name `xml:"response"`
@@ -285,6 +476,102 @@ func TestContext_Bytes(t *testing.T) {
 	assert.Equal(t, "binary data", rec.Body.String())
 }
 
+func TestContext_Attachment(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	data := []byte("id,name\n1,alice\n")
+	err := ctx.Attachment(http.StatusOK, "report.csv", data)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `attachment; filename="report.csv"`, rec.Header().Get("Content-Disposition"))
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/csv")
+	assert.Equal(t, "16", rec.Header().Get("Content-Length"))
+	assert.Equal(t, data, rec.Body.Bytes())
+}
+
+func TestContext_Attachment_UnknownExtension(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.Attachment(http.StatusOK, "blob.unknownext", []byte{0x01})
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+}
+
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{
+			name:     "ascii",
+			filename: "report.pdf",
+			expected: `attachment; filename="report.pdf"`,
+		},
+		{
+			name:     "quotes escaped",
+			filename: `my "file".txt`,
+			expected: `attachment; filename="my \"file\".txt"`,
+		},
+		{
+			name:     "non-ascii",
+			filename: "résumé.pdf",
+			expected: `attachment; filename="r_sum_.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`,
+		},
+		{
+			name:     "spaces encoded in extended value",
+			filename: "日本 語.txt",
+			expected: `attachment; filename="__ _.txt"; filename*=UTF-8''%E6%97%A5%E6%9C%AC%20%E8%AA%9E.txt`,
+		},
+		{
+			name:     "path stripped",
+			filename: "../../etc/passwd",
+			expected: `attachment; filename="passwd"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, contentDisposition("attachment", tt.filename))
+		})
+	}
+}
+
+func TestContext_Stream(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	payload := bytes.Repeat([]byte("chunk"), streamChunkSize)
+	err := ctx.Stream(http.StatusOK, "application/octet-stream", bytes.NewReader(payload))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, payload, rec.Body.Bytes())
+	assert.True(t, rec.flushed)
+}
+
+func TestContext_Stream_ReadError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.Stream(http.StatusOK, "text/plain", iotest.ErrReader(errors.New("boom")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read stream")
+}
+
 func TestContext_NoContent(t *testing.T) {
 	req := httptest.NewRequest(http.MethodDelete, "/test", nil)
 	rec := httptest.NewRecorder()
@@ -343,6 +630,33 @@ func TestContext_SetHeader(t *testing.T) {
 	assert.Equal(t, "value", rec.Header().Get("X-Custom"))
 }
 
+func TestContext_SetHeadersFrom(t *testing.T) {
+	type RateLimitHeaders struct {
+		Limit     int    `header:"X-RateLimit-Limit"`
+		Remaining int    `header:"X-RateLimit-Remaining"`
+		Reset     string `header:"X-RateLimit-Reset"`
+		Internal  string
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	ctx.SetHeadersFrom(&RateLimitHeaders{Limit: 100, Remaining: 42, Internal: "x"})
+	require.NoError(t, ctx.NoContent(http.StatusNoContent))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "100", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "42", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Empty(t, rec.Header().Values("X-RateLimit-Reset"))
+	assert.Empty(t, rec.Body.String())
+
+	// Non-struct values are ignored
+	ctx.SetHeadersFrom("not a struct")
+	ctx.SetHeadersFrom(nil)
+}
+
 func TestContext_SetGet(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
 	rec := httptest.NewRecorder()
@@ -375,6 +689,48 @@ func TestContext_MustGet_Panic(t *testing.T) {
 	})
 }
 
+func TestContext_GetTyped(t *testing.T) {
+	type user struct {
+		Name string
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	SetTyped(ctx, "user", &user{Name: "alice"})
+	SetTyped(ctx, "attempts", 3)
+
+	// Hit
+	got, ok := GetTyped[*user](ctx, "user")
+	require.True(t, ok)
+	assert.Equal(t, "alice", got.Name)
+
+	attempts, ok := GetTyped[int](ctx, "attempts")
+	require.True(t, ok)
+	assert.Equal(t, 3, attempts)
+
+	// Miss
+	got, ok = GetTyped[*user](ctx, "missing")
+	assert.False(t, ok)
+	assert.Nil(t, got)
+
+	// Wrong type
+	name, ok := GetTyped[string](ctx, "attempts")
+	assert.False(t, ok)
+	assert.Empty(t, name)
+
+	other, ok := GetTyped[user](ctx, "user")
+	assert.False(t, ok)
+	assert.Equal(t, user{}, other)
+
+	// A typed nil is found
+	SetTyped[*user](ctx, "anonymous", nil)
+
+	got, ok = GetTyped[*user](ctx, "anonymous")
+	assert.True(t, ok)
+	assert.Nil(t, got)
+}
+
 func TestContext_Context(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
 	rec := httptest.NewRecorder()
@@ -598,6 +954,169 @@ func TestContext_FormFiles_NotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// newUploadRequest builds a multipart request with a single file part.
+func newUploadRequest(t *testing.T, field, filename, contentType string, content []byte) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="`+field+`"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestContext_FormFileLimited(t *testing.T) {
+	req := newUploadRequest(t, "avatar", "me.png", "image/png", []byte("png bytes"))
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	file, header, err := ctx.FormFileLimited("avatar", 1024, "image/jpeg", "image/png")
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "png bytes", string(content))
+	assert.Equal(t, "me.png", header.Filename)
+}
+
+func TestContext_FormFileLimited_TooLarge(t *testing.T) {
+	req := newUploadRequest(t, "avatar", "big.png", "image/png", bytes.Repeat([]byte("x"), 2048))
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	_, _, err := ctx.FormFileLimited("avatar", 1024)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+}
+
+func TestContext_FormFileLimited_BodyTooLarge(t *testing.T) {
+	// The body limit trips while parsing, before the file is spooled
+	req := newUploadRequest(t, "avatar", "huge.bin", "application/octet-stream",
+		bytes.Repeat([]byte("x"), multipartFormOverhead+4096))
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	_, _, err := ctx.FormFileLimited("avatar", 1024)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+}
+
+func TestContext_FormFileLimited_DisallowedType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		allowed     []string
+		wantErr     bool
+	}{
+		{name: "exact match", contentType: "image/png", allowed: []string{"image/png"}},
+		{name: "case and parameters", contentType: "Text/Plain; charset=utf-8", allowed: []string{"text/plain"}},
+		{name: "wildcard", contentType: "image/webp", allowed: []string{"image/*"}},
+		{name: "not allowed", contentType: "application/x-msdownload", allowed: []string{"image/*"}, wantErr: true},
+		{name: "wildcard prefix only", contentType: "imagex/png", allowed: []string{"image/*"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newUploadRequest(t, "file", "upload", tt.contentType, []byte("data"))
+			ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+			file, _, err := ctx.FormFileLimited("file", 1024, tt.allowed...)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrFileTypeNotAllowed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			file.Close()
+		})
+	}
+}
+
+func TestContext_DetectFileContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name       string
+		declared   string
+		content    []byte
+		wantType   string
+		wantPrefix string
+	}{
+		{name: "png declared as text", declared: "text/plain", content: png, wantType: "image/png"},
+		{name: "text declared as png", declared: "image/png", content: []byte("just some text"), wantPrefix: "text/plain"},
+		{name: "executable declared as png", declared: "image/png", content: []byte("MZ\x90\x00\x03\x00\x00\x00"), wantType: "application/octet-stream"},
+		{name: "empty file", declared: "image/png", content: []byte{}, wantPrefix: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newUploadRequest(t, "file", "upload.png", tt.declared, tt.content)
+			ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+			file, header, err := ctx.FormFile("file")
+			require.NoError(t, err)
+
+			defer file.Close()
+
+			contentType, err := ctx.DetectFileContentType(header)
+			require.NoError(t, err)
+
+			if tt.wantType != "" {
+				assert.Equal(t, tt.wantType, contentType)
+			} else {
+				assert.True(t, strings.HasPrefix(contentType, tt.wantPrefix), contentType)
+			}
+
+			// The file is still readable from the start
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, content)
+		})
+	}
+}
+
+// nopCloseFile adapts a bytes.Reader to multipart.File.
+type nopCloseFile struct {
+	*bytes.Reader
+}
+
+func (nopCloseFile) Close() error { return nil }
+
+func TestLimitedFile(t *testing.T) {
+	// A file larger than its declared size is cut off at the limit
+	file := newLimitedFile(nopCloseFile{bytes.NewReader([]byte("0123456789"))}, 4)
+
+	content, err := io.ReadAll(file)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, "0123", string(content))
+
+	buf := make([]byte, 4)
+	n, err := file.ReadAt(buf, 2)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, "23", string(buf[:n]))
+
+	n, err = file.ReadAt(buf[:2], 0)
+	require.NoError(t, err)
+	assert.Equal(t, "01", string(buf[:n]))
+
+	// A file within the limit reads normally
+	within := newLimitedFile(nopCloseFile{bytes.NewReader([]byte("0123"))}, 4)
+
+	content, err = io.ReadAll(within)
+	require.NoError(t, err)
+	assert.Equal(t, "0123", string(content))
+}
+
 func TestContext_FormValue(t *testing.T) {
 	// Create multipart form with fields
 	body := &bytes.Buffer{}
@@ -707,6 +1226,133 @@ func TestContext_ParseMultipartForm_InvalidForm(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestContext_EachPart(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	require.NoError(t, writer.WriteField("title", "report"))
+
+	part, err := writer.CreateFormFile("file", "report.txt")
+	require.NoError(t, err)
+
+	_, err = part.Write([]byte("file content"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	got := make(map[string]string)
+
+	err = ctx.EachPart(func(part *multipart.Part) error {
+		data, err := io.ReadAll(part)
+		got[part.FormName()+":"+part.FileName()] = string(data)
+
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"title:":          "report",
+		"file:report.txt": "file content",
+	}, got)
+
+	// The body was consumed as a stream
+	assert.Empty(t, ctx.FormValue("title"))
+}
+
+func TestContext_EachPart_StopsOnError(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	require.NoError(t, writer.WriteField("first", "1"))
+	require.NoError(t, writer.WriteField("second", "2"))
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	stop := errors.New("stop")
+	calls := 0
+
+	err := ctx.EachPart(func(part *multipart.Part) error {
+		calls++
+
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestContext_EachPart_NotMultipart(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	require.ErrorIs(t, ctx.EachPart(func(*multipart.Part) error { return nil }), http.ErrNotMultipart)
+}
+
+func TestContext_EachPart_StreamsLargePart(t *testing.T) {
+	const size = 64 << 20
+
+	// Generate the upload while it is read, so it is never held in memory
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := writer.CreateFormFile("file", "large.bin")
+		if err == nil {
+			_, err = io.CopyN(part, zeroReader{}, size)
+		}
+
+		if err == nil {
+			err = writer.Close()
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", pr)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil)
+
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	var received int64
+
+	err := ctx.EachPart(func(part *multipart.Part) error {
+		n, err := io.Copy(io.Discard, part)
+		received += n
+
+		return err
+	})
+	require.NoError(t, err)
+
+	runtime.ReadMemStats(&after)
+
+	assert.Equal(t, int64(size), received)
+	// Allocation stays far below the part size
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(size/8))
+}
+
+// zeroReader is an endless source of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+
+	return len(p), nil
+}
+
 func TestContext_Bind_MultipartForm(t *testing.T) {
 	// Create multipart form
 	body := &bytes.Buffer{}
@@ -1004,6 +1650,91 @@ func TestContext_WriteSSE_InvalidJSON(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to marshal SSE data to JSON")
 }
 
+func TestContext_WriteSSEEvent_AllFields(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.WriteSSEEvent(SSEEvent{
+		ID:    "42",
+		Event: "update",
+		Data:  map[string]int{"count": 1},
+		Retry: 3 * time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "id: 42\nevent: update\nretry: 3000\ndata: {\"count\":1}\n\n", rec.Body.String())
+	assert.True(t, rec.flushed)
+}
+
+func TestContext_WriteSSEEvent_MultiLineData(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.WriteSSEEvent(SSEEvent{Data: "line one\nline two\r\nline three\rline four"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "data: line one\ndata: line two\ndata: line three\ndata: line four\n\n", rec.Body.String())
+}
+
+func TestContext_WriteSSEEvent_RejectsInjection(t *testing.T) {
+	tests := []struct {
+		name string
+		ev   SSEEvent
+	}{
+		{"id with newline", SSEEvent{ID: "1\nevent: admin", Data: "x"}},
+		{"id with carriage return", SSEEvent{ID: "1\rdata: forged", Data: "x"}},
+		{"id with NUL", SSEEvent{ID: "1\x00", Data: "x"}},
+		{"event with newline", SSEEvent{Event: "update\n\ndata: forged", Data: "x"}},
+		{"event with carriage return", SSEEvent{Event: "update\rretry: 1", Data: "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/events", nil)
+			rec := newFlushableRecorder()
+
+			ctx := NewContext(rec, req, nil)
+
+			err := ctx.WriteSSEEvent(tt.ev)
+			require.ErrorIs(t, err, ErrInvalidSSEField)
+			assert.Empty(t, rec.Body.String())
+		})
+	}
+}
+
+func TestContext_WriteSSEEvent_DataCannotInjectFields(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	// A lone CR is a line break, so it starts a new data line rather than a field
+	err := ctx.WriteSSEEvent(SSEEvent{Data: "ok\revent: admin\r\rdata: forged"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "data: ok\ndata: event: admin\ndata: \ndata: data: forged\n\n", rec.Body.String())
+}
+
+func TestContext_WriteSSEEvent_OmitsEmptyFields(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	err := ctx.WriteSSEEvent(SSEEvent{Data: "hello"})
+	require.NoError(t, err)
+
+	output := rec.Body.String()
+	assert.NotContains(t, output, "id:")
+	assert.NotContains(t, output, "event:")
+	assert.NotContains(t, output, "retry:")
+	assert.Equal(t, "data: hello\n\n", output)
+}
+
 func TestContext_Flush_Success(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/events", nil)
 	rec := newFlushableRecorder()
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/xraph/go-utils/val"
+)
+
+// Handle adapts a typed handler to an http.HandlerFunc. For every request it
+// creates a Ctx, binds and validates a Req with BindRequest, calls fn and
+// writes the returned Res as a 200 JSON response:
+//
+//	mux.Handle("POST /users", http.Handle(
+//	    func(ctx http.Context, req CreateUserRequest) (UserResponse, error) {
+//	        return users.Create(ctx.Context(), req)
+//	    },
+//	))
+//
+// A *val.ValidationError, from binding or returned by fn, is answered with a
+// 422 problem built by NewValidationProblem. A body larger than the maximum
+// body size is answered with 413 and a body that cannot be decoded, such as
+// malformed JSON or, in strict mode, an unknown field, with a 400 problem
+// describing the decoding error. Any other error is answered with a 500
+// problem that does not expose the error. The context is created with opts
+// and cleaned up once fn returns.
+func Handle[Req any, Res any](fn func(ctx Context, req Req) (Res, error), opts ...ContextOption) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(w, r, nil, opts...)
+		if c, ok := ctx.(ContextWithClean); ok {
+			defer c.Cleanup()
+		}
+
+		var req Req
+		if err := ctx.BindRequest(&req); err != nil {
+			_ = writeBindError(ctx, err)
+
+			return
+		}
+
+		res, err := fn(ctx, req)
+		if err != nil {
+			_ = writeHandlerError(ctx, err)
+
+			return
+		}
+
+		_ = ctx.JSON(http.StatusOK, res)
+	}
+}
+
+// writeBindError answers an error of BindRequest. Request body errors are
+// client errors; anything else is handled by writeHandlerError.
+func writeBindError(ctx Context, err error) error {
+	switch {
+	case errors.Is(err, ErrBodyTooLarge):
+		return ctx.Problem(http.StatusRequestEntityTooLarge, Problem{Detail: ErrBodyTooLarge.Error()})
+	case errors.Is(err, errBindBody):
+		return ctx.Problem(http.StatusBadRequest, Problem{Detail: err.Error()})
+	default:
+		return writeHandlerError(ctx, err)
+	}
+}
+
+// writeHandlerError answers err with a 422 validation problem or, for any
+// other error, a generic 500 problem.
+func writeHandlerError(ctx Context, err error) error {
+	var validationErr *val.ValidationError
+	if errors.As(err, &validationErr) {
+		return ctx.Problem(http.StatusUnprocessableEntity, NewValidationProblem(validationErr))
+	}
+
+	return ctx.Problem(http.StatusInternalServerError, Problem{})
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type HandleGreetRequest struct {
+	Lang string `query:"lang" default:"en"`
+	Name string `json:"name" minLength:"2"`
+}
+
+type HandleGreetResponse struct {
+	Greeting string `json:"greeting"`
+}
+
+func greetHandler(ctx Context, req HandleGreetRequest) (HandleGreetResponse, error) {
+	if req.Name == "error" {
+		return HandleGreetResponse{}, errors.New("database unavailable")
+	}
+
+	return HandleGreetResponse{Greeting: req.Lang + ": hello " + req.Name}, nil
+}
+
+func TestHandle(t *testing.T) {
+	handler := Handle(greetHandler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/greet?lang=fr", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var res HandleGreetResponse
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "fr: hello Ada", res.Greeting)
+}
+
+func TestHandle_ValidationError(t *testing.T) {
+	handler := Handle(greetHandler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":"A"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var body struct {
+		Status int `json:"status"`
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusUnprocessableEntity, body.Status)
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "name", body.Errors[0].Field)
+}
+
+func TestHandle_InternalError(t *testing.T) {
+	handler := Handle(greetHandler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":"error"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.NotContains(t, rec.Body.String(), "database unavailable")
+}
+
+func TestHandle_MalformedBody(t *testing.T) {
+	handler := Handle(greetHandler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "failed to bind body")
+}
+
+func TestHandle_UnknownField(t *testing.T) {
+	handler := Handle(greetHandler, WithStrictJSON(true))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":"Ada","admin":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "admin")
+}
+
+func TestHandle_BodyTooLarge(t *testing.T) {
+	handler := Handle(greetHandler, WithMaxBodySize(8))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":"Ada Lovelace"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+}
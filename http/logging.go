@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/xraph/go-utils/log"
+)
+
+// RequestIDHeader is the header NewLoggingMiddleware reads the request ID
+// from when the request context carries none.
+const RequestIDHeader = "X-Request-ID"
+
+// LoggingOption configures NewLoggingMiddleware.
+type LoggingOption func(*loggingOptions)
+
+type loggingOptions struct {
+	skipPaths []string
+}
+
+// WithSkipPaths disables logging for requests to the given paths, such as
+// health checks and metrics scrapes. Paths are matched exactly.
+func WithSkipPaths(paths ...string) LoggingOption {
+	return func(opts *loggingOptions) {
+		opts.skipPaths = append(opts.skipPaths, paths...)
+	}
+}
+
+// NewLoggingMiddleware returns access-log middleware that logs one line per
+// request handled by the wrapped handler, with its method, path, status,
+// response size in bytes, duration and, if present, request ID. The request
+// ID is taken from the request context (log.WithRequestID), then from the
+// RequestIDHeader header.
+//
+// Requests answered with a 5xx status are logged at error level, all others
+// at info level. A handler that writes nothing is logged with status 200,
+// as net/http answers it. A nil l logs nothing.
+func NewLoggingMiddleware(l log.Logger, opts ...LoggingOption) func(http.Handler) http.Handler {
+	if l == nil {
+		l = log.NewNoopLogger()
+	}
+
+	options := &loggingOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(options.skipPaths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			start := time.Now()
+
+			rec := NewStatusRecorder(w)
+			next.ServeHTTP(rec, r)
+
+			fields := []log.Field{
+				log.String("method", r.Method),
+				log.String("path", r.URL.Path),
+				log.Int("status", rec.Status()),
+				log.Int64("bytes", rec.Size()),
+				log.Duration("duration", time.Since(start)),
+			}
+
+			requestID := log.RequestIDFromContext(r.Context())
+			if requestID == "" {
+				requestID = r.Header.Get(RequestIDHeader)
+			}
+
+			if requestID != "" {
+				fields = append(fields, log.String("request_id", requestID))
+			}
+
+			if rec.Status() >= http.StatusInternalServerError {
+				l.Error("request handled", fields...)
+			} else {
+				l.Info("request handled", fields...)
+			}
+		})
+	}
+}
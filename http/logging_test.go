@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xraph/go-utils/log"
+)
+
+// logFields returns the fields of a TestLogger entry keyed by name.
+func logFields(entry log.LogEntry) map[string]any {
+	fields := make(map[string]any, len(entry.Fields))
+	for _, field := range entry.Fields {
+		f := field.(log.Field)
+		fields[f.Key()] = f.Value()
+	}
+
+	return fields
+}
+
+func TestLoggingMiddleware_FluentBuilder(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	handler := NewLoggingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = NewContext(w, r, nil).Status(http.StatusCreated).JSON(map[string]string{"id": "42"})
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	logs := logger.(*log.TestLogger).GetLogs()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "INFO", logs[0].Level)
+	assert.Equal(t, "request handled", logs[0].Message)
+
+	fields := logFields(logs[0])
+	assert.Equal(t, http.MethodPost, fields["method"])
+	assert.Equal(t, "/orders", fields["path"])
+	assert.Equal(t, int64(http.StatusCreated), fields["status"])
+	assert.Equal(t, int64(rec.Body.Len()), fields["bytes"])
+	assert.Equal(t, "req-1", fields["request_id"])
+
+	duration, ok := fields["duration"].(time.Duration)
+	require.True(t, ok)
+	assert.Positive(t, duration)
+}
+
+func TestLoggingMiddleware_Status(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantLevel  string
+	}{
+		{
+			name:       "implicit 200",
+			handler:    func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ok")) },
+			wantStatus: http.StatusOK,
+			wantLevel:  "INFO",
+		},
+		{
+			name:       "nothing written",
+			handler:    func(w http.ResponseWriter, r *http.Request) {},
+			wantStatus: http.StatusOK,
+			wantLevel:  "INFO",
+		},
+		{
+			name: "first status wins",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.WriteHeader(http.StatusOK)
+			},
+			wantStatus: http.StatusNotFound,
+			wantLevel:  "INFO",
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = NewContext(w, r, nil).Status(http.StatusServiceUnavailable).NoContent()
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantLevel:  "ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := log.NewTestLogger()
+
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			NewLoggingMiddleware(logger)(tt.handler).ServeHTTP(httptest.NewRecorder(), req)
+
+			logs := logger.(*log.TestLogger).GetLogs()
+			require.Len(t, logs, 1)
+			assert.Equal(t, tt.wantLevel, logs[0].Level)
+
+			fields := logFields(logs[0])
+			assert.Equal(t, int64(tt.wantStatus), fields["status"])
+			assert.NotContains(t, fields, "request_id")
+		})
+	}
+}
+
+func TestLoggingMiddleware_RequestIDFromContext(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	handler := NewLoggingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(RequestIDHeader, "from-header")
+	req = req.WithContext(log.WithRequestID(req.Context(), "from-context"))
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	logs := logger.(*log.TestLogger).GetLogs()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "from-context", logFields(logs[0])["request_id"])
+}
+
+func TestLoggingMiddleware_SkipPaths(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	calls := 0
+	handler := NewLoggingMiddleware(logger, WithSkipPaths("/health", "/metrics"))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+
+	for _, path := range []string{"/health", "/metrics", "/health/ready"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 3, calls)
+
+	logs := logger.(*log.TestLogger).GetLogs()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "/health/ready", logFields(logs[0])["path"])
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/xraph/go-utils/val"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Extensions holds additional
+// members, which are serialized alongside the standard ones; an extension
+// cannot replace a standard member.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// MarshalJSON implements json.Marshaler, flattening Extensions into the
+// problem object and omitting empty standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	maps.Copy(members, p.Extensions)
+
+	for key, value := range map[string]string{
+		"type":     p.Type,
+		"title":    p.Title,
+		"detail":   p.Detail,
+		"instance": p.Instance,
+	} {
+		delete(members, key)
+
+		if value != "" {
+			members[key] = value
+		}
+	}
+
+	delete(members, "status")
+
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+
+	return json.Marshal(members)
+}
+
+// NewValidationProblem builds a 422 Problem from a validation error. Each
+// field error is listed in the "errors" extension with its field, message,
+// and, when set, value and code.
+func NewValidationProblem(ve *val.ValidationError) Problem {
+	fieldErrors := []val.ValidationFieldError{}
+	if ve != nil && ve.Errors != nil {
+		fieldErrors = ve.Errors
+	}
+
+	return Problem{
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Detail: val.ValidationFailedMessage,
+		Extensions: map[string]any{
+			"errors": fieldErrors,
+		},
+	}
+}
+
+// Problem sends p as an application/problem+json response with the given
+// status. If p has no status it is set to code, and if p has neither a type
+// nor a title, the title defaults to the status text as RFC 7807 recommends
+// for "about:blank" problems.
+func (c *Ctx) Problem(code int, p Problem) error {
+	if p.Status == 0 {
+		p.Status = code
+	}
+
+	if p.Title == "" && (p.Type == "" || p.Type == "about:blank") {
+		p.Title = http.StatusText(code)
+	}
+
+	c.response.Header().Set("Content-Type", ProblemContentType)
+	c.response.WriteHeader(code)
+
+	if err := json.NewEncoder(c.response).Encode(p); err != nil {
+		return fmt.Errorf("failed to encode problem: %w", err)
+	}
+
+	return nil
+}
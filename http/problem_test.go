@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xraph/go-utils/val"
+)
+
+func TestContext_Problem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx := NewContext(rec, httptest.NewRequest(http.MethodGet, "/orders/42", nil), nil)
+
+	err := ctx.Problem(http.StatusNotFound, Problem{
+		Type:     "https://example.com/problems/order-not-found",
+		Title:    "Order not found",
+		Detail:   "order 42 does not exist",
+		Instance: "/orders/42",
+		Extensions: map[string]any{
+			"orderId": 42,
+			"status":  "ignored",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	assert.Equal(t, map[string]any{
+		"type":     "https://example.com/problems/order-not-found",
+		"title":    "Order not found",
+		"status":   float64(http.StatusNotFound),
+		"detail":   "order 42 does not exist",
+		"instance": "/orders/42",
+		"orderId":  float64(42),
+	}, body)
+}
+
+func TestContext_Problem_Defaults(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ctx := NewContext(rec, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+
+	require.NoError(t, ctx.Problem(http.StatusConflict, Problem{}))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	// Empty members are omitted and the title defaults to the status text
+	assert.Equal(t, map[string]any{
+		"title":  "Conflict",
+		"status": float64(http.StatusConflict),
+	}, body)
+}
+
+func TestNewValidationProblem(t *testing.T) {
+	ve := val.NewValidationError()
+	ve.AddWithCode("email", "email is required", val.ErrCodeRequired, nil)
+	ve.Add("age", "must be at least 18", 12)
+
+	rec := httptest.NewRecorder()
+	ctx := NewContext(rec, httptest.NewRequest(http.MethodPost, "/users", nil), nil)
+
+	require.NoError(t, ctx.Problem(ve.StatusCode(), NewValidationProblem(ve)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var body struct {
+		Title  string                     `json:"title"`
+		Status int                        `json:"status"`
+		Detail string                     `json:"detail"`
+		Errors []val.ValidationFieldError `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	assert.Equal(t, "Unprocessable Entity", body.Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, body.Status)
+	assert.Equal(t, val.ValidationFailedMessage, body.Detail)
+	require.Len(t, body.Errors, 2)
+	assert.Equal(t, val.ValidationFieldError{Field: "email", Message: "email is required", Code: val.ErrCodeRequired}, body.Errors[0])
+	assert.Equal(t, "age", body.Errors[1].Field)
+	assert.Equal(t, "must be at least 18", body.Errors[1].Message)
+	assert.InDelta(t, 12, body.Errors[1].Value, 0)
+}
+
+func TestNewValidationProblem_Nil(t *testing.T) {
+	data, err := json.Marshal(NewValidationProblem(nil))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), `"errors":[]`)
+}
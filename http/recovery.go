@@ -0,0 +1,74 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+
+	"github.com/xraph/go-utils/log"
+	"github.com/xraph/go-utils/metrics"
+)
+
+// HTTPPanicsTotalMetric is the counter incremented by NewRecoveryMiddleware
+// for every recovered panic.
+const HTTPPanicsTotalMetric = "http_panics_total"
+
+// maxPanicStackSize bounds the stack trace captured for a recovered panic.
+const maxPanicStackSize = 8 << 10
+
+// NewRecoveryMiddleware returns middleware that recovers panics raised by the
+// wrapped handler. Each panic increments HTTPPanicsTotalMetric, is logged at
+// error level with the panicking goroutine's stack (truncated to 8 KiB), and
+// is answered with a 500 JSON body. Either m or l may be nil.
+//
+// http.ErrAbortHandler is re-panicked so net/http can abort the response as
+// intended. If the handler already wrote a status before panicking, the 500
+// cannot replace it and only the body is appended.
+func NewRecoveryMiddleware(m Metrics, l log.Logger) func(http.Handler) http.Handler {
+	if m == nil {
+		m = metrics.NewNoopMetrics()
+	}
+
+	panics := m.Counter(HTTPPanicsTotalMetric)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+
+				if recovered == http.ErrAbortHandler { //nolint:errorlint // sentinel compared by identity, as net/http does
+					panic(recovered)
+				}
+
+				panics.Inc()
+
+				if l != nil {
+					l.Error("panic recovered",
+						log.String("panic", fmt.Sprint(recovered)),
+						log.String("method", r.Method),
+						log.String("path", r.URL.Path),
+						log.String("stack", panicStack()),
+					)
+				}
+
+				c := NewContext(w, r, nil)
+				_ = c.JSON(http.StatusInternalServerError, map[string]string{
+					"error": http.StatusText(http.StatusInternalServerError),
+				})
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// panicStack returns the stack of the current goroutine, truncated to
+// maxPanicStackSize bytes.
+func panicStack() string {
+	buf := make([]byte, maxPanicStackSize)
+
+	return string(buf[:runtime.Stack(buf, false)])
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xraph/go-utils/log"
+	"github.com/xraph/go-utils/metrics"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	m := metrics.NewMetricsCollector("test")
+	logger := log.NewTestLogger()
+
+	handler := NewRecoveryMiddleware(m, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]string
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "Internal Server Error", body["error"])
+
+	assert.InDelta(t, 1.0, m.Counter(HTTPPanicsTotalMetric).Value(), 0)
+
+	testLogger := logger.(*log.TestLogger)
+	require.True(t, testLogger.AssertHasLog("ERROR", "panic recovered"))
+
+	fields := make(map[string]any)
+	for _, field := range testLogger.GetLogs()[0].Fields {
+		f := field.(log.Field)
+		fields[f.Key()] = f.Value()
+	}
+
+	assert.Equal(t, "boom", fields["panic"])
+	assert.Equal(t, "/orders", fields["path"])
+
+	stack, ok := fields["stack"].(string)
+	require.True(t, ok)
+	assert.Contains(t, stack, "goroutine")
+	assert.LessOrEqual(t, len(stack), maxPanicStackSize)
+}
+
+func TestRecoveryMiddleware_NoPanic(t *testing.T) {
+	m := metrics.NewMetricsCollector("test")
+
+	handler := NewRecoveryMiddleware(m, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.InDelta(t, 0.0, m.Counter(HTTPPanicsTotalMetric).Value(), 0)
+}
+
+func TestRecoveryMiddleware_AbortHandler(t *testing.T) {
+	handler := NewRecoveryMiddleware(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestPanicStackBounded(t *testing.T) {
+	var stack string
+
+	var recurse func(depth int)
+	recurse = func(depth int) {
+		if depth == 0 {
+			stack = panicStack()
+
+			return
+		}
+
+		recurse(depth - 1)
+	}
+	recurse(500)
+
+	assert.Len(t, stack, maxPanicStackSize)
+	assert.True(t, strings.HasPrefix(stack, "goroutine"))
+}
@@ -0,0 +1,290 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xraph/go-utils/val"
+)
+
+// SchemaFor reflects a request struct into a JSON Schema object for OpenAPI
+// documentation. v is a struct, a pointer to one, or a reflect.Type.
+//
+// Properties are named as in validation errors: by the path, query, header or
+// json tag, falling back to the field name. Fields required for binding are
+// listed in "required", and the validation tags minLength, maxLength,
+// pattern, format, minimum, maximum, multipleOf and enum as well as the
+// default tag become the matching schema keywords. []byte fields are strings
+// with format byte, or contentEncoding base64url or base16 when the binder
+// decodes them that way. Embedded structs are
+// flattened as in BindRequest; other struct fields become nested objects.
+//
+// An error is returned if v is not a struct or a tag value cannot be parsed
+// for the field's type.
+func SchemaFor(v any) (map[string]any, error) {
+	rt, ok := v.(reflect.Type)
+	if !ok {
+		if v == nil {
+			return nil, errors.New("SchemaFor requires a struct")
+		}
+
+		rt = reflect.TypeOf(v)
+	}
+
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("SchemaFor requires a struct, got %s", rt)
+	}
+
+	return objectSchema(rt, make(map[reflect.Type]bool))
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// objectSchema returns the schema of a struct type. seen holds the struct
+// types being expanded, so recursive types end in a plain object schema.
+func objectSchema(rt reflect.Type, seen map[reflect.Type]bool) (map[string]any, error) {
+	schema := map[string]any{"type": "object"}
+	if seen[rt] {
+		return schema, nil
+	}
+
+	seen[rt] = true
+	defer delete(seen, rt)
+
+	properties := make(map[string]any)
+
+	var required []string
+
+	if err := addFieldSchemas(rt, seen, properties, &required); err != nil {
+		return nil, err
+	}
+
+	schema["properties"] = properties
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema, nil
+}
+
+// addFieldSchemas adds the schema of every field of rt to properties,
+// flattening embedded structs the way the binder does.
+func addFieldSchemas(rt reflect.Type, seen map[reflect.Type]bool, properties map[string]any, required *[]string) error {
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+
+		// Skip unexported fields
+		if !field.IsExported() {
+			continue
+		}
+
+		// Handle embedded structs
+		if field.Anonymous {
+			hasExplicitTag := field.Tag.Get("path") != "" ||
+				field.Tag.Get("query") != "" ||
+				field.Tag.Get("header") != "" ||
+				field.Tag.Get("json") != ""
+
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+
+			if !hasExplicitTag && embeddedType.Kind() == reflect.Struct {
+				if err := addFieldSchemas(embeddedType, seen, properties, required); err != nil {
+					return err
+				}
+
+				continue
+			}
+		}
+
+		if field.Tag.Get("json") == "-" && !val.IsParameterField(field) {
+			continue
+		}
+
+		name := val.GetFieldName(field)
+
+		schema, err := fieldSchema(field, seen)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+
+		properties[name] = schema
+
+		if val.IsFieldRequired(field) {
+			*required = append(*required, name)
+		}
+	}
+
+	return nil
+}
+
+// fieldSchema returns the schema of a struct field: the schema of its type
+// plus the constraints from its validation tags.
+func fieldSchema(field reflect.StructField, seen map[reflect.Type]bool) (map[string]any, error) {
+	ft := field.Type
+	for ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+
+	schema, err := typeSchema(ft, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	if isBytesField(ft) {
+		setEncodingSchema(schema, field)
+	}
+
+	for _, keyword := range []string{"minLength", "maxLength"} {
+		if tag := field.Tag.Get(keyword); tag != "" {
+			n, err := strconv.Atoi(tag)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s tag %q", keyword, tag)
+			}
+
+			schema[keyword] = n
+		}
+	}
+
+	for _, keyword := range []string{"minimum", "maximum", "multipleOf"} {
+		if tag := field.Tag.Get(keyword); tag != "" {
+			n, err := strconv.ParseFloat(tag, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s tag %q", keyword, tag)
+			}
+
+			schema[keyword] = n
+		}
+	}
+
+	if pattern := field.Tag.Get("pattern"); pattern != "" {
+		schema["pattern"] = pattern
+	}
+
+	if format := field.Tag.Get("format"); format != "" {
+		schema["format"] = format
+	}
+
+	if enumTag := field.Tag.Get("enum"); enumTag != "" {
+		var enum []any
+
+		for value := range strings.SplitSeq(enumTag, ",") {
+			v, err := schemaValue(ft, strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid enum tag %q: %w", enumTag, err)
+			}
+
+			enum = append(enum, v)
+		}
+
+		schema["enum"] = enum
+	}
+
+	if defaultTag := field.Tag.Get("default"); defaultTag != "" {
+		v, err := schemaValue(ft, defaultTag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default tag %q: %w", defaultTag, err)
+		}
+
+		schema["default"] = v
+	}
+
+	return schema, nil
+}
+
+// setEncodingSchema describes the wire format of a []byte field the way the
+// binder decodes it: by its encoding tag, defaulting to base64url for path,
+// query and header parameters and to standard base64 for body fields.
+func setEncodingSchema(schema map[string]any, field reflect.StructField) {
+	enc := field.Tag.Get("encoding")
+	if enc == "" && val.IsParameterField(field) {
+		enc = "base64url"
+	}
+
+	switch enc {
+	case "base64url":
+		delete(schema, "format")
+		schema["contentEncoding"] = "base64url"
+	case "hex":
+		delete(schema, "format")
+		schema["contentEncoding"] = "base16"
+	}
+}
+
+// typeSchema returns the schema of a Go type as it is encoded in JSON.
+func typeSchema(rt reflect.Type, seen map[reflect.Type]bool) (map[string]any, error) {
+	switch {
+	case rt == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8:
+		return map[string]any{"type": "string", "format": "byte"}, nil
+	}
+
+	switch rt.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice, reflect.Array:
+		elem := rt.Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		items, err := typeSchema(elem, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		elem := rt.Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		values, err := typeSchema(elem, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return objectSchema(rt, seen)
+	default:
+		// Interfaces and other kinds accept any value
+		return map[string]any{}, nil
+	}
+}
+
+// schemaValue parses an enum or default tag value as a value of type rt, so
+// it is emitted with the matching JSON type.
+func schemaValue(rt reflect.Type, value string) (any, error) {
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaFor_ValidationOptionalRequest(t *testing.T) {
+	schema, err := SchemaFor(&ValidationOptionalRequest{})
+	require.NoError(t, err)
+
+	expected := `{
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"optionalEmail": {"type": "string", "format": "email"},
+			"optionalName": {"type": "string", "minLength": 3},
+			"optionalCode": {"type": "string", "pattern": "^[A-Z]{3}$"},
+			"optionalAge": {"type": "integer", "minimum": 18}
+		},
+		"required": ["email"]
+	}`
+
+	actual, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
+
+type schemaAddress struct {
+	City string `json:"city" maxLength:"64"`
+}
+
+type SchemaPaging struct {
+	Limit int `default:"20" maximum:"100" query:"limit"`
+}
+
+type schemaNode struct {
+	Name     string        `json:"name"`
+	Children []*schemaNode `json:"children,omitempty"`
+}
+
+type schemaRequest struct {
+	SchemaPaging
+
+	ID        string            `path:"id"`
+	Status    string            `enum:"active, disabled" json:"status"`
+	Priority  int               `enum:"1,2,3"            json:"priority,omitempty"`
+	Ratio     float64           `json:"ratio"            multipleOf:"0.5"`
+	Tags      []string          `json:"tags,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Address   *schemaAddress    `json:"address"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Payload   []byte            `json:"payload,omitempty"`
+	Tree      schemaNode        `json:"tree"`
+	Internal  string            `json:"-"`
+	hidden    string
+}
+
+func TestSchemaFor_Types(t *testing.T) {
+	schema, err := SchemaFor(reflect.TypeFor[schemaRequest]())
+	require.NoError(t, err)
+
+	expected := `{
+		"type": "object",
+		"properties": {
+			"limit": {"type": "integer", "maximum": 100, "default": 20},
+			"id": {"type": "string"},
+			"status": {"type": "string", "enum": ["active", "disabled"]},
+			"priority": {"type": "integer", "enum": [1, 2, 3]},
+			"ratio": {"type": "number", "multipleOf": 0.5},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"address": {
+				"type": "object",
+				"properties": {"city": {"type": "string", "maxLength": 64}},
+				"required": ["city"]
+			},
+			"createdAt": {"type": "string", "format": "date-time"},
+			"payload": {"type": "string", "format": "byte"},
+			"tree": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"type": "object"}}
+				},
+				"required": ["name"]
+			}
+		},
+		"required": ["id", "status", "ratio", "createdAt", "tree"]
+	}`
+
+	actual, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
+
+func TestSchemaFor_ByteEncodings(t *testing.T) {
+	schema, err := SchemaFor(struct {
+		Token    []byte  `query:"token"`
+		Raw      []byte  `encoding:"base64" header:"X-Raw"`
+		Checksum []byte  `encoding:"hex"    json:"checksum"`
+		Digest   *[]byte `encoding:"hex"    query:"digest"`
+		Payload  []byte  `json:"payload"`
+	}{})
+	require.NoError(t, err)
+
+	expected := `{
+		"token": {"type": "string", "contentEncoding": "base64url"},
+		"X-Raw": {"type": "string", "format": "byte"},
+		"checksum": {"type": "string", "contentEncoding": "base16"},
+		"digest": {"type": "string", "contentEncoding": "base16"},
+		"payload": {"type": "string", "format": "byte"}
+	}`
+
+	actual, err := json.Marshal(schema["properties"])
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
+
+func TestSchemaFor_Errors(t *testing.T) {
+	_, err := SchemaFor(nil)
+	require.Error(t, err)
+
+	_, err = SchemaFor("not a struct")
+	require.Error(t, err)
+
+	_, err = SchemaFor(struct {
+		Age int `minimum:"eighteen" query:"age"`
+	}{})
+	require.ErrorContains(t, err, "age")
+
+	_, err = SchemaFor(struct {
+		Count int `enum:"one,two" query:"count"`
+	}{})
+	require.ErrorContains(t, err, "enum")
+}
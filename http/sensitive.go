@@ -1,7 +1,9 @@
 package http
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 )
@@ -25,6 +27,61 @@ const (
 	SensitiveModeMask
 )
 
+// SensitivePolicy specifies how a route renders sensitive fields in responses.
+// It is carried in the request context under ContextKeyForSensitiveCleaning.
+type SensitivePolicy int
+
+const (
+	// SensitivePolicyOff leaves sensitive fields untouched.
+	SensitivePolicyOff SensitivePolicy = iota
+	// SensitivePolicyRedact cleans sensitive fields as declared by their tag.
+	// This is the behavior of the boolean sensitive cleaning flag.
+	SensitivePolicyRedact
+	// SensitivePolicyMask replaces every sensitive field with its mask, using
+	// the tag's custom mask when present and "[REDACTED]" otherwise, so the
+	// field remains visible as masked rather than zeroed.
+	SensitivePolicyMask
+)
+
+// String returns the policy name.
+func (p SensitivePolicy) String() string {
+	switch p {
+	case SensitivePolicyRedact:
+		return "redact"
+	case SensitivePolicyMask:
+		return "mask"
+	default:
+		return "off"
+	}
+}
+
+// SensitiveFieldsMiddleware returns middleware that sets the sensitive field
+// policy for every request it wraps, allowing route groups to choose how
+// responses render sensitive fields.
+func SensitiveFieldsMiddleware(policy SensitivePolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), ContextKeyForSensitiveCleaning, policy)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// sensitivePolicyFromValue converts a context value into a policy.
+// A boolean true maps to SensitivePolicyRedact for backward compatibility.
+func sensitivePolicyFromValue(v any) SensitivePolicy {
+	switch policy := v.(type) {
+	case SensitivePolicy:
+		return policy
+	case bool:
+		if policy {
+			return SensitivePolicyRedact
+		}
+	}
+
+	return SensitivePolicyOff
+}
+
 const (
 	// RedactedPlaceholder is the default placeholder for redacted sensitive fields.
 	RedactedPlaceholder = "[REDACTED]"
@@ -66,18 +123,24 @@ func ParseSensitiveTag(tagValue string) *SensitiveFieldConfig {
 // CleanSensitiveFields creates a cleaned copy of the value with sensitive fields processed.
 // It handles nested structs, slices, arrays, and maps recursively.
 func CleanSensitiveFields(v any) any {
-	if v == nil {
-		return nil
+	return CleanSensitiveFieldsWithPolicy(v, SensitivePolicyRedact)
+}
+
+// CleanSensitiveFieldsWithPolicy creates a cleaned copy of the value with sensitive
+// fields rendered according to the given policy.
+func CleanSensitiveFieldsWithPolicy(v any, policy SensitivePolicy) any {
+	if v == nil || policy == SensitivePolicyOff {
+		return v
 	}
 
 	rv := reflect.ValueOf(v)
-	cleaned := cleanSensitiveValue(rv)
+	cleaned := cleanSensitiveValue(rv, policy)
 
 	return cleaned.Interface()
 }
 
 // cleanSensitiveValue recursively cleans sensitive fields from a reflect.Value.
-func cleanSensitiveValue(rv reflect.Value) reflect.Value {
+func cleanSensitiveValue(rv reflect.Value, policy SensitivePolicy) reflect.Value {
 	// Handle pointers and interfaces
 	switch rv.Kind() {
 	case reflect.Ptr, reflect.Interface:
@@ -86,32 +149,32 @@ func cleanSensitiveValue(rv reflect.Value) reflect.Value {
 		}
 
 		if rv.Kind() == reflect.Ptr {
-			cleaned := cleanSensitiveValue(rv.Elem())
+			cleaned := cleanSensitiveValue(rv.Elem(), policy)
 			result := reflect.New(rv.Elem().Type())
 			result.Elem().Set(cleaned)
 
 			return result
 		}
 
-		return cleanSensitiveValue(rv.Elem())
+		return cleanSensitiveValue(rv.Elem(), policy)
 	}
 
 	switch rv.Kind() {
 	case reflect.Struct:
-		return cleanSensitiveStruct(rv)
+		return cleanSensitiveStruct(rv, policy)
 	case reflect.Slice:
-		return cleanSensitiveSlice(rv)
+		return cleanSensitiveSlice(rv, policy)
 	case reflect.Array:
-		return cleanSensitiveArray(rv)
+		return cleanSensitiveArray(rv, policy)
 	case reflect.Map:
-		return cleanSensitiveMap(rv)
+		return cleanSensitiveMap(rv, policy)
 	default:
 		return rv
 	}
 }
 
 // cleanSensitiveStruct creates a cleaned copy of a struct with sensitive fields processed.
-func cleanSensitiveStruct(rv reflect.Value) reflect.Value {
+func cleanSensitiveStruct(rv reflect.Value, policy SensitivePolicy) reflect.Value {
 	rt := rv.Type()
 	result := reflect.New(rt).Elem()
 
@@ -130,11 +193,11 @@ func cleanSensitiveStruct(rv reflect.Value) reflect.Value {
 
 		if config != nil {
 			// Apply sensitive field cleaning
-			cleanedVal := applySensitiveCleaning(field.Type, config)
+			cleanedVal := applySensitiveCleaning(field.Type, config, policy)
 			result.Field(i).Set(cleanedVal)
 		} else {
 			// Recursively clean nested values
-			cleanedVal := cleanSensitiveValue(fieldVal)
+			cleanedVal := cleanSensitiveValue(fieldVal, policy)
 			result.Field(i).Set(cleanedVal)
 		}
 	}
@@ -143,7 +206,7 @@ func cleanSensitiveStruct(rv reflect.Value) reflect.Value {
 }
 
 // cleanSensitiveSlice creates a cleaned copy of a slice with sensitive fields processed.
-func cleanSensitiveSlice(rv reflect.Value) reflect.Value {
+func cleanSensitiveSlice(rv reflect.Value, policy SensitivePolicy) reflect.Value {
 	if rv.IsNil() {
 		return rv
 	}
@@ -151,7 +214,7 @@ func cleanSensitiveSlice(rv reflect.Value) reflect.Value {
 	result := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Cap())
 
 	for i := range rv.Len() {
-		cleaned := cleanSensitiveValue(rv.Index(i))
+		cleaned := cleanSensitiveValue(rv.Index(i), policy)
 		result.Index(i).Set(cleaned)
 	}
 
@@ -159,11 +222,11 @@ func cleanSensitiveSlice(rv reflect.Value) reflect.Value {
 }
 
 // cleanSensitiveArray creates a cleaned copy of an array with sensitive fields processed.
-func cleanSensitiveArray(rv reflect.Value) reflect.Value {
+func cleanSensitiveArray(rv reflect.Value, policy SensitivePolicy) reflect.Value {
 	result := reflect.New(rv.Type()).Elem()
 
 	for i := range rv.Len() {
-		cleaned := cleanSensitiveValue(rv.Index(i))
+		cleaned := cleanSensitiveValue(rv.Index(i), policy)
 		result.Index(i).Set(cleaned)
 	}
 
@@ -171,7 +234,7 @@ func cleanSensitiveArray(rv reflect.Value) reflect.Value {
 }
 
 // cleanSensitiveMap creates a cleaned copy of a map with sensitive fields processed.
-func cleanSensitiveMap(rv reflect.Value) reflect.Value {
+func cleanSensitiveMap(rv reflect.Value, policy SensitivePolicy) reflect.Value {
 	if rv.IsNil() {
 		return rv
 	}
@@ -182,15 +245,23 @@ func cleanSensitiveMap(rv reflect.Value) reflect.Value {
 	for iter.Next() {
 		key := iter.Key()
 		val := iter.Value()
-		cleanedVal := cleanSensitiveValue(val)
+		cleanedVal := cleanSensitiveValue(val, policy)
 		result.SetMapIndex(key, cleanedVal)
 	}
 
 	return result
 }
 
-// applySensitiveCleaning applies the appropriate cleaning based on the config.
-func applySensitiveCleaning(fieldType reflect.Type, config *SensitiveFieldConfig) reflect.Value {
+// applySensitiveCleaning applies the appropriate cleaning based on the config and policy.
+func applySensitiveCleaning(fieldType reflect.Type, config *SensitiveFieldConfig, policy SensitivePolicy) reflect.Value {
+	if policy == SensitivePolicyMask {
+		if config.Mode == SensitiveModeMask {
+			return getStringValue(fieldType, config.Mask)
+		}
+
+		return getStringValue(fieldType, RedactedPlaceholder)
+	}
+
 	switch config.Mode {
 	case SensitiveModeZero:
 		return reflect.Zero(fieldType)
@@ -233,13 +304,16 @@ type ResponseProcessor struct {
 	// HeaderSetter is called for each header:"..." tagged field with non-zero value.
 	HeaderSetter func(name, value string)
 	// CleanSensitive when true, processes sensitive fields.
+	// Equivalent to Policy SensitivePolicyRedact; ignored when Policy is set.
 	CleanSensitive bool
+	// Policy controls how sensitive fields are rendered.
+	Policy SensitivePolicy
 }
 
 // ProcessResponse handles response struct tags:
 // - Calls HeaderSetter for header:"..." fields with non-zero values
 // - Returns the unwrapped body if a body:"" tag is found
-// - Cleans sensitive fields if CleanSensitive is true
+// - Cleans sensitive fields according to Policy (or CleanSensitive)
 // - Falls back to original value if no special tags found.
 func (p *ResponseProcessor) ProcessResponse(v any) any {
 	if v == nil {
@@ -247,10 +321,13 @@ func (p *ResponseProcessor) ProcessResponse(v any) any {
 	}
 
 	// Clean sensitive fields first if enabled
-	if p.CleanSensitive {
-		v = CleanSensitiveFields(v)
+	policy := p.Policy
+	if policy == SensitivePolicyOff && p.CleanSensitive {
+		policy = SensitivePolicyRedact
 	}
 
+	v = CleanSensitiveFieldsWithPolicy(v, policy)
+
 	rv := reflect.ValueOf(v)
 
 	// Handle pointer
@@ -266,21 +343,47 @@ func (p *ResponseProcessor) ProcessResponse(v any) any {
 		return v
 	}
 
-	rt := rv.Type()
+	if bodyValue, ok := p.processResponseFields(rv); ok {
+		return bodyValue
+	}
 
-	var bodyValue any
+	return v
+}
+
+// processResponseFields sets headers from header:"..." fields and returns the
+// value of the body:"" field, if any. Embedded structs without their own
+// header or body tag are flattened, so their fields are processed as if they
+// were declared on the outer struct. As with Go field promotion, a body field
+// declared on the outer struct wins over one in an embedded struct.
+func (p *ResponseProcessor) processResponseFields(rv reflect.Value) (any, bool) {
+	rt := rv.Type()
 
-	hasBodyUnwrap := false
+	var (
+		bodyValue         any
+		hasBodyUnwrap     bool
+		embeddedBodyValue any
+		hasEmbeddedBody   bool
+	)
 
 	for i := range rt.NumField() {
 		field := rt.Field(i)
-		if !field.IsExported() {
+		fieldVal := rv.Field(i)
+
+		if embedded, ok := flattenedEmbeddedStruct(field, fieldVal); ok {
+			if value, found := p.processResponseFields(embedded); found && !hasEmbeddedBody {
+				embeddedBodyValue = value
+				hasEmbeddedBody = true
+			}
+
+			continue
+		}
+
+		if !field.IsExported() || !fieldVal.CanInterface() {
 			continue
 		}
 
 		// Set headers via callback
 		if headerName := field.Tag.Get("header"); headerName != "" && headerName != "-" {
-			fieldVal := rv.Field(i)
 			if !fieldVal.IsZero() && p.HeaderSetter != nil {
 				p.HeaderSetter(headerName, fmt.Sprint(fieldVal.Interface()))
 			}
@@ -288,16 +391,43 @@ func (p *ResponseProcessor) ProcessResponse(v any) any {
 
 		// Check for body:"" unwrap marker
 		if bodyTag, hasTag := field.Tag.Lookup("body"); hasTag && bodyTag == "" {
-			bodyValue = rv.Field(i).Interface()
+			bodyValue = fieldVal.Interface()
 			hasBodyUnwrap = true
 		}
 	}
 
 	if hasBodyUnwrap {
-		return bodyValue
+		return bodyValue, true
 	}
 
-	return v
+	return embeddedBodyValue, hasEmbeddedBody
+}
+
+// flattenedEmbeddedStruct returns the struct value of an embedded field that
+// should be flattened into its parent: an anonymous struct or non-nil struct
+// pointer without a header or body tag of its own.
+func flattenedEmbeddedStruct(field reflect.StructField, fieldVal reflect.Value) (reflect.Value, bool) {
+	if !field.Anonymous {
+		return reflect.Value{}, false
+	}
+
+	if _, hasBody := field.Tag.Lookup("body"); hasBody || field.Tag.Get("header") != "" {
+		return reflect.Value{}, false
+	}
+
+	if fieldVal.Kind() == reflect.Ptr {
+		if fieldVal.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		fieldVal = fieldVal.Elem()
+	}
+
+	if fieldVal.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	return fieldVal, true
 }
 
 // ProcessResponseValue is a convenience function that processes a response value
@@ -313,9 +443,15 @@ func ProcessResponseValue(v any, headerSetter func(name, value string)) any {
 // ProcessResponseValueWithSensitive is a convenience function that processes a response value
 // with the given header setter callback and sensitive field cleaning.
 func ProcessResponseValueWithSensitive(v any, headerSetter func(name, value string), cleanSensitive bool) any {
+	return ProcessResponseValueWithPolicy(v, headerSetter, sensitivePolicyFromValue(cleanSensitive))
+}
+
+// ProcessResponseValueWithPolicy is a convenience function that processes a response value
+// with the given header setter callback, rendering sensitive fields according to policy.
+func ProcessResponseValueWithPolicy(v any, headerSetter func(name, value string), policy SensitivePolicy) any {
 	processor := &ResponseProcessor{
-		HeaderSetter:   headerSetter,
-		CleanSensitive: cleanSensitive,
+		HeaderSetter: headerSetter,
+		Policy:       policy,
 	}
 
 	return processor.ProcessResponse(v)
@@ -1,6 +1,10 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -427,3 +431,250 @@ func TestProcessResponseValueWithSensitive_Disabled(t *testing.T) {
 	// Secret should NOT be cleaned when disabled
 	assertStringEqual(t, returned.Secret, "super-secret", "Secret (should be unchanged when disabled)")
 }
+
+type Pagination struct {
+	Total  int    `header:"X-Total-Count" json:"-"`
+	Cursor string `header:"X-Next-Cursor" json:"-"`
+}
+
+type pagedBody struct {
+	Items []string `body:""`
+}
+
+func TestProcessResponseValue_EmbeddedHeaders(t *testing.T) {
+	type ListResponse struct {
+		Pagination
+
+		RequestID string   `header:"X-Request-ID"`
+		Items     []string `body:""`
+	}
+
+	headers := make(map[string]string)
+	headerSetter := func(name, value string) {
+		headers[name] = value
+	}
+
+	input := &ListResponse{
+		Pagination: Pagination{Total: 42, Cursor: "abc"},
+		RequestID:  "req-1",
+		Items:      []string{"a", "b"},
+	}
+
+	result := ProcessResponseValue(input, headerSetter)
+
+	items, ok := result.([]string)
+	if !ok {
+		t.Fatalf("Expected []string, got %T", result)
+	}
+
+	assertIntEqual(t, len(items), 2, "len(items)")
+	assertStringEqual(t, headers["X-Total-Count"], "42", "X-Total-Count header")
+	assertStringEqual(t, headers["X-Next-Cursor"], "abc", "X-Next-Cursor header")
+	assertStringEqual(t, headers["X-Request-ID"], "req-1", "X-Request-ID header")
+}
+
+func TestProcessResponseValue_EmbeddedPointerHeaders(t *testing.T) {
+	type ListResponse struct {
+		*Pagination
+
+		Items []string `json:"items"`
+	}
+
+	headers := make(map[string]string)
+	headerSetter := func(name, value string) {
+		headers[name] = value
+	}
+
+	ProcessResponseValue(ListResponse{Pagination: &Pagination{Total: 7}}, headerSetter)
+	assertStringEqual(t, headers["X-Total-Count"], "7", "X-Total-Count header")
+
+	// A nil embedded pointer is skipped
+	headers = make(map[string]string)
+	ProcessResponseValue(ListResponse{}, headerSetter)
+	assertIntEqual(t, len(headers), 0, "len(headers)")
+}
+
+func TestProcessResponseValue_EmbeddedBodyUnwrap(t *testing.T) {
+	type ListResponse struct {
+		Pagination
+		pagedBody
+	}
+
+	headers := make(map[string]string)
+	headerSetter := func(name, value string) {
+		headers[name] = value
+	}
+
+	input := ListResponse{
+		Pagination: Pagination{Total: 2},
+		pagedBody:  pagedBody{Items: []string{"a", "b"}},
+	}
+
+	result := ProcessResponseValue(input, headerSetter)
+
+	items, ok := result.([]string)
+	if !ok {
+		t.Fatalf("Expected []string, got %T", result)
+	}
+
+	assertIntEqual(t, len(items), 2, "len(items)")
+	assertStringEqual(t, headers["X-Total-Count"], "2", "X-Total-Count header")
+}
+
+func TestProcessResponseValue_OuterBodyWinsOverEmbedded(t *testing.T) {
+	type Response struct {
+		pagedBody
+
+		Data string `body:""`
+	}
+
+	result := ProcessResponseValue(Response{pagedBody: pagedBody{Items: []string{"a"}}, Data: "outer"}, nil)
+
+	data, ok := result.(string)
+	if !ok {
+		t.Fatalf("Expected string, got %T", result)
+	}
+
+	assertStringEqual(t, data, "outer", "body")
+}
+
+type policyResponse struct {
+	ID       string `json:"id"`
+	Password string `json:"password" sensitive:"true"`
+	Token    string `json:"token"    sensitive:"redact"`
+	Card     string `json:"card"     sensitive:"mask:****-****"`
+}
+
+func newPolicyResponse() *policyResponse {
+	return &policyResponse{
+		ID:       "123",
+		Password: "hunter2",
+		Token:    "tok_abc",
+		Card:     "4111-1111",
+	}
+}
+
+func TestProcessResponseValueWithPolicy_Off(t *testing.T) {
+	result := ProcessResponseValueWithPolicy(newPolicyResponse(), nil, SensitivePolicyOff)
+
+	cleaned, ok := result.(*policyResponse)
+	if !ok {
+		t.Fatalf("Expected *policyResponse, got %T", result)
+	}
+
+	assertStringEqual(t, cleaned.Password, "hunter2", "Password")
+	assertStringEqual(t, cleaned.Token, "tok_abc", "Token")
+	assertStringEqual(t, cleaned.Card, "4111-1111", "Card")
+}
+
+func TestProcessResponseValueWithPolicy_Redact(t *testing.T) {
+	result := ProcessResponseValueWithPolicy(newPolicyResponse(), nil, SensitivePolicyRedact)
+
+	cleaned, ok := result.(*policyResponse)
+	if !ok {
+		t.Fatalf("Expected *policyResponse, got %T", result)
+	}
+
+	assertStringEqual(t, cleaned.ID, "123", "ID")
+	assertStringEqual(t, cleaned.Password, "", "Password")
+	assertStringEqual(t, cleaned.Token, "[REDACTED]", "Token")
+	assertStringEqual(t, cleaned.Card, "****-****", "Card")
+}
+
+func TestProcessResponseValueWithPolicy_Mask(t *testing.T) {
+	result := ProcessResponseValueWithPolicy(newPolicyResponse(), nil, SensitivePolicyMask)
+
+	cleaned, ok := result.(*policyResponse)
+	if !ok {
+		t.Fatalf("Expected *policyResponse, got %T", result)
+	}
+
+	assertStringEqual(t, cleaned.ID, "123", "ID")
+	assertStringEqual(t, cleaned.Password, "[REDACTED]", "Password")
+	assertStringEqual(t, cleaned.Token, "[REDACTED]", "Token")
+	assertStringEqual(t, cleaned.Card, "****-****", "Card")
+}
+
+func TestProcessResponseValueWithSensitive_BooleanMapsToRedact(t *testing.T) {
+	withBool := ProcessResponseValueWithSensitive(newPolicyResponse(), nil, true).(*policyResponse)
+	withPolicy := ProcessResponseValueWithPolicy(newPolicyResponse(), nil, SensitivePolicyRedact).(*policyResponse)
+
+	if *withBool != *withPolicy {
+		t.Errorf("boolean flag = %+v, want %+v", *withBool, *withPolicy)
+	}
+}
+
+func TestSensitiveFieldsMiddleware(t *testing.T) {
+	tests := []struct {
+		policy   SensitivePolicy
+		password string
+		token    string
+	}{
+		{policy: SensitivePolicyOff, password: "hunter2", token: "tok_abc"},
+		{policy: SensitivePolicyRedact, password: "", token: "[REDACTED]"},
+		{policy: SensitivePolicyMask, password: "[REDACTED]", token: "[REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			handler := SensitiveFieldsMiddleware(tt.policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := NewContext(w, r, nil)
+				if err := ctx.JSON(http.StatusOK, newPolicyResponse()); err != nil {
+					t.Fatalf("JSON() error = %v", err)
+				}
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/account", nil))
+
+			var body policyResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			assertStringEqual(t, body.Password, tt.password, "Password")
+			assertStringEqual(t, body.Token, tt.token, "Token")
+		})
+	}
+}
+
+func TestContext_SensitivePolicy_BooleanFlag(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/account", nil)
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyForSensitiveCleaning, true))
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	if got := ctx.sensitivePolicy(); got != SensitivePolicyRedact {
+		t.Errorf("sensitivePolicy() = %v, want %v", got, SensitivePolicyRedact)
+	}
+
+	ctx.Set("forge:sensitive_field_cleaning", SensitivePolicyMask)
+
+	if got := ctx.sensitivePolicy(); got != SensitivePolicyMask {
+		t.Errorf("sensitivePolicy() = %v, want %v", got, SensitivePolicyMask)
+	}
+}
+
+func TestContext_SensitivePolicy_OffOverridesRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/account", nil)
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyForSensitiveCleaning, SensitivePolicyMask))
+
+	ctx := NewContext(httptest.NewRecorder(), req, nil).(*Ctx)
+
+	if got := ctx.sensitivePolicy(); got != SensitivePolicyMask {
+		t.Errorf("sensitivePolicy() = %v, want %v", got, SensitivePolicyMask)
+	}
+
+	// Turning cleaning off on the forge context overrides the request policy
+	ctx.Set("forge:sensitive_field_cleaning", SensitivePolicyOff)
+
+	if got := ctx.sensitivePolicy(); got != SensitivePolicyOff {
+		t.Errorf("sensitivePolicy() = %v, want %v", got, SensitivePolicyOff)
+	}
+
+	ctx.Set("forge:sensitive_field_cleaning", false)
+
+	if got := ctx.sensitivePolicy(); got != SensitivePolicyOff {
+		t.Errorf("sensitivePolicy() = %v, want %v", got, SensitivePolicyOff)
+	}
+}
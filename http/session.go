@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"time"
@@ -67,25 +68,61 @@ type Context interface {
 	BindJSON(v any) error
 	BindXML(v any) error
 
+	// SetMaxBodySize limits the request body size read by Bind, BindJSON and
+	// BindXML. Larger bodies fail with ErrBodyTooLarge.
+	SetMaxBodySize(n int64)
+
 	// BindRequest binds and validates request data from all sources (path, query, header, body)
 	// using struct tags. Automatically validates based on validation tags.
 	BindRequest(v any) error
 
+	// BindAndValidate binds like BindRequest and returns validation failures
+	// as a map of field names to messages alongside the error.
+	BindAndValidate(v any) (map[string][]string, error)
+
+	// BindQuery and BindHeader bind and validate only the fields tagged with
+	// query or header respectively, ignoring fields from other sources.
+	BindQuery(v any) error
+	BindHeader(v any) error
+
+	// BindMap binds all query and form values into a map, coercing
+	// unambiguous numbers and booleans.
+	BindMap() (map[string]any, error)
+
 	// Multipart form data
 	FormFile(name string) (multipart.File, *multipart.FileHeader, error)
+	FormFileLimited(name string, maxBytes int64, allowedTypes ...string) (multipart.File, *multipart.FileHeader, error)
 	FormFiles(name string) ([]*multipart.FileHeader, error)
+	DetectFileContentType(fileHeader *multipart.FileHeader) (string, error)
 	FormValue(name string) string
 	FormValues(name string) []string
 	ParseMultipartForm(maxMemory int64) error
 
+	// MultipartReader and EachPart stream multipart parts as they arrive,
+	// instead of buffering the form. They cannot be combined with FormValue,
+	// FormFile and the other form helpers on the same request.
+	MultipartReader() (*multipart.Reader, error)
+	EachPart(fn func(part *multipart.Part) error) error
+
 	// Response helpers
 	JSON(code int, v any) error
+	JSONWithETag(code int, v any) error
+	Problem(code int, p Problem) error
 	XML(code int, v any) error
 	String(code int, s string) error
 	Bytes(code int, data []byte) error
 	NoContent(code int) error
 	Redirect(code int, url string) error
 
+	// File download helpers
+	Attachment(code int, filename string, data []byte) error
+	Stream(code int, contentType string, r io.Reader) error
+
+	// JSONCompressed sends a JSON response compressed with gzip or deflate
+	// when the client accepts it and the body exceeds the compression threshold.
+	JSONCompressed(code int, v any) error
+	SetCompressionThreshold(size int)
+
 	// Fluent response builder
 	Status(code int) ResponseBuilder
 
@@ -95,6 +132,15 @@ type Context interface {
 	// Automatically flushes after writing.
 	WriteSSE(event string, data any) error
 
+	// WriteSSEEvent writes a Server-Sent Event with optional id and retry fields.
+	// Multi-line data is split into multiple data lines. Automatically flushes after writing.
+	WriteSSEEvent(ev SSEEvent) error
+
+	// StreamJSON starts a newline-delimited JSON response and returns a writer
+	// that encodes and flushes one value per line.
+	// Returns an error if the response writer doesn't support flushing.
+	StreamJSON(code int) (*JSONStreamWriter, error)
+
 	// Flush flushes any buffered response data to the client.
 	// Returns an error if the response writer doesn't support flushing.
 	Flush() error
@@ -102,6 +148,7 @@ type Context interface {
 	// Headers
 	Header(key string) string
 	SetHeader(key, value string)
+	SetHeadersFrom(v any)
 
 	// Context values
 	Set(key string, value any)
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// StatusRecorder is an http.ResponseWriter that records the status code and
+// body size written through it, for middleware that needs to observe the
+// response:
+//
+//	rec := http.NewStatusRecorder(w)
+//	next.ServeHTTP(rec, r)
+//	log.Info("served", log.Int("status", rec.Status()), log.Int64("bytes", rec.Size()))
+//
+// It implements http.Flusher and http.Hijacker by passing through to the
+// wrapped writer, so streaming responses such as SSE and connection upgrades
+// such as websockets keep working behind it, and exposes the wrapped writer
+// to http.ResponseController through Unwrap.
+type StatusRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	size        int64
+	wroteHeader bool
+}
+
+var (
+	_ http.Flusher  = (*StatusRecorder)(nil)
+	_ http.Hijacker = (*StatusRecorder)(nil)
+)
+
+// NewStatusRecorder returns a StatusRecorder wrapping w.
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+// Status returns the status code written, 200 if none was written yet since
+// net/http answers with 200 then, or 101 after a successful Hijack.
+func (w *StatusRecorder) Status() int {
+	return w.status
+}
+
+// Size returns the number of body bytes written.
+func (w *StatusRecorder) Size() int64 {
+	return w.size
+}
+
+// WriteHeader records the first status written and passes it on.
+func (w *StatusRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts the bytes written to the wrapped writer.
+func (w *StatusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+
+	return n, err
+}
+
+// Flush passes through to the wrapped writer if it supports flushing.
+func (w *StatusRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack passes through to the wrapped writer. It fails with an error
+// wrapping http.ErrNotSupported if the wrapped writer cannot be hijacked.
+// Once hijacked, the status is recorded as 101 Switching Protocols, as the
+// handler now answers on the connection itself.
+func (w *StatusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking: %w", http.ErrNotSupported)
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.status = http.StatusSwitchingProtocols
+	w.wroteHeader = true
+
+	return conn, rw, nil
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController.
+func (w *StatusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hijackableRecorder is a ResponseRecorder that can be hijacked.
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+
+	conn     net.Conn
+	hijacked bool
+}
+
+func (w *hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+
+	return w.conn, bufio.NewReadWriter(bufio.NewReader(w.conn), bufio.NewWriter(w.conn)), nil
+}
+
+func TestStatusRecorder(t *testing.T) {
+	w := httptest.NewRecorder()
+	rec := NewStatusRecorder(w)
+
+	assert.Equal(t, http.StatusOK, rec.Status())
+	assert.Zero(t, rec.Size())
+
+	rec.WriteHeader(http.StatusAccepted)
+	rec.WriteHeader(http.StatusInternalServerError)
+
+	_, err := rec.Write([]byte("hello "))
+	require.NoError(t, err)
+	_, err = rec.Write([]byte("world"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusAccepted, rec.Status())
+	assert.Equal(t, int64(11), rec.Size())
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "hello world", w.Body.String())
+}
+
+func TestStatusRecorder_ImplicitStatus(t *testing.T) {
+	rec := NewStatusRecorder(httptest.NewRecorder())
+
+	_, err := rec.Write([]byte("ok"))
+	require.NoError(t, err)
+
+	// A status written after the body is ignored, as net/http does
+	rec.WriteHeader(http.StatusNotFound)
+	assert.Equal(t, http.StatusOK, rec.Status())
+}
+
+func TestStatusRecorder_Flush(t *testing.T) {
+	w := httptest.NewRecorder()
+	rec := NewStatusRecorder(w)
+
+	rec.Flush()
+	assert.True(t, w.Flushed)
+
+	// SSE through a context wrapping the recorder still flushes
+	w = httptest.NewRecorder()
+	rec = NewStatusRecorder(w)
+
+	ctx := NewContext(rec, httptest.NewRequest(http.MethodGet, "/events", nil), nil)
+	require.NoError(t, ctx.WriteSSE("update", "ready"))
+	require.NoError(t, ctx.Flush())
+
+	assert.True(t, w.Flushed)
+	assert.Contains(t, w.Body.String(), "event: update\n")
+	assert.Equal(t, int64(w.Body.Len()), rec.Size())
+
+	// http.ResponseController reaches the wrapped writer
+	w = httptest.NewRecorder()
+	require.NoError(t, http.NewResponseController(NewStatusRecorder(w)).Flush())
+	assert.True(t, w.Flushed)
+}
+
+func TestStatusRecorder_Hijack(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	w := &hijackableRecorder{ResponseRecorder: httptest.NewRecorder(), conn: server}
+	rec := NewStatusRecorder(w)
+
+	conn, rw, err := rec.Hijack()
+	require.NoError(t, err)
+	assert.True(t, w.hijacked)
+	assert.Same(t, server, conn)
+	assert.NotNil(t, rw)
+	assert.Equal(t, http.StatusSwitchingProtocols, rec.Status())
+}
+
+func TestStatusRecorder_HijackUnsupported(t *testing.T) {
+	rec := NewStatusRecorder(httptest.NewRecorder())
+
+	_, _, err := rec.Hijack()
+	require.ErrorIs(t, err, http.ErrNotSupported)
+	assert.Equal(t, http.StatusOK, rec.Status())
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// JSONStreamWriter writes newline-delimited JSON (NDJSON) to a response.
+// Each call to Write encodes a single value on its own line and flushes it
+// to the client, so large result sets never need to be buffered in memory.
+type JSONStreamWriter struct {
+	ctx     *Ctx
+	encoder *json.Encoder
+	count   int
+}
+
+// StreamJSON starts a newline-delimited JSON response with the given status.
+// Returns an error if the response writer doesn't support flushing; in that
+// case no headers or status are written.
+func (c *Ctx) StreamJSON(code int) (*JSONStreamWriter, error) {
+	if _, ok := c.response.(http.Flusher); !ok {
+		return nil, errors.New("response writer does not support flushing")
+	}
+
+	c.response.Header().Set("Content-Type", "application/x-ndjson")
+	c.response.Header().Del("Content-Length")
+	c.response.WriteHeader(code)
+
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+
+	return &JSONStreamWriter{
+		ctx:     c,
+		encoder: json.NewEncoder(c.response),
+	}, nil
+}
+
+// Write encodes v as a single JSON line and flushes it to the client.
+func (w *JSONStreamWriter) Write(v any) error {
+	if err := w.encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON line: %w", err)
+	}
+
+	w.count++
+
+	return w.ctx.Flush()
+}
+
+// Count returns the number of values written so far.
+func (w *JSONStreamWriter) Count() int {
+	return w.count
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_StreamJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	stream, err := ctx.StreamJSON(http.StatusOK)
+	require.NoError(t, err)
+
+	type row struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	for i, name := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, stream.Write(row{ID: i + 1, Name: name}))
+	}
+
+	assert.Equal(t, 3, stream.Count())
+	assert.True(t, rec.flushed)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+	scanner := bufio.NewScanner(strings.NewReader(rec.Body.String()))
+
+	var rows []row
+
+	for scanner.Scan() {
+		var r row
+
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+
+		rows = append(rows, r)
+	}
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, "alice", rows[0].Name)
+	assert.Equal(t, "bob", rows[1].Name)
+	assert.Equal(t, "carol", rows[2].Name)
+}
+
+func TestContext_StreamJSON_NotFlushable(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	rec := newNonFlushableWriter()
+
+	ctx := NewContext(rec, req, nil)
+
+	stream, err := ctx.StreamJSON(http.StatusOK)
+	require.Error(t, err)
+	assert.Nil(t, stream)
+	assert.Contains(t, err.Error(), "does not support flushing")
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+}
+
+func TestJSONStreamWriter_EncodeError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	rec := newFlushableRecorder()
+
+	ctx := NewContext(rec, req, nil)
+
+	stream, err := ctx.StreamJSON(http.StatusOK)
+	require.NoError(t, err)
+
+	err = stream.Write(make(chan int))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to encode JSON line")
+	assert.Equal(t, 0, stream.Count())
+}
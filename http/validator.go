@@ -42,6 +42,12 @@ func validateISO8601(fl validator.FieldLevel) bool {
 
 // validateStruct validates struct fields using go-playground/validator and custom tags.
 func (c *Ctx) validateStruct(v any, rt reflect.Type, errs *val.ValidationError) error {
+	return c.validateStructSource(v, rt, "", errs)
+}
+
+// validateStructSource validates struct fields like validateStruct. A non-empty
+// source restricts validation to fields tagged with that source.
+func (c *Ctx) validateStructSource(v any, rt reflect.Type, source string, errs *val.ValidationError) error {
 	validate := getValidator()
 
 	// First, validate using go-playground/validator (only if validate tag exists).
@@ -49,6 +55,14 @@ func (c *Ctx) validateStruct(v any, rt reflect.Type, errs *val.ValidationError)
 	if err != nil {
 		var validationErrs validator.ValidationErrors
 		if errors.As(err, &validationErrs) {
+			if source != "" {
+				validationErrs = slices.DeleteFunc(validationErrs, func(fe validator.FieldError) bool {
+					field, ok := structFieldByNamespace(rt, fe.StructNamespace())
+
+					return !ok || field.Tag.Get(source) == ""
+				})
+			}
+
 			c.mapValidationErrors(validationErrs, errs)
 		}
 	}
@@ -59,11 +73,47 @@ func (c *Ctx) validateStruct(v any, rt reflect.Type, errs *val.ValidationError)
 		rv = rv.Elem()
 	}
 
-	c.validateCustomTags(rv, rt, errs)
+	c.validateCustomTags(rv, rt, source, errs)
 
 	return nil
 }
 
+// structFieldByNamespace resolves a validator struct namespace such as
+// "Request.BaseParams.Limit" to the struct field it names.
+func structFieldByNamespace(rt reflect.Type, namespace string) (reflect.StructField, bool) {
+	names := strings.Split(namespace, ".")
+
+	var (
+		field reflect.StructField
+		found bool
+	)
+
+	// The first segment is the name of the top-level struct type
+	for _, name := range names[1:] {
+		for rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array || rt.Kind() == reflect.Map {
+			rt = rt.Elem()
+		}
+
+		if rt.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+
+		// Strip slice and map indexes such as "Items[0]"
+		if idx := strings.IndexByte(name, '['); idx >= 0 {
+			name = name[:idx]
+		}
+
+		field, found = rt.FieldByName(name)
+		if !found {
+			return reflect.StructField{}, false
+		}
+
+		rt = field.Type
+	}
+
+	return field, found
+}
+
 // mapValidationErrors maps validator.ValidationErrors to our ValidationError format.
 func (c *Ctx) mapValidationErrors(validationErrs validator.ValidationErrors, errors *val.ValidationError) {
 	for _, err := range validationErrs {
@@ -145,7 +195,8 @@ func (c *Ctx) getErrorCode(err validator.FieldError) string {
 }
 
 // validateCustomTags validates fields with our custom validation tags.
-func (c *Ctx) validateCustomTags(rv reflect.Value, rt reflect.Type, errors *val.ValidationError) {
+// A non-empty source restricts validation to fields with that tag.
+func (c *Ctx) validateCustomTags(rv reflect.Value, rt reflect.Type, source string, errors *val.ValidationError) {
 	for i := range rt.NumField() {
 		field := rt.Field(i)
 		fieldValue := rv.Field(i)
@@ -176,13 +227,17 @@ func (c *Ctx) validateCustomTags(rv reflect.Value, rt reflect.Type, errors *val.
 				}
 
 				if embeddedType.Kind() == reflect.Struct {
-					c.validateCustomTags(embeddedValue, embeddedType, errors)
+					c.validateCustomTags(embeddedValue, embeddedType, source, errors)
 
 					continue
 				}
 			}
 		}
 
+		if source != "" && field.Tag.Get(source) == "" {
+			continue
+		}
+
 		// Check if field has any of our custom validation tags
 		hasCustomTags := field.Tag.Get("format") != "" ||
 			field.Tag.Get("minLength") != "" ||
@@ -197,12 +252,22 @@ func (c *Ctx) validateCustomTags(rv reflect.Value, rt reflect.Type, errors *val.
 		isParamField := val.IsParameterField(field)
 		fieldRequired := val.IsFieldRequired(field)
 
+		// Fields named by the naming strategy are optional, and checked for
+		// presence by bindUntaggedField when tagged required:"true"
+		defaultName, defaultNamed := c.defaultParamName(field)
+		if defaultNamed {
+			fieldRequired = false
+		}
+
 		// Skip if no validation needed: no custom tags AND (not required OR is optional)
 		if !hasCustomTags && !fieldRequired {
 			continue
 		}
 
 		fieldName := val.GetFieldName(field)
+		if defaultNamed {
+			fieldName = defaultName
+		}
 
 		// Handle pointer fields
 		if fieldValue.Kind() == reflect.Ptr {
@@ -65,6 +65,7 @@ type BeautifulLogger struct {
 	mu          sync.RWMutex
 	colorScheme *BeautifulColorScheme
 	format      FormatConfig
+	once        *onceFilter
 }
 
 // BeautifulColorScheme defines the color palette for beautiful output.
@@ -107,6 +108,7 @@ func NewBeautifulLogger(name string) *BeautifulLogger {
 		fields:      make(map[string]any),
 		colorScheme: DefaultBeautifulColorScheme(),
 		format:      DefaultFormatConfig(),
+		once:        newOnceFilter(0),
 	}
 }
 
@@ -124,6 +126,14 @@ func (bl *BeautifulLogger) WithLevel(level zapcore.Level) *BeautifulLogger {
 	return bl
 }
 
+// WithLogOnceTTL sets the window after which a LogOnce key is logged again.
+// Zero logs each key only once.
+func (bl *BeautifulLogger) WithLogOnceTTL(ttl time.Duration) *BeautifulLogger {
+	bl.once = newOnceFilter(ttl)
+
+	return bl
+}
+
 // WithShowCaller enables/disables caller information.
 func (bl *BeautifulLogger) WithShowCaller(show bool) *BeautifulLogger {
 	bl.format.ShowCaller = show
@@ -212,6 +222,25 @@ func (bl *BeautifulLogger) Fatalf(template string, args ...any) {
 	bl.Fatal(fmt.Sprintf(template, args...))
 }
 
+func (bl *BeautifulLogger) LogOnce(key string, level LogLevel, msg string, fields ...Field) {
+	if !bl.once.allow(key) {
+		return
+	}
+
+	switch zapLevel(level) {
+	case zapcore.DebugLevel:
+		bl.Debug(msg, fields...)
+	case zapcore.WarnLevel:
+		bl.Warn(msg, fields...)
+	case zapcore.ErrorLevel:
+		bl.Error(msg, fields...)
+	case zapcore.FatalLevel:
+		bl.Fatal(msg, fields...)
+	default:
+		bl.Info(msg, fields...)
+	}
+}
+
 func (bl *BeautifulLogger) With(fields ...Field) Logger {
 	newLogger := bl.clone()
 	for _, f := range fields {
@@ -417,6 +446,7 @@ func (bl *BeautifulLogger) clone() *BeautifulLogger {
 		fields:      newFields,
 		colorScheme: bl.colorScheme,
 		format:      bl.format,
+		once:        bl.once,
 	}
 }
 
@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -22,6 +23,12 @@ type Logger interface {
 	Errorf(template string, args ...any)
 	Fatalf(template string, args ...any)
 
+	// LogOnce logs msg at level only the first time key is seen, so persistent
+	// conditions such as deprecated configuration are reported without
+	// spamming. Depending on the logger, a key may be logged again after a
+	// configured TTL.
+	LogOnce(key string, level LogLevel, msg string, fields ...Field)
+
 	// Context and enrichment
 	With(fields ...Field) Logger
 	WithContext(ctx context.Context) Logger
@@ -34,6 +41,17 @@ type Logger interface {
 	Sync() error
 }
 
+// LevelSetter is implemented by loggers whose minimum level can be changed at
+// runtime, for example to enable debug logging from an admin endpoint:
+//
+//	if ls, ok := logger.(log.LevelSetter); ok {
+//	    ls.SetLevel(log.LevelDebug)
+//	}
+type LevelSetter interface {
+	SetLevel(level LogLevel)
+	Level() LogLevel
+}
+
 // SugarLogger provides a more flexible API.
 type SugarLogger interface {
 	Debugw(msg string, keysAndValues ...any)
@@ -59,4 +77,7 @@ type LoggingConfig struct {
 	Format      string   `env:"LOG_FORMAT"  mapstructure:"format"      yaml:"format"`
 	Environment string   `env:"ENVIRONMENT" mapstructure:"environment" yaml:"environment"`
 	Output      string   `env:"LOG_OUTPUT"  mapstructure:"output"      yaml:"output"`
+	// LogOnceTTL is the window after which a LogOnce key is logged again.
+	// Zero logs each key only once.
+	LogOnceTTL time.Duration `env:"LOG_ONCE_TTL" mapstructure:"log_once_ttl" yaml:"log_once_ttl"`
 }
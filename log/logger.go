@@ -18,7 +18,9 @@ var (
 
 // logger implements the Logger interface using zap.
 type logger struct {
-	zap *zap.Logger
+	zap   *zap.Logger
+	level zap.AtomicLevel
+	once  *onceFilter
 }
 
 // noopLogger implements Logger interface but does nothing.
@@ -48,42 +50,65 @@ const (
 func NewLogger(config LoggingConfig) Logger {
 	var zapLogger *zap.Logger
 
-	// Determine log level
-	logLevel := zapcore.InfoLevel
-
-	switch strings.ToLower(string(config.Level)) {
-	case "debug":
-		logLevel = zapcore.DebugLevel
-	case "info":
-		logLevel = zapcore.InfoLevel
-	case "warn", "warning":
-		logLevel = zapcore.WarnLevel
-	case "error":
-		logLevel = zapcore.ErrorLevel
-	case "fatal":
-		logLevel = zapcore.FatalLevel
-	}
+	// Determine log level; the atomic level allows changing it at runtime
+	level := zap.NewAtomicLevelAt(zapLevel(config.Level))
 
 	// Configure logger based on environment
 	if config.Environment == "production" || config.Format == "json" {
 		zapConfig := zap.NewProductionConfig()
-		zapConfig.Level = zap.NewAtomicLevelAt(logLevel)
+		zapConfig.Level = level
 		zapLogger, _ = zapConfig.Build(zap.AddCallerSkip(1))
 	} else {
-		zapLogger = createDevelopmentLogger(logLevel)
+		zapLogger = createDevelopmentLogger(level)
 	}
 
-	return &logger{zap: zapLogger}
+	return &logger{zap: zapLogger, level: level, once: newOnceFilter(config.LogOnceTTL)}
+}
+
+// zapLevel converts a LogLevel to the corresponding zap level.
+// Unknown levels default to info.
+func zapLevel(level LogLevel) zapcore.Level {
+	switch strings.ToLower(string(level)) {
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn", "warning":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	case "fatal":
+		return zapcore.FatalLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// logLevel converts a zap level to the corresponding LogLevel.
+// Panic levels are reported as fatal.
+func logLevel(level zapcore.Level) LogLevel {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return LevelDebug
+	case level == zapcore.InfoLevel:
+		return LevelInfo
+	case level == zapcore.WarnLevel:
+		return LevelWarn
+	case level == zapcore.ErrorLevel:
+		return LevelError
+	default:
+		return LevelFatal
+	}
 }
 
 // NewDevelopmentLogger creates a development logger with enhanced colors.
 func NewDevelopmentLogger() Logger {
-	return &logger{zap: createDevelopmentLogger(zapcore.DebugLevel)}
+	return NewDevelopmentLoggerWithLevel(zapcore.DebugLevel)
 }
 
 // NewDevelopmentLoggerWithLevel creates a development logger with specified level.
 func NewDevelopmentLoggerWithLevel(level zapcore.Level) Logger {
-	return &logger{zap: createDevelopmentLogger(level)}
+	atomicLevel := zap.NewAtomicLevelAt(level)
+
+	return &logger{zap: createDevelopmentLogger(atomicLevel), level: atomicLevel, once: newOnceFilter(0)}
 }
 
 // NewProductionLogger creates a production logger.
@@ -92,7 +117,7 @@ func NewProductionLogger() Logger {
 	config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	zapLogger, _ := config.Build(zap.AddCallerSkip(1))
 
-	return &logger{zap: zapLogger}
+	return &logger{zap: zapLogger, level: config.Level, once: newOnceFilter(0)}
 }
 
 // NewNoopLogger creates a logger that does nothing.
@@ -101,7 +126,7 @@ func NewNoopLogger() Logger {
 }
 
 // createDevelopmentLogger creates a development logger with enhanced formatting.
-func createDevelopmentLogger(level zapcore.Level) *zap.Logger {
+func createDevelopmentLogger(level zap.AtomicLevel) *zap.Logger {
 	encoderConfig := zapcore.EncoderConfig{
 		TimeKey:        "ts",
 		LevelKey:       "level",
@@ -126,7 +151,7 @@ func createDevelopmentLogger(level zapcore.Level) *zap.Logger {
 	core := zapcore.NewCore(
 		createColoredEncoder(encoderConfig),
 		writeSyncer,
-		zap.NewAtomicLevelAt(level),
+		level,
 	)
 
 	return zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
@@ -347,8 +372,16 @@ func (l *logger) Fatalf(template string, args ...any) {
 	l.zap.Fatal(fmt.Sprintf(template, args...))
 }
 
+func (l *logger) LogOnce(key string, level LogLevel, msg string, fields ...Field) {
+	if !l.once.allow(key) {
+		return
+	}
+
+	l.zap.Log(zapLevel(level), msg, fieldsToZap(fields)...)
+}
+
 func (l *logger) With(fields ...Field) Logger {
-	return &logger{zap: l.zap.With(fieldsToZap(fields)...)}
+	return &logger{zap: l.zap.With(fieldsToZap(fields)...), level: l.level, once: l.once}
 }
 
 func (l *logger) WithContext(ctx context.Context) Logger {
@@ -359,14 +392,25 @@ func (l *logger) WithContext(ctx context.Context) Logger {
 	// Use the new context-aware field constructors
 	contextFields := ContextFields(ctx)
 	if len(contextFields) > 0 {
-		return &logger{zap: l.zap.With(fieldsToZap(contextFields)...)}
+		return &logger{zap: l.zap.With(fieldsToZap(contextFields)...), level: l.level, once: l.once}
 	}
 
 	return l
 }
 
 func (l *logger) Named(name string) Logger {
-	return &logger{zap: l.zap.Named(name)}
+	return &logger{zap: l.zap.Named(name), level: l.level, once: l.once}
+}
+
+// SetLevel changes the minimum level of the logger and every logger derived
+// from it with With, WithContext or Named.
+func (l *logger) SetLevel(level LogLevel) {
+	l.level.SetLevel(zapLevel(level))
+}
+
+// Level returns the current minimum level of the logger.
+func (l *logger) Level() LogLevel {
+	return logLevel(l.level.Level())
 }
 
 func (l *logger) Sugar() SugarLogger {
@@ -395,6 +439,12 @@ func (l *noopLogger) Named(name string) Logger               { return l }
 func (l *noopLogger) Sugar() SugarLogger                     { return &noopSugarLogger{} }
 func (l *noopLogger) Sync() error                            { return nil }
 
+func (l *noopLogger) LogOnce(key string, level LogLevel, msg string, fields ...Field) {}
+
+// SetLevel is a no-op; Level always reports LevelInfo.
+func (l *noopLogger) SetLevel(level LogLevel) {}
+func (l *noopLogger) Level() LogLevel         { return LevelInfo }
+
 // noopSugarLogger implements SugarLogger interface but does nothing.
 type noopSugarLogger struct{}
 
@@ -501,6 +551,34 @@ func LoggerFromContext(ctx context.Context) Logger {
 	return GetGlobalLogger()
 }
 
+// ContextWithLogger stores a logger in the context for retrieval with
+// FromContext. It is equivalent to WithLogger.
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return WithLogger(ctx, logger)
+}
+
+// FromContext returns the logger stored in the context, or the global logger,
+// with the request, trace and user IDs stored in the context attached as
+// request_id, trace_id and user_id fields. Middleware can store a scoped
+// logger once and handlers retrieve it without rebuilding fields:
+//
+//	ctx = log.ContextWithLogger(ctx, logger)
+//	ctx = log.WithRequestID(ctx, requestID)
+//	...
+//	log.FromContext(ctx).Info("handled") // includes request_id
+func FromContext(ctx context.Context) Logger {
+	logger := LoggerFromContext(ctx)
+	if ctx == nil {
+		return logger
+	}
+
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		return logger.With(fields...)
+	}
+
+	return logger
+}
+
 // WithRequestID adds a request ID to the context.
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey, requestID)
@@ -0,0 +1,219 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var errTest = errors.New("boom")
+
+// newObservedLogger returns a logger writing to an in-memory observer core.
+func newObservedLogger(level LogLevel) (*logger, *observer.ObservedLogs) {
+	atomicLevel := zap.NewAtomicLevelAt(zapLevel(level))
+	core, logs := observer.New(atomicLevel)
+
+	return &logger{zap: zap.New(core), level: atomicLevel, once: newOnceFilter(0)}, logs
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	l, logs := newObservedLogger(LevelInfo)
+
+	var _ LevelSetter = l
+
+	l.Debug("before")
+
+	if logs.Len() != 0 {
+		t.Fatalf("expected debug log to be filtered at info level, got %d entries", logs.Len())
+	}
+
+	l.SetLevel(LevelDebug)
+
+	if got := l.Level(); got != LevelDebug {
+		t.Fatalf("Level() = %q, want %q", got, LevelDebug)
+	}
+
+	l.Debug("after")
+
+	entries := logs.TakeAll()
+	if len(entries) != 1 || entries[0].Message != "after" {
+		t.Fatalf("expected only the debug log after SetLevel, got %v", entries)
+	}
+
+	l.SetLevel(LevelWarn)
+	l.Info("filtered")
+
+	if logs.Len() != 0 {
+		t.Fatalf("expected info log to be filtered at warn level, got %d entries", logs.Len())
+	}
+}
+
+func TestLogger_SetLevelAffectsDerivedLoggers(t *testing.T) {
+	l, logs := newObservedLogger(LevelInfo)
+
+	child := l.With(String("request_id", "abc")).Named("child")
+
+	l.SetLevel(LevelDebug)
+	child.Debug("from child")
+
+	if logs.Len() != 1 {
+		t.Fatalf("expected derived logger to follow the parent level, got %d entries", logs.Len())
+	}
+
+	if ls, ok := child.(LevelSetter); !ok || ls.Level() != LevelDebug {
+		t.Fatal("expected derived logger to report the shared level")
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := map[zapcore.Level]LogLevel{
+		zapcore.DebugLevel:  LevelDebug,
+		zapcore.InfoLevel:   LevelInfo,
+		zapcore.WarnLevel:   LevelWarn,
+		zapcore.ErrorLevel:  LevelError,
+		zapcore.DPanicLevel: LevelFatal,
+		zapcore.FatalLevel:  LevelFatal,
+	}
+
+	for level, want := range tests {
+		if got := logLevel(level); got != want {
+			t.Errorf("logLevel(%v) = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestNoopLogger_SetLevel(t *testing.T) {
+	l := &noopLogger{}
+
+	var _ LevelSetter = l
+
+	l.SetLevel(LevelDebug)
+
+	if got := l.Level(); got != LevelInfo {
+		t.Fatalf("Level() = %q, want %q", got, LevelInfo)
+	}
+}
+
+func TestLogger_WithBindsFields(t *testing.T) {
+	l, logs := newObservedLogger(LevelDebug)
+
+	reqLogger := l.With(String("request_id", "req-1"), String("trace_id", "trace-1"))
+
+	reqLogger.Info("first")
+	reqLogger.Warn("second", Int("attempt", 2))
+	reqLogger.Error("third", Error(errTest), Duration("elapsed", 0))
+	reqLogger.LogOnce("key", LevelDebug, "fourth")
+	l.Info("parent")
+
+	entries := logs.TakeAll()
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(entries))
+	}
+
+	for _, entry := range entries[:4] {
+		fields := entry.ContextMap()
+		if fields["request_id"] != "req-1" || fields["trace_id"] != "trace-1" {
+			t.Errorf("entry %q is missing bound fields: %v", entry.Message, fields)
+		}
+	}
+
+	if got := entries[1].ContextMap()["attempt"]; got != int64(2) {
+		t.Errorf("attempt = %v, want 2", got)
+	}
+
+	if got := entries[2].ContextMap()["error"]; got != errTest.Error() {
+		t.Errorf("error = %v, want %q", got, errTest.Error())
+	}
+
+	// Binding fields must not leak into the parent logger
+	if _, ok := entries[4].ContextMap()["request_id"]; ok {
+		t.Error("parent logger should not carry the child's fields")
+	}
+}
+
+func TestLogger_WithIsCumulative(t *testing.T) {
+	l, logs := newObservedLogger(LevelInfo)
+
+	l.With(String("service", "api")).With(String("request_id", "req-1")).Info("nested")
+
+	fields := logs.All()[0].ContextMap()
+	if fields["service"] != "api" || fields["request_id"] != "req-1" {
+		t.Errorf("expected fields from both With calls, got %v", fields)
+	}
+}
+
+func TestNoopLogger_WithReturnsSelf(t *testing.T) {
+	l := &noopLogger{}
+
+	if got := l.With(String("request_id", "req-1")); got != Logger(l) {
+		t.Error("expected noop With to return the same logger")
+	}
+}
+
+func TestFromContext_RoundTrip(t *testing.T) {
+	l, logs := newObservedLogger(LevelInfo)
+
+	ctx := ContextWithLogger(context.Background(), l)
+
+	got := FromContext(ctx)
+	if got != Logger(l) {
+		t.Fatal("expected FromContext to return the stored logger when no IDs are set")
+	}
+
+	got.Info("plain")
+
+	if fields := logs.TakeAll()[0].ContextMap(); len(fields) != 0 {
+		t.Errorf("expected no fields, got %v", fields)
+	}
+}
+
+func TestFromContext_AttachesIDs(t *testing.T) {
+	l, logs := newObservedLogger(LevelInfo)
+
+	ctx := ContextWithLogger(context.Background(), l)
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithTraceID(ctx, "trace-1")
+	ctx = WithUserID(ctx, "user-1")
+
+	FromContext(ctx).Info("handled", String("route", "/users"))
+
+	fields := logs.TakeAll()[0].ContextMap()
+
+	want := map[string]any{
+		"request_id": "req-1",
+		"trace_id":   "trace-1",
+		"user_id":    "user-1",
+		"route":      "/users",
+	}
+
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+}
+
+func TestFromContext_FallsBackToGlobalLogger(t *testing.T) {
+	previous := globalLogger
+	defer func() { globalLogger = previous }()
+
+	l, logs := newObservedLogger(LevelInfo)
+	SetGlobalLogger(l)
+
+	//nolint:staticcheck // Verifies nil context handling
+	FromContext(nil).Info("nil context")
+	FromContext(WithRequestID(context.Background(), "req-2")).Info("global")
+
+	entries := logs.TakeAll()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	if got := entries[1].ContextMap()["request_id"]; got != "req-2" {
+		t.Errorf("request_id = %v, want req-2", got)
+	}
+}
@@ -303,3 +303,28 @@ func BenchmarkFieldCreation(b *testing.B) {
 		}
 	})
 }
+
+// TestLogOnce tests that repeated LogOnce calls with the same key log once.
+func TestLogOnce(t *testing.T) {
+	logger := log.NewTestLogger()
+	tl := logger.(*log.TestLogger)
+
+	for range 5 {
+		logger.LogOnce("deprecated-config", log.LevelWarn, "config option is deprecated")
+	}
+
+	if count := tl.CountLogs("WARN"); count != 1 {
+		t.Fatalf("expected 1 warning, got %d", count)
+	}
+
+	logger.LogOnce("optional-dependency", log.LevelInfo, "optional dependency unreachable")
+	logger.With(log.String("component", "cache")).LogOnce("optional-dependency", log.LevelInfo, "optional dependency unreachable")
+
+	if count := tl.CountLogs("INFO"); count != 1 {
+		t.Fatalf("expected 1 info log, got %d", count)
+	}
+
+	// Loggers without an output sink must accept the call as well.
+	log.NewNoopLogger().LogOnce("key", log.LevelError, "ignored")
+	log.NewProductionLogger().LogOnce("key", log.LevelDebug, "filtered by level")
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// onceSweepThreshold is the number of tracked keys above which expired keys
+// are swept, so keys for conditions that have cleared do not accumulate.
+const onceSweepThreshold = 1024
+
+// onceFilter tracks the keys logged through LogOnce. Loggers derived with
+// With, WithContext, or Named share the filter of their parent, so a key is
+// deduplicated across all of them.
+type onceFilter struct {
+	ttl  time.Duration
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// newOnceFilter creates a filter. A zero or negative ttl logs each key only
+// once for the lifetime of the filter; a positive ttl logs it again once ttl
+// has elapsed since it was last logged.
+func newOnceFilter(ttl time.Duration) *onceFilter {
+	return &onceFilter{
+		ttl:  ttl,
+		seen: make(map[string]time.Time),
+	}
+}
+
+// allow reports whether key should be logged now and records it if so.
+func (f *onceFilter) allow(key string) bool {
+	now := time.Now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if last, ok := f.seen[key]; ok && (f.ttl <= 0 || now.Sub(last) < f.ttl) {
+		return false
+	}
+
+	if f.ttl > 0 && len(f.seen) >= onceSweepThreshold {
+		for k, last := range f.seen {
+			if now.Sub(last) >= f.ttl {
+				delete(f.seen, k)
+			}
+		}
+	}
+
+	f.seen[key] = now
+
+	return true
+}
@@ -0,0 +1,57 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnceFilter(t *testing.T) {
+	t.Run("WithoutTTL", func(t *testing.T) {
+		f := newOnceFilter(0)
+
+		if !f.allow("key") {
+			t.Fatal("expected first call to be allowed")
+		}
+
+		if f.allow("key") {
+			t.Fatal("expected repeated call to be suppressed")
+		}
+
+		if !f.allow("other") {
+			t.Fatal("expected a different key to be allowed")
+		}
+	})
+
+	t.Run("WithTTL", func(t *testing.T) {
+		f := newOnceFilter(20 * time.Millisecond)
+
+		if !f.allow("key") {
+			t.Fatal("expected first call to be allowed")
+		}
+
+		if f.allow("key") {
+			t.Fatal("expected call within TTL to be suppressed")
+		}
+
+		time.Sleep(30 * time.Millisecond)
+
+		if !f.allow("key") {
+			t.Fatal("expected call after TTL to be allowed")
+		}
+	})
+
+	t.Run("SweepsExpiredKeys", func(t *testing.T) {
+		f := newOnceFilter(time.Millisecond)
+
+		for i := range onceSweepThreshold {
+			f.allow(string(rune(i)))
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		f.allow("fresh")
+
+		if len(f.seen) != 1 {
+			t.Fatalf("expected expired keys to be swept, %d remain", len(f.seen))
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// samplingLogger wraps a Logger and drops repeated messages. Loggers derived
+// with With, WithContext, or Named share the sampler of their parent, so
+// identical messages are counted across all of them.
+type samplingLogger struct {
+	inner   Logger
+	sampler *sampler
+}
+
+// NewSamplingLogger wraps inner so that, per tick, only the first `first`
+// entries with the same level and message are logged, followed by every
+// `thereafter`-th entry. A thereafter of zero or less drops everything after
+// the first entries. Fatal entries and LogOnce are never sampled.
+//
+// Formatted methods are sampled by template, so messages that only differ in
+// their arguments count as identical.
+//
+//	logger = log.NewSamplingLogger(logger, time.Second, 10, 100)
+func NewSamplingLogger(inner Logger, tick time.Duration, first, thereafter int) Logger {
+	// Skip the wrapper frame so zap reports the caller of the sampling logger
+	if l, ok := inner.(*logger); ok {
+		inner = &logger{zap: l.zap.WithOptions(zap.AddCallerSkip(1)), level: l.level, once: l.once}
+	}
+
+	return &samplingLogger{
+		inner: inner,
+		sampler: &sampler{
+			tick:       tick,
+			first:      uint64(max(first, 0)),
+			thereafter: uint64(max(thereafter, 0)),
+			counts:     make(map[samplingKey]*samplingCounter),
+		},
+	}
+}
+
+// samplingKey identifies identical log entries.
+type samplingKey struct {
+	level LogLevel
+	msg   string
+}
+
+// samplingCounter counts the entries of a key within the current tick.
+type samplingCounter struct {
+	resetAt time.Time
+	n       uint64
+}
+
+// sampler decides which entries are logged.
+type sampler struct {
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+
+	mu     sync.Mutex
+	counts map[samplingKey]*samplingCounter
+}
+
+// allow reports whether an entry with the given level and message should be logged.
+func (s *sampler) allow(level LogLevel, msg string) bool {
+	now := time.Now()
+	key := samplingKey{level: level, msg: msg}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= onceSweepThreshold {
+			for k, c := range s.counts {
+				if !now.Before(c.resetAt) {
+					delete(s.counts, k)
+				}
+			}
+		}
+
+		counter = &samplingCounter{}
+		s.counts[key] = counter
+	}
+
+	if !now.Before(counter.resetAt) {
+		counter.resetAt = now.Add(s.tick)
+		counter.n = 0
+	}
+
+	counter.n++
+
+	if counter.n <= s.first {
+		return true
+	}
+
+	return s.thereafter > 0 && (counter.n-s.first)%s.thereafter == 0
+}
+
+func (l *samplingLogger) Debug(msg string, fields ...Field) {
+	if l.sampler.allow(LevelDebug, msg) {
+		l.inner.Debug(msg, fields...)
+	}
+}
+
+func (l *samplingLogger) Info(msg string, fields ...Field) {
+	if l.sampler.allow(LevelInfo, msg) {
+		l.inner.Info(msg, fields...)
+	}
+}
+
+func (l *samplingLogger) Warn(msg string, fields ...Field) {
+	if l.sampler.allow(LevelWarn, msg) {
+		l.inner.Warn(msg, fields...)
+	}
+}
+
+func (l *samplingLogger) Error(msg string, fields ...Field) {
+	if l.sampler.allow(LevelError, msg) {
+		l.inner.Error(msg, fields...)
+	}
+}
+
+func (l *samplingLogger) Fatal(msg string, fields ...Field) {
+	l.inner.Fatal(msg, fields...)
+}
+
+func (l *samplingLogger) Debugf(template string, args ...any) {
+	if l.sampler.allow(LevelDebug, template) {
+		l.inner.Debugf(template, args...)
+	}
+}
+
+func (l *samplingLogger) Infof(template string, args ...any) {
+	if l.sampler.allow(LevelInfo, template) {
+		l.inner.Infof(template, args...)
+	}
+}
+
+func (l *samplingLogger) Warnf(template string, args ...any) {
+	if l.sampler.allow(LevelWarn, template) {
+		l.inner.Warnf(template, args...)
+	}
+}
+
+func (l *samplingLogger) Errorf(template string, args ...any) {
+	if l.sampler.allow(LevelError, template) {
+		l.inner.Errorf(template, args...)
+	}
+}
+
+func (l *samplingLogger) Fatalf(template string, args ...any) {
+	l.inner.Fatalf(template, args...)
+}
+
+func (l *samplingLogger) LogOnce(key string, level LogLevel, msg string, fields ...Field) {
+	l.inner.LogOnce(key, level, msg, fields...)
+}
+
+func (l *samplingLogger) With(fields ...Field) Logger {
+	return &samplingLogger{inner: l.inner.With(fields...), sampler: l.sampler}
+}
+
+func (l *samplingLogger) WithContext(ctx context.Context) Logger {
+	return &samplingLogger{inner: l.inner.WithContext(ctx), sampler: l.sampler}
+}
+
+func (l *samplingLogger) Named(name string) Logger {
+	return &samplingLogger{inner: l.inner.Named(name), sampler: l.sampler}
+}
+
+func (l *samplingLogger) Sugar() SugarLogger {
+	return &samplingSugarLogger{inner: l.inner.Sugar(), sampler: l.sampler}
+}
+
+func (l *samplingLogger) Sync() error {
+	return l.inner.Sync()
+}
+
+// SetLevel changes the level of the wrapped logger if it is a LevelSetter.
+func (l *samplingLogger) SetLevel(level LogLevel) {
+	if ls, ok := l.inner.(LevelSetter); ok {
+		ls.SetLevel(level)
+	}
+}
+
+// Level returns the level of the wrapped logger, or LevelInfo if it is not a
+// LevelSetter.
+func (l *samplingLogger) Level() LogLevel {
+	if ls, ok := l.inner.(LevelSetter); ok {
+		return ls.Level()
+	}
+
+	return LevelInfo
+}
+
+// samplingSugarLogger applies the sampler of a samplingLogger to its sugared logger.
+type samplingSugarLogger struct {
+	inner   SugarLogger
+	sampler *sampler
+}
+
+func (s *samplingSugarLogger) Debugw(msg string, keysAndValues ...any) {
+	if s.sampler.allow(LevelDebug, msg) {
+		s.inner.Debugw(msg, keysAndValues...)
+	}
+}
+
+func (s *samplingSugarLogger) Infow(msg string, keysAndValues ...any) {
+	if s.sampler.allow(LevelInfo, msg) {
+		s.inner.Infow(msg, keysAndValues...)
+	}
+}
+
+func (s *samplingSugarLogger) Warnw(msg string, keysAndValues ...any) {
+	if s.sampler.allow(LevelWarn, msg) {
+		s.inner.Warnw(msg, keysAndValues...)
+	}
+}
+
+func (s *samplingSugarLogger) Errorw(msg string, keysAndValues ...any) {
+	if s.sampler.allow(LevelError, msg) {
+		s.inner.Errorw(msg, keysAndValues...)
+	}
+}
+
+func (s *samplingSugarLogger) Fatalw(msg string, keysAndValues ...any) {
+	s.inner.Fatalw(msg, keysAndValues...)
+}
+
+func (s *samplingSugarLogger) With(args ...any) SugarLogger {
+	return &samplingSugarLogger{inner: s.inner.With(args...), sampler: s.sampler}
+}
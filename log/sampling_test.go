@@ -0,0 +1,105 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSamplingLogger(t *testing.T) {
+	inner, logs := newObservedLogger(LevelInfo)
+	l := NewSamplingLogger(inner, time.Minute, 10, 100)
+
+	for range 1000 {
+		l.Warn("connection pool exhausted")
+	}
+
+	// The first 10, then every 100th of the remaining 990
+	if got := logs.Len(); got != 19 {
+		t.Fatalf("expected 19 sampled entries, got %d", got)
+	}
+
+	// Different messages are sampled independently
+	l.Warn("disk almost full")
+
+	if got := logs.FilterMessage("disk almost full").Len(); got != 1 {
+		t.Fatalf("expected a different message to be logged, got %d", got)
+	}
+}
+
+func TestSamplingLogger_TickResetsCounts(t *testing.T) {
+	inner, logs := newObservedLogger(LevelInfo)
+	l := NewSamplingLogger(inner, 20*time.Millisecond, 1, 0)
+
+	l.Info("tick")
+	l.Info("tick")
+
+	if got := logs.Len(); got != 1 {
+		t.Fatalf("expected 1 entry in the first tick, got %d", got)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	l.Info("tick")
+
+	if got := logs.Len(); got != 2 {
+		t.Fatalf("expected the count to reset after a tick, got %d entries", got)
+	}
+}
+
+func TestSamplingLogger_WithSharesSampler(t *testing.T) {
+	inner, logs := newObservedLogger(LevelInfo)
+	l := NewSamplingLogger(inner, time.Minute, 2, 0)
+
+	child := l.With(String("request_id", "req-1"))
+
+	l.Error("failed")
+	child.Error("failed")
+	child.Error("failed")
+	child.Named("sub").Error("failed")
+
+	entries := logs.TakeAll()
+	if len(entries) != 2 {
+		t.Fatalf("expected derived loggers to share the sampler, got %d entries", len(entries))
+	}
+
+	if got := entries[1].ContextMap()["request_id"]; got != "req-1" {
+		t.Errorf("request_id = %v, want req-1", got)
+	}
+}
+
+func TestSamplingLogger_FormattedAndSugar(t *testing.T) {
+	inner, logs := newObservedLogger(LevelInfo)
+	l := NewSamplingLogger(inner, time.Minute, 1, 0)
+
+	for i := range 10 {
+		l.Infof("retry %d", i)
+		l.Sugar().Infow("sugared", "attempt", i)
+	}
+
+	if got := logs.Len(); got != 2 {
+		t.Fatalf("expected 1 formatted and 1 sugared entry, got %d", got)
+	}
+}
+
+func TestSamplingLogger_LevelSetter(t *testing.T) {
+	inner, logs := newObservedLogger(LevelInfo)
+	l := NewSamplingLogger(inner, time.Minute, 10, 100)
+	child := l.With(String("component", "api"))
+
+	ls, ok := child.(LevelSetter)
+	if !ok {
+		t.Fatal("expected the sampling logger to be a LevelSetter")
+	}
+
+	// Changing the level through a derived logger reaches the wrapped logger
+	ls.SetLevel(LevelDebug)
+
+	if got := l.(LevelSetter).Level(); got != LevelDebug {
+		t.Fatalf("expected level %q, got %q", LevelDebug, got)
+	}
+
+	l.Debug("cache miss")
+
+	if got := logs.FilterMessage("cache miss").Len(); got != 1 {
+		t.Fatalf("expected the debug entry to be logged, got %d", got)
+	}
+}
@@ -3,6 +3,7 @@ package log
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -10,6 +11,7 @@ import (
 // TestLogger provides a test logger implementation.
 type TestLogger struct {
 	logs []LogEntry
+	once *onceFilter
 	mu   sync.RWMutex
 }
 
@@ -24,6 +26,7 @@ type LogEntry struct {
 func NewTestLogger() Logger {
 	return &TestLogger{
 		logs: make([]LogEntry, 0),
+		once: newOnceFilter(0),
 	}
 }
 
@@ -130,6 +133,21 @@ func (tl *TestLogger) Fatalf(template string, args ...any) {
 	tl.addLog("FATAL", fmt.Sprintf(template, args...), nil)
 }
 
+// LogOnce logs a message at level the first time key is seen.
+func (tl *TestLogger) LogOnce(key string, level LogLevel, msg string, fields ...Field) {
+	tl.mu.Lock()
+	if tl.once == nil {
+		tl.once = newOnceFilter(0)
+	}
+
+	once := tl.once
+	tl.mu.Unlock()
+
+	if once.allow(key) {
+		tl.addLog(strings.ToUpper(zapLevel(level).String()), msg, fields)
+	}
+}
+
 func (tl *TestLogger) With(fields ...Field) Logger {
 	tl.mu.Lock()
 	defer tl.mu.Unlock()
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"strings"
+)
+
+// =============================================================================
+// LABEL AGGREGATION
+// =============================================================================
+
+// HistogramAggregate is the merge of every label variant of a histogram.
+// Buckets holds the per-boundary counts of all variants added together, keyed
+// like Histogram.Buckets.
+type HistogramAggregate struct {
+	Name    string             `json:"name"`
+	Series  int                `json:"series"`
+	Count   uint64             `json:"count"`
+	Sum     float64            `json:"sum"`
+	Buckets map[float64]uint64 `json:"buckets"`
+}
+
+// Aggregate returns the sum of the counter or gauge registered under the
+// fully qualified name and all of its label variants, e.g. the total of
+// every http_requests_total{method=...,status=...} series. Counters are
+// summed if both a counter and a gauge use the name.
+//
+// It returns ErrMetricNotFound if no counter or gauge uses the name, and
+// ErrAggregateUnsupported if only a histogram, summary or timer does.
+func (mc *metricsCollector) Aggregate(name string) (float64, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	var (
+		total float64
+		found bool
+	)
+
+	for key, counter := range mc.counters {
+		if inFamily(key, name) {
+			total += counter.Value()
+			found = true
+		}
+	}
+
+	if found {
+		return total, nil
+	}
+
+	for key, gauge := range mc.gauges {
+		if inFamily(key, name) {
+			total += gauge.Value()
+			found = true
+		}
+	}
+
+	if found {
+		return total, nil
+	}
+
+	if mc.familyExists(name) {
+		return 0, ErrAggregateUnsupported
+	}
+
+	return 0, ErrMetricNotFound
+}
+
+// AggregateHistogram merges the histogram registered under the fully
+// qualified name with all of its label variants. Bucket counts are added per
+// boundary, so variants with differing boundaries yield the union of them.
+//
+// It returns ErrMetricNotFound if no histogram uses the name, and
+// ErrAggregateUnsupported if only another metric type does.
+func (mc *metricsCollector) AggregateHistogram(name string) (HistogramAggregate, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	aggregate := HistogramAggregate{Name: name, Buckets: make(map[float64]uint64)}
+
+	for key, histogram := range mc.histograms {
+		if !inFamily(key, name) {
+			continue
+		}
+
+		aggregate.Series++
+		aggregate.Count += histogram.Count()
+		aggregate.Sum += histogram.Sum()
+
+		for boundary, count := range histogram.Buckets() {
+			aggregate.Buckets[boundary] += count
+		}
+	}
+
+	if aggregate.Series > 0 {
+		return aggregate, nil
+	}
+
+	if mc.familyExists(name) {
+		return HistogramAggregate{}, ErrAggregateUnsupported
+	}
+
+	return HistogramAggregate{}, ErrMetricNotFound
+}
+
+// familyExists reports whether any metric is registered under name or as a
+// label variant of it. Must be called with mc.mu held.
+func (mc *metricsCollector) familyExists(name string) bool {
+	return anyInFamily(mc.counters, name) || anyInFamily(mc.gauges, name) ||
+		anyInFamily(mc.histograms, name) || anyInFamily(mc.summaries, name) ||
+		anyInFamily(mc.timers, name)
+}
+
+func anyInFamily[M any](metrics map[string]M, name string) bool {
+	for key := range metrics {
+		if inFamily(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inFamily reports whether the registry key is the metric name itself or the
+// label fingerprint of one of its variants, name{labels}.
+func inFamily(key, name string) bool {
+	rest, ok := strings.CutPrefix(key, name)
+
+	return ok && (rest == "" || strings.HasPrefix(rest, "{"))
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollector_Aggregate(t *testing.T) {
+	collector := NewMetricsCollector("aggregate")
+
+	requests := collector.Counter("http_requests_total")
+	requests.WithLabels(map[string]string{"method": "GET"}).Add(5)
+	requests.WithLabels(map[string]string{"method": "POST"}).Add(3)
+	requests.WithLabels(map[string]string{"method": "DELETE"}).Add(2)
+
+	// Families sharing a name prefix are not included
+	collector.Counter("http_requests_total_errors").Add(100)
+
+	total, err := collector.Aggregate("http_requests_total")
+	require.NoError(t, err)
+	assert.InDelta(t, 10, total, 0)
+
+	inflight := collector.Gauge("inflight")
+	inflight.Set(1)
+	inflight.WithLabels(map[string]string{"route": "/a"}).Set(4)
+	inflight.WithLabels(map[string]string{"route": "/b"}).Set(-2)
+
+	total, err = collector.Aggregate("inflight")
+	require.NoError(t, err)
+	assert.InDelta(t, 3, total, 0)
+
+	collector.Histogram("latency").Observe(1)
+
+	_, err = collector.Aggregate("latency")
+	require.ErrorIs(t, err, ErrAggregateUnsupported)
+
+	_, err = collector.Aggregate("missing")
+	require.ErrorIs(t, err, ErrMetricNotFound)
+}
+
+func TestMetricsCollector_AggregateHistogram(t *testing.T) {
+	collector := NewMetricsCollector("aggregate")
+
+	latency := collector.Histogram("latency", WithBuckets(0.1, 1, 10))
+	latency.WithLabels(map[string]string{"route": "/a"}).Observe(0.05)
+	latency.WithLabels(map[string]string{"route": "/b"}).Observe(0.5)
+	latency.WithLabels(map[string]string{"route": "/b"}).Observe(5)
+	latency.WithLabels(map[string]string{"route": "/c"}).Observe(0.5)
+
+	aggregate, err := collector.AggregateHistogram("latency")
+	require.NoError(t, err)
+
+	assert.Equal(t, "latency", aggregate.Name)
+	assert.Equal(t, 4, aggregate.Series)
+	assert.Equal(t, uint64(4), aggregate.Count)
+	assert.InDelta(t, 6.05, aggregate.Sum, 1e-9)
+
+	var bucketTotal uint64
+	for _, count := range aggregate.Buckets {
+		bucketTotal += count
+	}
+
+	want := latency.Buckets()
+	for _, variant := range []string{"/a", "/b", "/c"} {
+		for boundary, count := range latency.WithLabels(map[string]string{"route": variant}).Buckets() {
+			want[boundary] += count
+		}
+	}
+
+	assert.Equal(t, want, aggregate.Buckets)
+	assert.Equal(t, aggregate.Count, bucketTotal)
+
+	collector.Counter("requests_total").Inc()
+
+	_, err = collector.AggregateHistogram("requests_total")
+	require.ErrorIs(t, err, ErrAggregateUnsupported)
+
+	_, err = collector.AggregateHistogram("missing")
+	require.ErrorIs(t, err, ErrMetricNotFound)
+}
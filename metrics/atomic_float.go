@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// AtomicFloat64 is a float64 that can be updated atomically, for custom
+// metric implementations. It stores the IEEE 754 bits of the value in an
+// atomic.Uint64 and updates it with a compare-and-swap loop, the way the
+// built-in counters, gauges and histograms do. The zero value is 0.
+//
+// An AtomicFloat64 must not be copied after first use.
+type AtomicFloat64 struct {
+	bits atomic.Uint64
+}
+
+// Load returns the current value.
+func (f *AtomicFloat64) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+// Set stores value.
+func (f *AtomicFloat64) Set(value float64) {
+	f.bits.Store(math.Float64bits(value))
+}
+
+// Add adds delta and returns the new value. Overflowing the float64 range
+// yields ±Inf, and adding NaN yields NaN, as with the + operator.
+func (f *AtomicFloat64) Add(delta float64) float64 {
+	for {
+		oldBits := f.bits.Load()
+		newVal := math.Float64frombits(oldBits) + delta
+
+		if f.bits.CompareAndSwap(oldBits, math.Float64bits(newVal)) {
+			return newVal
+		}
+	}
+}
+
+// CompareAndSwap stores newVal if the current value is old and reports
+// whether it did. Values are compared by their bits rather than with ==, so
+// a NaN matches the same NaN, and 0 and -0 do not match each other.
+func (f *AtomicFloat64) CompareAndSwap(old, newVal float64) bool {
+	return f.bits.CompareAndSwap(math.Float64bits(old), math.Float64bits(newVal))
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicFloat64_ZeroValue(t *testing.T) {
+	var f AtomicFloat64
+
+	assert.Zero(t, f.Load())
+	assert.False(t, math.Signbit(f.Load()))
+}
+
+func TestAtomicFloat64_ConcurrentAdd(t *testing.T) {
+	var (
+		f  AtomicFloat64
+		wg sync.WaitGroup
+	)
+
+	for range 50 {
+		wg.Go(func() {
+			for range 1000 {
+				f.Add(0.5)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	// Halves are exact in binary, so no rounding error accumulates
+	assert.Equal(t, 25000.0, f.Load())
+}
+
+func TestAtomicFloat64_ConcurrentSet(t *testing.T) {
+	var (
+		f  AtomicFloat64
+		wg sync.WaitGroup
+	)
+
+	values := []float64{1.5, -2.25, math.MaxFloat64, math.SmallestNonzeroFloat64}
+
+	for _, v := range values {
+		wg.Go(func() {
+			for range 1000 {
+				f.Set(v)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	// Writes are never torn: the result is one of the values set
+	assert.Contains(t, values, f.Load())
+}
+
+func TestAtomicFloat64_Add(t *testing.T) {
+	var f AtomicFloat64
+
+	assert.Equal(t, 1.5, f.Add(1.5))
+	assert.Equal(t, -1.0, f.Add(-2.5))
+
+	f.Set(math.MaxFloat64)
+	assert.True(t, math.IsInf(f.Add(math.MaxFloat64), 1))
+
+	f.Set(-math.MaxFloat64)
+	assert.True(t, math.IsInf(f.Add(-math.MaxFloat64), -1))
+
+	f.Set(1)
+	assert.True(t, math.IsNaN(f.Add(math.NaN())))
+	assert.True(t, math.IsNaN(f.Add(1)), "NaN is sticky")
+}
+
+func TestAtomicFloat64_CompareAndSwap(t *testing.T) {
+	t.Run("matching value", func(t *testing.T) {
+		var f AtomicFloat64
+
+		f.Set(1.5)
+		assert.True(t, f.CompareAndSwap(1.5, 2))
+		assert.Equal(t, 2.0, f.Load())
+	})
+
+	t.Run("stale value", func(t *testing.T) {
+		var f AtomicFloat64
+
+		f.Set(1.5)
+		assert.False(t, f.CompareAndSwap(1, 2))
+		assert.Equal(t, 1.5, f.Load())
+	})
+
+	t.Run("signed zeros differ", func(t *testing.T) {
+		var f AtomicFloat64
+
+		negativeZero := math.Copysign(0, -1)
+
+		assert.False(t, f.CompareAndSwap(negativeZero, 1))
+		assert.True(t, f.CompareAndSwap(0, negativeZero))
+		assert.True(t, math.Signbit(f.Load()))
+		assert.False(t, f.CompareAndSwap(0, 1))
+	})
+
+	t.Run("NaN matches itself", func(t *testing.T) {
+		var f AtomicFloat64
+
+		nan := math.NaN()
+		f.Set(nan)
+
+		assert.True(t, f.CompareAndSwap(nan, 1))
+		assert.Equal(t, 1.0, f.Load())
+	})
+
+	t.Run("infinities", func(t *testing.T) {
+		var f AtomicFloat64
+
+		f.Set(math.Inf(1))
+		assert.False(t, f.CompareAndSwap(math.Inf(-1), 0))
+		assert.True(t, f.CompareAndSwap(math.Inf(1), math.Inf(-1)))
+		assert.True(t, math.IsInf(f.Load(), -1))
+	})
+}
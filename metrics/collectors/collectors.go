@@ -2,6 +2,10 @@ package collectors
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,6 +20,12 @@ import (
 
 // CustomMetricSource defines the interface for any datasource that can be collected.
 // Implementations should return current metric values in a MetricSnapshot.
+//
+// A source that computes several metric groups may return a partial snapshot
+// together with a non-nil error when some groups fail: a non-nil snapshot with
+// a non-nil error means "use what's here". The builder applies the partial
+// snapshot and still counts and logs the collection as failed. Return a nil
+// snapshot to discard the collection entirely.
 type CustomMetricSource interface {
 	// Name returns the collector name
 	Name() string
@@ -39,6 +49,11 @@ type MetricSnapshot struct {
 	// Gauges: absolute values that can increase or decrease
 	Gauges map[string]float64
 
+	// LabeledGauges: gauge families whose values are distinguished by labels.
+	// Every value of a family should use the same label keys. A family present
+	// in a snapshot is replaced: label sets missing from it are removed.
+	LabeledGauges map[string][]LabeledValue
+
 	// Histograms: observations to be recorded
 	// Each collection can contain multiple observations per metric
 	Histograms map[string][]float64
@@ -51,6 +66,11 @@ type MetricSnapshot struct {
 	// Each collection can contain multiple observations per metric
 	Timers map[string][]time.Duration
 
+	// Exemplars: optional trace context keyed by metric name
+	// Attached to the counter increment, or to the last histogram or timer
+	// observation, recorded for the same key in this snapshot
+	Exemplars map[string]metrics.Exemplar
+
 	// Labels: optional labels to apply to all metrics in this snapshot
 	Labels map[string]string
 
@@ -58,6 +78,12 @@ type MetricSnapshot struct {
 	Timestamp time.Time
 }
 
+// LabeledValue is a single value of a labeled metric family.
+type LabeledValue struct {
+	Labels map[string]string
+	Value  float64
+}
+
 // Validate checks if the snapshot is valid.
 func (s *MetricSnapshot) Validate() error {
 	if s == nil {
@@ -67,6 +93,97 @@ func (s *MetricSnapshot) Validate() error {
 	return nil
 }
 
+// Merge combines other into s so that snapshots built from several
+// sub-collectors can be pushed or returned as one:
+//
+//   - Counters with the same key are summed.
+//   - Gauges and Exemplars are last-write-wins: values from other replace
+//     those in s.
+//   - LabeledGauges, Histograms, Summaries and Timers are appended.
+//   - Labels are unioned. When both snapshots set the same label to different
+//     values, the value in s is kept and ErrLabelConflict is returned after
+//     the rest of other has been merged.
+//   - Timestamp becomes the later of the two.
+//
+// A nil other is a no-op. Merge does not modify other.
+func (s *MetricSnapshot) Merge(other *MetricSnapshot) error {
+	if s == nil {
+		return ErrNilSnapshot
+	}
+
+	if other == nil {
+		return nil
+	}
+
+	if s.Counters == nil && len(other.Counters) > 0 {
+		s.Counters = make(map[string]float64, len(other.Counters))
+	}
+
+	for name, value := range other.Counters {
+		s.Counters[name] += value
+	}
+
+	if len(other.Gauges) > 0 {
+		if s.Gauges == nil {
+			s.Gauges = make(map[string]float64, len(other.Gauges))
+		}
+
+		maps.Copy(s.Gauges, other.Gauges)
+	}
+
+	if len(other.Exemplars) > 0 {
+		if s.Exemplars == nil {
+			s.Exemplars = make(map[string]metrics.Exemplar, len(other.Exemplars))
+		}
+
+		maps.Copy(s.Exemplars, other.Exemplars)
+	}
+
+	s.LabeledGauges = mergeSlices(s.LabeledGauges, other.LabeledGauges)
+	s.Histograms = mergeSlices(s.Histograms, other.Histograms)
+	s.Summaries = mergeSlices(s.Summaries, other.Summaries)
+	s.Timers = mergeSlices(s.Timers, other.Timers)
+
+	if other.Timestamp.After(s.Timestamp) {
+		s.Timestamp = other.Timestamp
+	}
+
+	if s.Labels == nil && len(other.Labels) > 0 {
+		s.Labels = make(map[string]string, len(other.Labels))
+	}
+
+	var err error
+
+	for key, value := range other.Labels {
+		if existing, ok := s.Labels[key]; ok && existing != value {
+			err = ErrLabelConflict
+			continue
+		}
+
+		s.Labels[key] = value
+	}
+
+	return err
+}
+
+// mergeSlices appends the slices of src to those of dst with the same key.
+// Slices are copied so dst never aliases src.
+func mergeSlices[T any](dst, src map[string][]T) map[string][]T {
+	if len(src) == 0 {
+		return dst
+	}
+
+	if dst == nil {
+		dst = make(map[string][]T, len(src))
+	}
+
+	for name, values := range src {
+		dst[name] = append(slices.Clip(dst[name]), values...)
+	}
+
+	return dst
+}
+
 // =============================================================================
 // CUSTOM COLLECTOR BUILDER (Pull-based)
 // =============================================================================
@@ -74,19 +191,24 @@ func (s *MetricSnapshot) Validate() error {
 // CustomCollectorBuilder automatically collects metrics from any datasource
 // implementing CustomMetricSource. Supports periodic polling (pull model).
 type CustomCollectorBuilder struct {
-	source   CustomMetricSource
-	interval time.Duration
-	metrics  metrics.Metrics
-	options  []metrics.MetricOption
+	source         CustomMetricSource
+	interval       time.Duration
+	collectTimeout time.Duration
+	counterWrap    float64 // Value cumulative counters wrap at, zero if they reset instead
+	metrics        metrics.Metrics
+	options        []metrics.MetricOption
 
 	// Context is stored for goroutine lifecycle management (legitimate use case)
-	ctx    context.Context //nolint:containedctx // Required for collection loop cancellation
-	cancel context.CancelFunc
-	wg     sync.WaitGroup // Tracks collection goroutine
+	ctx         context.Context //nolint:containedctx // Required for collection loop cancellation
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup // Tracks collection goroutine
+	lifecycleMu sync.Mutex     // Serializes Start and Stop
 
 	// Internal metric registry
 	counters      map[string]metrics.Counter
 	gauges        map[string]metrics.Gauge
+	gaugeVecs     map[string]*metrics.GaugeVec
+	gaugeVecSets  map[string]map[string]map[string]string // Label sets last set per gauge family
 	histograms    map[string]metrics.Histogram
 	summaries     map[string]metrics.Summary
 	timers        map[string]metrics.Timer
@@ -94,12 +216,56 @@ type CustomCollectorBuilder struct {
 
 	logger log.Logger
 
+	// Collection statistics
+	stats   BuilderStats
+	recent  [ErrorRateWindow]bool // Outcomes of the last collections, true for failures
+	statsMu sync.RWMutex
+
 	mu      sync.RWMutex
 	started atomic.Bool
 }
 
+var _ metrics.ClosableCollector = (*CustomCollectorBuilder)(nil)
+
+// BuilderStats reports the collection activity of a collector builder.
+type BuilderStats struct {
+	Name               string        `json:"name"`
+	Started            bool          `json:"started"`
+	StartTime          time.Time     `json:"start_time"`
+	Interval           time.Duration `json:"interval"`
+	CollectionCount    int64         `json:"collection_count"`
+	ErrorCount         int64         `json:"error_count"`
+	ConsecutiveErrors  int64         `json:"consecutive_errors"`
+	LastCollectionTime time.Time     `json:"last_collection_time"`
+	LastSuccessTime    time.Time     `json:"last_success_time"`
+	LastError          string        `json:"last_error,omitempty"`
+	LastErrorTime      time.Time     `json:"last_error_time"`
+	RecentCollections  int64         `json:"recent_collections"`
+	RecentErrors       int64         `json:"recent_errors"`
+	PartialCollections int64         `json:"partial_collections"`
+	PushCount          int64         `json:"push_count"`
+	DroppedPushes      int64         `json:"dropped_pushes"`
+	CancelledPushes    int64         `json:"cancelled_pushes"`
+}
+
+// ErrorRateWindow is the number of most recent collections ErrorRate covers,
+// reported as RecentCollections and RecentErrors in BuilderStats.
+const ErrorRateWindow = 100
+
+// ErrorRate returns the fraction of the last ErrorRateWindow collections that
+// failed, so a builder that recovers is not judged by old failures. Use
+// ErrorCount and CollectionCount for lifetime totals.
+func (s BuilderStats) ErrorRate() float64 {
+	if s.RecentCollections == 0 {
+		return 0
+	}
+
+	return float64(s.RecentErrors) / float64(s.RecentCollections)
+}
+
 // NewCustomCollectorBuilder creates a new collector builder for the given datasource.
 func NewCustomCollectorBuilder(source CustomMetricSource, opts ...metrics.MetricOption) *CustomCollectorBuilder {
+	// Replaced on every start; set here so the builder is usable before then
 	ctx, cancel := context.WithCancel(context.Background())
 
 	options := &metrics.MetricOptions{}
@@ -121,6 +287,8 @@ func NewCustomCollectorBuilder(source CustomMetricSource, opts ...metrics.Metric
 		cancel:        cancel,
 		counters:      make(map[string]metrics.Counter),
 		gauges:        make(map[string]metrics.Gauge),
+		gaugeVecs:     make(map[string]*metrics.GaugeVec),
+		gaugeVecSets:  make(map[string]map[string]map[string]string),
 		histograms:    make(map[string]metrics.Histogram),
 		summaries:     make(map[string]metrics.Summary),
 		timers:        make(map[string]metrics.Timer),
@@ -136,6 +304,58 @@ func (b *CustomCollectorBuilder) WithInterval(d time.Duration) *CustomCollectorB
 	return b
 }
 
+// WithCollectTimeout bounds each call to the source's Collect. A collection
+// that has not returned after d is counted as failed with ErrCollectTimeout
+// and the builder moves on; the source is expected to honor its context, and
+// one that does not is left to finish in the background. Zero or negative
+// disables the timeout, which is the default.
+func (b *CustomCollectorBuilder) WithCollectTimeout(d time.Duration) *CustomCollectorBuilder {
+	b.collectTimeout = d
+
+	return b
+}
+
+// counterWrapWindow is the fraction of the wrap range, below the maximum, in
+// which a previous counter value must lie for a decrease to count as a wrap.
+const counterWrapWindow = 0.1
+
+// WithCounterWrap declares that the source's cumulative counters wrap around
+// at maxValue, e.g. math.MaxUint64 for uint64 counters, instead of only
+// resetting to zero.
+//
+// A counter that decreases is treated as wrapped when its previous value was
+// within the top 10% of the range, and the delta added is
+// (maxValue - old) + new. Otherwise it is treated as reset, as without this
+// option, and the new value is added as the delta. A counter that wrapped
+// after climbing from a low value within a single interval is therefore
+// taken for a reset. Non-positive or non-finite values disable wrap
+// detection, which is the default.
+func (b *CustomCollectorBuilder) WithCounterWrap(maxValue float64) *CustomCollectorBuilder {
+	if maxValue <= 0 || math.IsInf(maxValue, 0) || math.IsNaN(maxValue) {
+		maxValue = 0
+	}
+
+	b.counterWrap = maxValue
+
+	return b
+}
+
+// counterDelta returns the increase of a cumulative counter from oldValue to
+// value, accounting for resets and, with WithCounterWrap, wraps.
+func (b *CustomCollectorBuilder) counterDelta(oldValue, value float64) float64 {
+	if value >= oldValue {
+		return value - oldValue
+	}
+
+	wrap := b.counterWrap
+	if wrap > 0 && oldValue <= wrap && value <= wrap && oldValue >= wrap*(1-counterWrapWindow) {
+		return (wrap - oldValue) + value
+	}
+
+	// Counter reset detected - treat current value as delta
+	return value
+}
+
 // WithOptions adds metric options that will be applied to all created metrics.
 func (b *CustomCollectorBuilder) WithOptions(opts ...metrics.MetricOption) *CustomCollectorBuilder {
 	b.options = append(b.options, opts...)
@@ -143,31 +363,119 @@ func (b *CustomCollectorBuilder) WithOptions(opts ...metrics.MetricOption) *Cust
 	return b
 }
 
+// Name returns the name of the underlying datasource.
+func (b *CustomCollectorBuilder) Name() string {
+	return b.source.Name()
+}
+
+// Stats returns a snapshot of the builder's collection statistics.
+func (b *CustomCollectorBuilder) Stats() BuilderStats {
+	b.statsMu.RLock()
+	stats := b.stats
+	b.statsMu.RUnlock()
+
+	stats.Name = b.source.Name()
+	stats.Started = b.started.Load()
+	stats.Interval = b.interval
+
+	return stats
+}
+
 // Start begins automatic metric collection in a background goroutine.
+// Equivalent to StartWithContext with a background context.
 func (b *CustomCollectorBuilder) Start() error {
-	if b.started.Swap(true) {
+	return b.StartWithContext(context.Background())
+}
+
+// StartWithContext begins automatic metric collection in a background
+// goroutine that runs until ctx is cancelled or Stop is called. The context
+// is also passed to the source's Collect. A builder whose context was
+// cancelled still reports itself as started until Stop is called, and can be
+// started again after that.
+func (b *CustomCollectorBuilder) StartWithContext(ctx context.Context) error {
+	return b.start(ctx, b.collectLoop)
+}
+
+// start launches loop under a context derived from ctx.
+func (b *CustomCollectorBuilder) start(ctx context.Context, loop func()) error {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	if b.started.Load() {
 		return ErrAlreadyStarted
 	}
 
+	b.ctx, b.cancel = context.WithCancel(ctx)
+	b.started.Store(true)
+
+	b.markStarted()
 	b.wg.Add(1)
 
-	go b.collectLoop()
+	go loop()
 
 	return nil
 }
 
-// Stop halts metric collection and waits for the collection goroutine to exit.
+// Stop halts metric collection and blocks until the collection goroutine has
+// exited, so no collection is in flight once it returns.
 func (b *CustomCollectorBuilder) Stop() error {
-	if !b.started.Swap(false) {
+	b.lifecycleMu.Lock()
+	defer b.lifecycleMu.Unlock()
+
+	if !b.started.Load() {
 		return ErrNotStarted
 	}
 
+	b.started.Store(false)
 	b.cancel()
 	b.wg.Wait() // Wait for collection goroutine to exit
 
 	return nil
 }
 
+// Close stops the collection loop if it is running, implementing
+// metrics.ClosableCollector so closing a Metrics the builder is registered
+// with stops it. Unlike Stop it does not fail when the builder is not
+// started. If ctx ends before the loop has exited, its error is returned and
+// the loop finishes stopping in the background.
+func (b *CustomCollectorBuilder) Close(ctx context.Context) error {
+	done := make(chan error, 1)
+
+	go func() {
+		err := b.Stop()
+		if errors.Is(err, ErrNotStarted) {
+			err = nil
+		}
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Collect returns the current values of the builder's metrics keyed by name,
+// so the builder can be registered with a Metrics as a custom collector.
+func (b *CustomCollectorBuilder) Collect() map[string]any {
+	snapshot := b.metrics.Snapshot()
+
+	values := make(map[string]any, len(snapshot))
+	for key, entry := range snapshot {
+		values[key] = entry
+	}
+
+	return values
+}
+
+// Reset resets the builder's metrics.
+func (b *CustomCollectorBuilder) Reset() error {
+	return b.metrics.Reset()
+}
+
 // Metrics returns the underlying metrics collector for direct access.
 func (b *CustomCollectorBuilder) Metrics() metrics.Metrics {
 	return b.metrics
@@ -176,18 +484,72 @@ func (b *CustomCollectorBuilder) Metrics() metrics.Metrics {
 // CollectOnce performs a single collection without starting the automatic loop.
 // Useful for testing or on-demand collection.
 func (b *CustomCollectorBuilder) CollectOnce(ctx context.Context) error {
-	snapshot, err := b.source.Collect(ctx)
-	if err != nil {
+	snapshot, err := b.collectSnapshot(ctx)
+	if snapshot == nil {
 		return err
 	}
 
-	if err := snapshot.Validate(); err != nil {
+	updateErr := b.updateFromSnapshot(ctx, snapshot)
+	if err != nil {
 		return err
 	}
 
-	b.updateFromSnapshot(snapshot)
+	return updateErr
+}
+
+// collectSnapshot calls the source and records the outcome. The returned
+// snapshot is the one to apply and may be partial when err is non-nil.
+func (b *CustomCollectorBuilder) collectSnapshot(ctx context.Context) (*MetricSnapshot, error) {
+	snapshot, err := b.collectSource(ctx)
+	if err == nil {
+		err = snapshot.Validate()
+	}
+
+	b.recordCollection(err)
 
-	return nil
+	if err != nil && snapshot != nil {
+		b.recordPartialCollection()
+	}
+
+	return snapshot, err
+}
+
+// collectSource calls the source's Collect, bounded by the collect timeout.
+func (b *CustomCollectorBuilder) collectSource(ctx context.Context) (*MetricSnapshot, error) {
+	if b.collectTimeout <= 0 {
+		return b.source.Collect(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.collectTimeout)
+	defer cancel()
+
+	type result struct {
+		snapshot *MetricSnapshot
+		err      error
+	}
+
+	// Buffered so a source that ignores its context can still return
+	done := make(chan result, 1)
+
+	go func() {
+		snapshot, err := b.source.Collect(ctx)
+		done <- result{snapshot: snapshot, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && errors.Is(r.err, context.DeadlineExceeded) && ctx.Err() != nil {
+			return r.snapshot, ErrCollectTimeout
+		}
+
+		return r.snapshot, r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrCollectTimeout
+		}
+
+		return nil, ctx.Err()
+	}
 }
 
 // collectLoop periodically collects metrics from the source.
@@ -212,40 +574,117 @@ func (b *CustomCollectorBuilder) collectLoop() {
 
 // collect fetches metrics from the source and updates all metrics.
 func (b *CustomCollectorBuilder) collect() {
-	snapshot, err := b.source.Collect(b.ctx)
+	snapshot, err := b.collectSnapshot(b.ctx)
 	if err != nil {
 		// Log error but don't stop collecting
-		b.logger.Error("failed to collect metrics", log.Error(err))
+		b.logger.Error("failed to collect metrics",
+			log.Error(err),
+			log.Bool("partial", snapshot != nil))
+	}
 
+	if snapshot == nil {
 		return
 	}
 
-	if err := snapshot.Validate(); err != nil {
+	if err := b.updateFromSnapshot(b.ctx, snapshot); err != nil {
+		b.logger.Debug("metric collection interrupted", log.Error(err))
+	}
+}
+
+// markStarted records the start time of the collection loop.
+func (b *CustomCollectorBuilder) markStarted() {
+	b.statsMu.Lock()
+	b.stats.StartTime = time.Now()
+	b.statsMu.Unlock()
+}
+
+// recordCollection updates the collection statistics with the outcome of a collection.
+func (b *CustomCollectorBuilder) recordCollection(err error) {
+	now := time.Now()
+
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+
+	// Replace the outcome that leaves the window with this one
+	slot := &b.recent[b.stats.CollectionCount%ErrorRateWindow]
+	if b.stats.CollectionCount >= ErrorRateWindow && *slot {
+		b.stats.RecentErrors--
+	}
+
+	*slot = err != nil
+
+	b.stats.CollectionCount++
+	b.stats.RecentCollections = min(b.stats.CollectionCount, ErrorRateWindow)
+	b.stats.LastCollectionTime = now
+
+	if err != nil {
+		b.stats.RecentErrors++
+		b.stats.ErrorCount++
+		b.stats.ConsecutiveErrors++
+		b.stats.LastError = err.Error()
+		b.stats.LastErrorTime = now
+
 		return
 	}
 
-	b.updateFromSnapshot(snapshot)
+	b.stats.ConsecutiveErrors = 0
+	b.stats.LastSuccessTime = now
+}
+
+// recordPartialCollection counts a failed collection whose partial snapshot was applied.
+func (b *CustomCollectorBuilder) recordPartialCollection() {
+	b.statsMu.Lock()
+	b.stats.PartialCollections++
+	b.statsMu.Unlock()
+}
+
+// recordPush updates the push statistics.
+func (b *CustomCollectorBuilder) recordPush(dropped bool) {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+
+	if dropped {
+		b.stats.DroppedPushes++
+
+		return
+	}
+
+	b.stats.PushCount++
+}
+
+// recordCancelledPush counts a pushed snapshot whose context ended before it was fully applied.
+func (b *CustomCollectorBuilder) recordCancelledPush() {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+
+	b.stats.CancelledPushes++
 }
 
 // updateFromSnapshot applies the snapshot values to metrics.
-func (b *CustomCollectorBuilder) updateFromSnapshot(snapshot *MetricSnapshot) {
+// The context is checked before each metric is applied; when it is done the
+// remaining metrics are skipped and the context error is returned. Metrics
+// applied before cancellation are kept.
+func (b *CustomCollectorBuilder) updateFromSnapshot(ctx context.Context, snapshot *MetricSnapshot) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	// Update counters (track deltas)
 	for name, value := range snapshot.Counters {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		counter := b.getOrCreateCounterLocked(name)
 
 		// Get previous value and calculate delta
-		oldValue := b.counterValues[name]
-		if value >= oldValue {
-			delta := value - oldValue
-			if delta > 0 {
+		delta := b.counterDelta(b.counterValues[name], value)
+
+		if delta > 0 {
+			if exemplar, ok := snapshot.Exemplars[name]; ok {
+				counter.AddWithExemplar(delta, exemplar)
+			} else {
 				counter.Add(delta)
 			}
-		} else {
-			// Counter reset detected - treat current value as delta
-			counter.Add(value)
 		}
 
 		// Update tracked value
@@ -254,20 +693,62 @@ func (b *CustomCollectorBuilder) updateFromSnapshot(snapshot *MetricSnapshot) {
 
 	// Update gauges (absolute values)
 	for name, value := range snapshot.Gauges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		gauge := b.getOrCreateGaugeLocked(name)
 		gauge.Set(value)
 	}
 
+	// Update labeled gauges (absolute values per label set)
+	for name, values := range snapshot.LabeledGauges {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		sets := make(map[string]map[string]string, len(values))
+
+		for _, v := range values {
+			vec := b.getOrCreateGaugeVecLocked(name, v.Labels)
+			vec.With(v.Labels).Set(v.Value)
+			sets[metrics.TagsToString(v.Labels)] = v.Labels
+		}
+
+		// Drop the label sets the source no longer reports
+		for key, labels := range b.gaugeVecSets[name] {
+			if _, ok := sets[key]; !ok {
+				b.gaugeVecs[name].Delete(labels)
+			}
+		}
+
+		b.gaugeVecSets[name] = sets
+	}
+
 	// Update histograms (observe all values)
 	for name, values := range snapshot.Histograms {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		histogram := b.getOrCreateHistogramLocked(name)
-		for _, v := range values {
-			histogram.Observe(v)
+		exemplar, hasExemplar := snapshot.Exemplars[name]
+
+		for i, v := range values {
+			if hasExemplar && i == len(values)-1 {
+				histogram.ObserveWithExemplar(v, exemplar)
+			} else {
+				histogram.Observe(v)
+			}
 		}
 	}
 
 	// Update summaries (observe all values)
 	for name, values := range snapshot.Summaries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		summary := b.getOrCreateSummaryLocked(name)
 		for _, v := range values {
 			summary.Observe(v)
@@ -276,11 +757,23 @@ func (b *CustomCollectorBuilder) updateFromSnapshot(snapshot *MetricSnapshot) {
 
 	// Update timers (record all durations)
 	for name, durations := range snapshot.Timers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		timer := b.getOrCreateTimerLocked(name)
-		for _, d := range durations {
-			timer.Record(d)
+		exemplar, hasExemplar := snapshot.Exemplars[name]
+
+		for i, d := range durations {
+			if hasExemplar && i == len(durations)-1 {
+				timer.RecordWithExemplar(d, exemplar)
+			} else {
+				timer.Record(d)
+			}
 		}
 	}
+
+	return nil
 }
 
 // getOrCreateCounterLocked gets or creates a counter. Must be called with lock held.
@@ -307,6 +800,24 @@ func (b *CustomCollectorBuilder) getOrCreateGaugeLocked(name string) metrics.Gau
 	return gauge
 }
 
+// getOrCreateGaugeVecLocked gets or creates a gauge family whose label names
+// are taken from the first labels seen. The family is registered with the
+// underlying collector so it is included in exports. Must be called with lock held.
+func (b *CustomCollectorBuilder) getOrCreateGaugeVecLocked(name string, labels map[string]string) *metrics.GaugeVec {
+	if vec, exists := b.gaugeVecs[name]; exists {
+		return vec
+	}
+
+	vec := metrics.NewGaugeVec(name, slices.Sorted(maps.Keys(labels)), b.options...)
+	if err := b.metrics.RegisterCollector(vec); err != nil {
+		b.logger.Warn("failed to register labeled gauge", log.String("name", name), log.Error(err))
+	}
+
+	b.gaugeVecs[name] = vec
+
+	return vec
+}
+
 // getOrCreateHistogramLocked gets or creates a histogram. Must be called with lock held.
 func (b *CustomCollectorBuilder) getOrCreateHistogramLocked(name string) metrics.Histogram {
 	if histogram, exists := b.histograms[name]; exists {
@@ -352,15 +863,25 @@ func (b *CustomCollectorBuilder) getOrCreateTimerLocked(name string) metrics.Tim
 type PushableCollectorBuilder struct {
 	*CustomCollectorBuilder
 
-	pushChan   chan *MetricSnapshot
-	bufferSize int
+	pushChan     chan pushedSnapshot
+	bufferSize   int
+	flushTimeout time.Duration // Zero unless WithFlushOnStop is set
+}
+
+// pushedSnapshot is a snapshot queued by PushContext with the context that
+// bounds its application. A Flush marker carries no snapshot; flushed is
+// closed once every snapshot queued before it has been applied.
+type pushedSnapshot struct {
+	ctx      context.Context //nolint:containedctx // Carries the pusher's deadline to the apply
+	snapshot *MetricSnapshot
+	flushed  chan struct{}
 }
 
 // NewPushableCollectorBuilder creates a builder that supports both pull and push.
 func NewPushableCollectorBuilder(source CustomMetricSource, opts ...metrics.MetricOption) *PushableCollectorBuilder {
 	return &PushableCollectorBuilder{
 		CustomCollectorBuilder: NewCustomCollectorBuilder(source, opts...),
-		pushChan:               make(chan *MetricSnapshot, 100), // default buffer size
+		pushChan:               make(chan pushedSnapshot, 100), // default buffer size
 		bufferSize:             100,
 	}
 }
@@ -379,18 +900,52 @@ func (b *PushableCollectorBuilder) WithOptions(opts ...metrics.MetricOption) *Pu
 	return b
 }
 
+// WithCollectTimeout bounds each call to the source's Collect (overrides embedded method).
+func (b *PushableCollectorBuilder) WithCollectTimeout(d time.Duration) *PushableCollectorBuilder {
+	b.CustomCollectorBuilder.WithCollectTimeout(d)
+
+	return b
+}
+
+// WithCounterWrap declares the value counters wrap at (overrides embedded method).
+func (b *PushableCollectorBuilder) WithCounterWrap(maxValue float64) *PushableCollectorBuilder {
+	b.CustomCollectorBuilder.WithCounterWrap(maxValue)
+
+	return b
+}
+
 // WithBufferSize sets the push channel buffer size.
 func (b *PushableCollectorBuilder) WithBufferSize(size int) *PushableCollectorBuilder {
 	b.bufferSize = size
 	// Recreate channel with new size
-	b.pushChan = make(chan *MetricSnapshot, size)
+	b.pushChan = make(chan pushedSnapshot, size)
+
+	return b
+}
+
+// WithFlushOnStop makes Stop and Close flush the push buffer before stopping,
+// waiting at most timeout, so snapshots pushed during shutdown are recorded
+// rather than lost. Snapshots still buffered after the timeout are dropped
+// and a warning is logged. A non-positive timeout disables flushing, which
+// is the default.
+func (b *PushableCollectorBuilder) WithFlushOnStop(timeout time.Duration) *PushableCollectorBuilder {
+	b.flushTimeout = max(timeout, 0)
 
 	return b
 }
 
 // Push sends metrics for immediate collection (non-blocking).
 // If the buffer is full, the push is dropped to prevent blocking.
+// Equivalent to PushContext with a background context.
 func (b *PushableCollectorBuilder) Push(snapshot *MetricSnapshot) error {
+	return b.PushContext(context.Background(), snapshot)
+}
+
+// PushContext sends metrics for immediate collection (non-blocking), bounded
+// by ctx. If ctx is done before the snapshot is applied, the snapshot is
+// discarded; if it is done while applying, the remaining metrics are skipped.
+// Use it to keep a push carrying a request deadline from outliving the request.
+func (b *PushableCollectorBuilder) PushContext(ctx context.Context, snapshot *MetricSnapshot) error {
 	if !b.started.Load() {
 		return ErrNotStarted
 	}
@@ -399,27 +954,96 @@ func (b *PushableCollectorBuilder) Push(snapshot *MetricSnapshot) error {
 		return err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	select {
-	case b.pushChan <- snapshot:
+	case b.pushChan <- pushedSnapshot{ctx: ctx, snapshot: snapshot}:
+		b.recordPush(false)
+
 		return nil
 	default:
 		// Buffer full - drop the snapshot
-		// TODO: Add metric for dropped pushes
+		b.recordPush(true)
+
 		return ErrPushBufferFull
 	}
 }
 
-// Start begins both periodic polling and push-based collection.
-func (b *PushableCollectorBuilder) Start() error {
-	if b.started.Swap(true) {
-		return ErrAlreadyStarted
+// Flush blocks until every snapshot pushed before the call has been applied,
+// or ctx is done. It returns ErrNotStarted if the builder is not running and
+// ErrStopped if it is stopped before the buffer is drained. While Flush
+// waits for room in a full buffer, its marker takes a slot that Push may
+// then find occupied.
+func (b *PushableCollectorBuilder) Flush(ctx context.Context) error {
+	if !b.started.Load() {
+		return ErrNotStarted
 	}
 
-	b.wg.Add(1)
+	flushed := make(chan struct{})
 
-	go b.collectLoopWithPush()
+	select {
+	case b.pushChan <- pushedSnapshot{flushed: flushed}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.ctx.Done():
+		return ErrStopped
+	}
 
-	return nil
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.ctx.Done():
+		return ErrStopped
+	}
+}
+
+// Stop halts metric collection like CustomCollectorBuilder.Stop, first
+// flushing the push buffer if WithFlushOnStop is set.
+func (b *PushableCollectorBuilder) Stop() error {
+	b.flushBeforeStop(context.Background())
+
+	return b.CustomCollectorBuilder.Stop()
+}
+
+// Close stops the builder like CustomCollectorBuilder.Close, first flushing
+// the push buffer if WithFlushOnStop is set. The flush is bounded by ctx as
+// well as by the flush timeout.
+func (b *PushableCollectorBuilder) Close(ctx context.Context) error {
+	b.flushBeforeStop(ctx)
+
+	return b.CustomCollectorBuilder.Close(ctx)
+}
+
+// flushBeforeStop flushes the push buffer if WithFlushOnStop is set, logging
+// snapshots left unapplied.
+func (b *PushableCollectorBuilder) flushBeforeStop(ctx context.Context) {
+	if b.flushTimeout <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.flushTimeout)
+	defer cancel()
+
+	if err := b.Flush(ctx); err != nil && !errors.Is(err, ErrNotStarted) {
+		b.logger.Warn("push buffer not flushed before stop",
+			log.Int("pending", len(b.pushChan)), log.Error(err))
+	}
+}
+
+// Start begins both periodic polling and push-based collection.
+// Equivalent to StartWithContext with a background context.
+func (b *PushableCollectorBuilder) Start() error {
+	return b.StartWithContext(context.Background())
+}
+
+// StartWithContext begins both periodic polling and push-based collection
+// until ctx is cancelled or Stop is called.
+func (b *PushableCollectorBuilder) StartWithContext(ctx context.Context) error {
+	return b.start(ctx, b.collectLoopWithPush)
 }
 
 // collectLoopWithPush handles both periodic pulls and pushed snapshots.
@@ -439,13 +1063,35 @@ func (b *PushableCollectorBuilder) collectLoopWithPush() {
 		case <-ticker.C:
 			// Pull-based collection
 			b.collect()
-		case snapshot := <-b.pushChan:
+		case pushed := <-b.pushChan:
+			if pushed.flushed != nil {
+				// Everything queued before the Flush marker is applied
+				close(pushed.flushed)
+
+				continue
+			}
+
 			// Push-based collection
-			b.updateFromSnapshot(snapshot)
+			b.applyPush(pushed)
 		}
 	}
 }
 
+// applyPush applies a pushed snapshot, honoring both the builder lifecycle and
+// the pusher's context.
+func (b *PushableCollectorBuilder) applyPush(pushed pushedSnapshot) {
+	ctx, cancel := context.WithCancel(pushed.ctx)
+	defer cancel()
+
+	stop := context.AfterFunc(b.ctx, cancel)
+	defer stop()
+
+	if err := b.updateFromSnapshot(ctx, pushed.snapshot); err != nil {
+		b.recordCancelledPush()
+		b.logger.Debug("pushed snapshot not applied", log.Error(err))
+	}
+}
+
 // =============================================================================
 // ERRORS
 // =============================================================================
@@ -462,6 +1108,15 @@ var (
 
 	// ErrPushBufferFull is returned when the push buffer is full.
 	ErrPushBufferFull = &CollectorError{Message: "push buffer full, snapshot dropped"}
+
+	// ErrStopped is returned when the collector is stopped before a flush completes.
+	ErrStopped = &CollectorError{Message: "collector stopped before flush completed"}
+
+	// ErrCollectTimeout is returned when the source does not return within the collect timeout.
+	ErrCollectTimeout = &CollectorError{Message: "metric collection timed out"}
+
+	// ErrLabelConflict is returned when merged snapshots set a label to different values.
+	ErrLabelConflict = &CollectorError{Message: "conflicting snapshot label values"}
 )
 
 // CollectorError represents a collector-related error.
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"maps"
+	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/xraph/go-utils/log"
 	"github.com/xraph/go-utils/metrics"
 )
 
@@ -124,6 +127,97 @@ func TestMetricSnapshot_Validate(t *testing.T) {
 	})
 }
 
+func TestMetricSnapshot_Merge(t *testing.T) {
+	t.Run("Counters are summed", func(t *testing.T) {
+		snapshot := &MetricSnapshot{Counters: map[string]float64{"queries": 10, "errors": 1}}
+		other := &MetricSnapshot{Counters: map[string]float64{"queries": 5, "hits": 3}}
+
+		require.NoError(t, snapshot.Merge(other))
+		assert.Equal(t, map[string]float64{"queries": 15, "errors": 1, "hits": 3}, snapshot.Counters)
+	})
+
+	t.Run("Gauges are last-write-wins", func(t *testing.T) {
+		snapshot := &MetricSnapshot{Gauges: map[string]float64{"connections": 10, "idle": 2}}
+		other := &MetricSnapshot{Gauges: map[string]float64{"connections": 4}}
+
+		require.NoError(t, snapshot.Merge(other))
+		assert.Equal(t, map[string]float64{"connections": 4, "idle": 2}, snapshot.Gauges)
+	})
+
+	t.Run("Observations are concatenated", func(t *testing.T) {
+		snapshot := &MetricSnapshot{
+			Histograms: map[string][]float64{"latency": {1, 2}},
+			Timers:     map[string][]time.Duration{"query": {time.Millisecond}},
+		}
+		other := &MetricSnapshot{
+			Histograms: map[string][]float64{"latency": {3}, "size": {100}},
+			Summaries:  map[string][]float64{"payload": {4, 5}},
+			Timers:     map[string][]time.Duration{"query": {2 * time.Millisecond}},
+			LabeledGauges: map[string][]LabeledValue{
+				"pool_size": {{Labels: map[string]string{"pool": "db"}, Value: 5}},
+			},
+		}
+
+		require.NoError(t, snapshot.Merge(other))
+		assert.Equal(t, []float64{1, 2, 3}, snapshot.Histograms["latency"])
+		assert.Equal(t, []float64{100}, snapshot.Histograms["size"])
+		assert.Equal(t, []float64{4, 5}, snapshot.Summaries["payload"])
+		assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond}, snapshot.Timers["query"])
+		assert.Len(t, snapshot.LabeledGauges["pool_size"], 1)
+
+		// The merged snapshot must not alias other
+		snapshot.Histograms["size"][0] = 0
+		assert.Equal(t, []float64{100}, other.Histograms["size"])
+	})
+
+	t.Run("Labels are unioned", func(t *testing.T) {
+		snapshot := &MetricSnapshot{Labels: map[string]string{"service": "api"}}
+		other := &MetricSnapshot{Labels: map[string]string{"service": "api", "region": "eu"}}
+
+		require.NoError(t, snapshot.Merge(other))
+		assert.Equal(t, map[string]string{"service": "api", "region": "eu"}, snapshot.Labels)
+	})
+
+	t.Run("Conflicting labels keep the receiver's value", func(t *testing.T) {
+		snapshot := &MetricSnapshot{Labels: map[string]string{"service": "api"}}
+		other := &MetricSnapshot{
+			Counters: map[string]float64{"queries": 1},
+			Labels:   map[string]string{"service": "worker"},
+		}
+
+		require.ErrorIs(t, snapshot.Merge(other), ErrLabelConflict)
+		assert.Equal(t, "api", snapshot.Labels["service"])
+		assert.InDelta(t, 1.0, snapshot.Counters["queries"], 0.001)
+	})
+
+	t.Run("Exemplars are last-write-wins", func(t *testing.T) {
+		snapshot := &MetricSnapshot{Exemplars: map[string]metrics.Exemplar{"errors": {TraceID: "a"}}}
+		other := &MetricSnapshot{Exemplars: map[string]metrics.Exemplar{"errors": {TraceID: "b"}}}
+
+		require.NoError(t, snapshot.Merge(other))
+		assert.Equal(t, "b", snapshot.Exemplars["errors"].TraceID)
+	})
+
+	t.Run("Timestamp takes the later value", func(t *testing.T) {
+		now := time.Now()
+		snapshot := &MetricSnapshot{Timestamp: now}
+
+		require.NoError(t, snapshot.Merge(&MetricSnapshot{Timestamp: now.Add(time.Second)}))
+		assert.Equal(t, now.Add(time.Second), snapshot.Timestamp)
+
+		require.NoError(t, snapshot.Merge(&MetricSnapshot{Timestamp: now}))
+		assert.Equal(t, now.Add(time.Second), snapshot.Timestamp)
+	})
+
+	t.Run("Nil snapshots", func(t *testing.T) {
+		snapshot := &MetricSnapshot{}
+		assert.NoError(t, snapshot.Merge(nil))
+
+		var nilSnapshot *MetricSnapshot
+		assert.ErrorIs(t, nilSnapshot.Merge(&MetricSnapshot{}), ErrNilSnapshot)
+	})
+}
+
 // =============================================================================
 // TESTS: CustomCollectorBuilder - Basic Operations
 // =============================================================================
@@ -185,6 +279,187 @@ func TestCustomCollectorBuilder_StartStop(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNotStarted)
 }
 
+// timedMetricSource records when each Collect call starts and finishes.
+type timedMetricSource struct {
+	delay time.Duration
+
+	mu       sync.Mutex
+	starts   []time.Time
+	finishes []time.Time
+}
+
+func (s *timedMetricSource) Name() string {
+	return "timed"
+}
+
+func (s *timedMetricSource) Collect(ctx context.Context) (*MetricSnapshot, error) {
+	s.mu.Lock()
+	s.starts = append(s.starts, time.Now())
+	s.mu.Unlock()
+
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	s.finishes = append(s.finishes, time.Now())
+	s.mu.Unlock()
+
+	return &MetricSnapshot{Gauges: map[string]float64{"up": 1}}, nil
+}
+
+// calls returns the start and finish times recorded so far.
+func (s *timedMetricSource) calls() ([]time.Time, []time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.starts), slices.Clone(s.finishes)
+}
+
+func TestCustomCollectorBuilder_StartWithContext(t *testing.T) {
+	source := &timedMetricSource{delay: 20 * time.Millisecond}
+	builder := NewCustomCollectorBuilder(source).
+		WithInterval(5 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.NoError(t, builder.StartWithContext(ctx))
+
+	require.Eventually(t, func() bool {
+		starts, _ := source.calls()
+
+		return len(starts) >= 2
+	}, time.Second, time.Millisecond)
+
+	// Cancelling the context ends the loop, including an in-flight collection
+	cancel()
+
+	require.NoError(t, builder.Stop())
+
+	stoppedAt := time.Now()
+	starts, finishes := source.calls()
+
+	// Every collection has finished by the time Stop returns
+	require.Len(t, finishes, len(starts))
+	assert.False(t, finishes[len(finishes)-1].After(stoppedAt))
+
+	time.Sleep(30 * time.Millisecond)
+
+	after, _ := source.calls()
+	assert.Len(t, after, len(starts), "Collect called after Stop returned")
+}
+
+func TestCustomCollectorBuilder_Restart(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).
+		WithInterval(5 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, builder.StartWithContext(ctx))
+
+	// A cancelled builder stays started until stopped
+	cancel()
+	assert.ErrorIs(t, builder.Start(), ErrAlreadyStarted)
+	require.NoError(t, builder.Stop())
+
+	calls := source.callCount.Load()
+
+	require.NoError(t, builder.Start())
+
+	require.Eventually(t, func() bool {
+		return source.callCount.Load() > calls
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, builder.Stop())
+}
+
+func TestCustomCollectorBuilder_ConcurrentStartStop(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).
+		WithInterval(time.Millisecond)
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			for range 20 {
+				_ = builder.Start()
+				_ = builder.Stop()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	_ = builder.Stop()
+
+	calls := source.callCount.Load()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, calls, source.callCount.Load())
+}
+
+// hangingMetricSource sleeps past any collect timeout, ignoring its context.
+type hangingMetricSource struct {
+	sleep time.Duration
+	calls atomic.Int32
+}
+
+func (s *hangingMetricSource) Name() string {
+	return "hanging"
+}
+
+func (s *hangingMetricSource) Collect(_ context.Context) (*MetricSnapshot, error) {
+	s.calls.Add(1)
+	time.Sleep(s.sleep)
+
+	return &MetricSnapshot{}, nil
+}
+
+func TestCustomCollectorBuilder_CollectTimeout(t *testing.T) {
+	logger := log.NewTestLogger()
+	source := &hangingMetricSource{sleep: 200 * time.Millisecond}
+	builder := NewCustomCollectorBuilder(source, metrics.WithLogger(logger)).
+		WithInterval(5 * time.Millisecond).
+		WithCollectTimeout(10 * time.Millisecond)
+
+	require.NoError(t, builder.Start())
+
+	// The loop keeps ticking while earlier collections are still hanging
+	require.Eventually(t, func() bool {
+		return builder.Stats().ErrorCount >= 3
+	}, time.Second, time.Millisecond)
+
+	stats := builder.Stats()
+	require.NoError(t, builder.Stop())
+
+	assert.Equal(t, stats.CollectionCount, stats.ErrorCount)
+	assert.Equal(t, ErrCollectTimeout.Error(), stats.LastError)
+	assert.GreaterOrEqual(t, source.calls.Load(), int32(3))
+	assert.True(t, logger.(*log.TestLogger).AssertHasLog("ERROR", "failed to collect metrics"))
+}
+
+func TestCustomCollectorBuilder_CollectOnceTimeout(t *testing.T) {
+	source := &hangingMetricSource{sleep: 100 * time.Millisecond}
+	builder := NewCustomCollectorBuilder(source).
+		WithCollectTimeout(5 * time.Millisecond)
+
+	start := time.Now()
+	err := builder.CollectOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrCollectTimeout)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, int64(1), builder.Stats().ErrorCount)
+
+	// A source that returns in time is unaffected
+	fast := newMockMetricSource("fast")
+	fast.data.Gauges["up"] = 1
+
+	builder = NewCustomCollectorBuilder(fast).WithCollectTimeout(time.Second)
+	require.NoError(t, builder.CollectOnce(context.Background()))
+}
+
 func TestCustomCollectorBuilder_Metrics(t *testing.T) {
 	source := newMockMetricSource("test")
 	builder := NewCustomCollectorBuilder(source)
@@ -194,6 +469,73 @@ func TestCustomCollectorBuilder_Metrics(t *testing.T) {
 	assert.Equal(t, "test", m.Name())
 }
 
+// countingExporter records the metrics it is asked to export.
+type countingExporter struct {
+	mu      sync.Mutex
+	exports []map[string]any
+}
+
+func (e *countingExporter) Export(m map[string]any) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.exports = append(e.exports, m)
+
+	return nil, nil
+}
+
+func (e *countingExporter) Format() string {
+	return "counting"
+}
+
+func (e *countingExporter) Stats() metrics.ExporterStats {
+	return metrics.ExporterStats{Format: "counting"}
+}
+
+func TestCustomCollectorBuilder_ClosedWithMetrics(t *testing.T) {
+	exporter := &countingExporter{}
+	parent := metrics.NewMetricsCollector("app", metrics.WithExporter("final", exporter))
+	parent.Counter("app_requests_total").Inc()
+
+	source := newMockMetricSource("test")
+	source.data.Gauges["temperature"] = 25.5
+
+	builder := NewCustomCollectorBuilder(source).
+		WithInterval(time.Millisecond)
+	require.NoError(t, parent.RegisterCollector(builder))
+	require.NoError(t, builder.Start())
+
+	require.Eventually(t, func() bool {
+		return source.callCount.Load() >= 2
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, parent.Close(context.Background()))
+
+	// Polling has stopped
+	assert.False(t, builder.Stats().Started)
+
+	calls := source.callCount.Load()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, calls, source.callCount.Load())
+
+	// A final export occurred
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+
+	require.Len(t, exporter.exports, 1)
+	assert.Contains(t, exporter.exports[0], "app_requests_total")
+
+	// The builder's values are available through the registered collector
+	assert.Contains(t, builder.Collect(), "temperature")
+}
+
+func TestCustomCollectorBuilder_CloseNotStarted(t *testing.T) {
+	builder := NewCustomCollectorBuilder(newMockMetricSource("test"))
+
+	require.NoError(t, builder.Close(context.Background()))
+}
+
 // =============================================================================
 // TESTS: CustomCollectorBuilder - Collection
 // =============================================================================
@@ -274,6 +616,81 @@ func TestCustomCollectorBuilder_CounterReset(t *testing.T) {
 	assert.Equal(t, 110.0, counter.Value())
 }
 
+func TestCustomCollectorBuilder_CounterWrap(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).WithCounterWrap(1000)
+
+	ctx := context.Background()
+
+	// First collection: counter = 950, near the wrap value
+	source.data.Counters["bytes_total"] = 950
+	err := builder.CollectOnce(ctx)
+	require.NoError(t, err)
+
+	counter := builder.counters["bytes_total"]
+	assert.Equal(t, 950.0, counter.Value())
+
+	// Counter wrapped: 950 -> 1000 -> 20, delta = (1000 - 950) + 20 = 70
+	source.data.Counters["bytes_total"] = 20
+	err = builder.CollectOnce(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1020.0, counter.Value())
+}
+
+func TestCustomCollectorBuilder_CounterWrapReset(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).WithCounterWrap(1000)
+
+	ctx := context.Background()
+
+	// First collection: counter = 400, far from the wrap value
+	source.data.Counters["bytes_total"] = 400
+	err := builder.CollectOnce(ctx)
+	require.NoError(t, err)
+
+	counter := builder.counters["bytes_total"]
+
+	// Counter reset: 400 -> 10 is treated as a reset, delta = 10
+	source.data.Counters["bytes_total"] = 10
+	err = builder.CollectOnce(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 410.0, counter.Value())
+}
+
+func TestCustomCollectorBuilder_CounterDelta_Wrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		wrap     float64
+		old      float64
+		value    float64
+		expected float64
+	}{
+		{name: "increase", wrap: 1000, old: 100, value: 150, expected: 50},
+		{name: "wrap", wrap: 1000, old: 990, value: 5, expected: 15},
+		{name: "wrap at window edge", wrap: 1000, old: 900, value: 0, expected: 100},
+		{name: "reset below window", wrap: 1000, old: 899, value: 5, expected: 5},
+		{name: "previous above wrap value", wrap: 1000, old: 1500, value: 5, expected: 5},
+		{name: "wrap disabled", wrap: 0, old: 990, value: 5, expected: 5},
+		{name: "uint64 wrap", wrap: math.MaxUint64, old: math.MaxUint64 - 4096, value: 4096, expected: 8192},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewCustomCollectorBuilder(newMockMetricSource("test")).WithCounterWrap(tt.wrap)
+			assert.InDelta(t, tt.expected, builder.counterDelta(tt.old, tt.value), 1e-9)
+		})
+	}
+}
+
+func TestCustomCollectorBuilder_WithCounterWrapInvalid(t *testing.T) {
+	for _, maxValue := range []float64{-1, math.Inf(1), math.NaN()} {
+		builder := NewCustomCollectorBuilder(newMockMetricSource("test")).WithCounterWrap(maxValue)
+		assert.Zero(t, builder.counterWrap)
+	}
+}
+
 func TestCustomCollectorBuilder_GaugeAbsolute(t *testing.T) {
 	source := newMockMetricSource("test")
 	builder := NewCustomCollectorBuilder(source)
@@ -380,6 +797,52 @@ func TestCustomCollectorBuilder_ErrorHandling(t *testing.T) {
 	assert.Equal(t, testErr, err)
 }
 
+// partialMetricSource returns the metric groups it could compute along with
+// an error for the group that failed.
+type partialMetricSource struct{}
+
+func (partialMetricSource) Name() string { return "partial" }
+
+func (partialMetricSource) Collect(ctx context.Context) (*MetricSnapshot, error) {
+	return &MetricSnapshot{
+		Gauges: map[string]float64{"connections_open": 7},
+	}, errors.New("replication stats unavailable")
+}
+
+func TestCustomCollectorBuilder_PartialSnapshot(t *testing.T) {
+	builder := NewCustomCollectorBuilder(partialMetricSource{})
+
+	err := builder.CollectOnce(context.Background())
+	require.EqualError(t, err, "replication stats unavailable")
+
+	gauge := builder.gauges["connections_open"]
+	require.NotNil(t, gauge)
+	assert.Equal(t, 7.0, gauge.Value())
+
+	stats := builder.Stats()
+	assert.Equal(t, int64(1), stats.CollectionCount)
+	assert.Equal(t, int64(1), stats.ErrorCount)
+	assert.Equal(t, int64(1), stats.PartialCollections)
+	assert.Equal(t, "replication stats unavailable", stats.LastError)
+}
+
+func TestCustomCollectorBuilder_PartialSnapshotInLoop(t *testing.T) {
+	builder := NewCustomCollectorBuilder(partialMetricSource{}).
+		WithInterval(time.Hour)
+
+	require.NoError(t, builder.Start())
+
+	defer builder.Stop()
+
+	require.Eventually(t, func() bool {
+		_, ok := builder.Metrics().ListMetrics()["connections_open"]
+
+		return ok
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, int64(1), builder.Stats().PartialCollections)
+}
+
 func TestCustomCollectorBuilder_PeriodicCollection(t *testing.T) {
 	source := newMockMetricSource("test")
 	source.data.Counters["requests_total"] = 0
@@ -457,6 +920,41 @@ func TestPushableCollectorBuilder_Push(t *testing.T) {
 	assert.Equal(t, 75.5, gauge.Value())
 }
 
+func TestPushableCollectorBuilder_PushWithExemplars(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source).
+		WithInterval(1 * time.Second) // Long interval to isolate push
+
+	require.NoError(t, builder.Start())
+
+	defer builder.Stop()
+
+	snapshot := &MetricSnapshot{
+		Counters:   map[string]float64{"payment_failures": 1},
+		Histograms: map[string][]float64{"payment_latency": {12, 950}},
+		Exemplars: map[string]metrics.Exemplar{
+			"payment_failures": {TraceID: "trace-1", SpanID: "span-1"},
+			"payment_latency":  {TraceID: "trace-2"},
+		},
+	}
+	require.NoError(t, builder.Push(snapshot))
+
+	require.Eventually(t, func() bool {
+		return builder.Stats().PushCount == 1 && builder.Metrics().Histogram("payment_latency").Count() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	counterExemplars := builder.Metrics().Counter("payment_failures").Exemplars()
+	require.Len(t, counterExemplars, 1)
+	assert.Equal(t, "trace-1", counterExemplars[0].TraceID)
+	assert.Equal(t, "span-1", counterExemplars[0].SpanID)
+
+	// The exemplar is attached to the last observation only
+	histogramExemplars := builder.Metrics().Histogram("payment_latency").Exemplars()
+	require.Len(t, histogramExemplars, 1)
+	assert.Equal(t, "trace-2", histogramExemplars[0].TraceID)
+	assert.InDelta(t, 950.0, histogramExemplars[0].Value, 0.001)
+}
+
 func TestPushableCollectorBuilder_PushBeforeStart(t *testing.T) {
 	source := newMockMetricSource("test")
 	builder := NewPushableCollectorBuilder(source)
@@ -494,6 +992,66 @@ func TestPushableCollectorBuilder_PushBufferFull(t *testing.T) {
 	assert.ErrorIs(t, err, ErrPushBufferFull)
 }
 
+func TestPushableCollectorBuilder_Flush(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source).
+		WithInterval(time.Hour) // Only pushes are applied
+
+	require.NoError(t, builder.Start())
+
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, builder.Push(&MetricSnapshot{
+			Counters:   map[string]float64{"critical_events": float64(i)},
+			Histograms: map[string][]float64{"event_size": {float64(i)}},
+		}))
+	}
+
+	require.NoError(t, builder.Flush(t.Context()))
+
+	// Everything pushed before Flush is recorded before Stop
+	assert.Equal(t, 10.0, builder.Metrics().Counter("critical_events").Value())
+	assert.Equal(t, uint64(10), builder.Metrics().Histogram("event_size").Count())
+	assert.Equal(t, int64(10), builder.Stats().PushCount)
+
+	require.NoError(t, builder.Stop())
+	assert.ErrorIs(t, builder.Flush(t.Context()), ErrNotStarted)
+}
+
+func TestPushableCollectorBuilder_FlushContextDone(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source).
+		WithInterval(time.Hour)
+
+	require.NoError(t, builder.Start())
+
+	defer builder.Stop()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	assert.ErrorIs(t, builder.Flush(ctx), context.Canceled)
+}
+
+func TestPushableCollectorBuilder_FlushOnStop(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source).
+		WithInterval(time.Hour).
+		WithFlushOnStop(time.Second)
+
+	require.NoError(t, builder.Start())
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, builder.Push(&MetricSnapshot{
+			Gauges: map[string]float64{"queue_depth": float64(i)},
+		}))
+	}
+
+	require.NoError(t, builder.Stop())
+
+	assert.Equal(t, 5.0, builder.Metrics().Gauge("queue_depth").Value())
+	assert.ErrorIs(t, builder.Stop(), ErrNotStarted)
+}
+
 func TestPushableCollectorBuilder_HybridMode(t *testing.T) {
 	source := newMockMetricSource("test")
 	source.data.Counters["pull_counter"] = 0
@@ -667,3 +1225,91 @@ func TestCollectorError_Error(t *testing.T) {
 	err := &CollectorError{Message: "test error"}
 	assert.Equal(t, "test error", err.Error())
 }
+
+func TestPushableCollectorBuilder_PushContext(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source).WithInterval(time.Hour)
+
+	require.NoError(t, builder.Start())
+
+	defer builder.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := builder.PushContext(ctx, &MetricSnapshot{
+		Gauges: map[string]float64{"queue_depth": 3},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		builder.mu.RLock()
+		defer builder.mu.RUnlock()
+
+		gauge, ok := builder.gauges["queue_depth"]
+
+		return ok && gauge.Value() == 3
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPushableCollectorBuilder_PushContextCancelled(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source)
+
+	// Mark as started without running the loop so the apply can be driven manually.
+	builder.started.Store(true)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := builder.PushContext(cancelled, &MetricSnapshot{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err = builder.PushContext(ctx, &MetricSnapshot{
+		Gauges:   map[string]float64{"queue_depth": 3},
+		Counters: map[string]float64{"requests_total": 10},
+	})
+	require.NoError(t, err)
+
+	// The request ends before the snapshot is applied
+	cancel()
+	builder.applyPush(<-builder.pushChan)
+
+	assert.Empty(t, builder.gauges)
+	assert.Empty(t, builder.counters)
+	assert.Equal(t, int64(1), builder.Stats().CancelledPushes)
+}
+
+func TestCustomCollectorBuilder_UpdateFromSnapshotCancelled(t *testing.T) {
+	builder := NewCustomCollectorBuilder(newMockMetricSource("test"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := builder.updateFromSnapshot(ctx, &MetricSnapshot{
+		Gauges: map[string]float64{"queue_depth": 3},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, builder.gauges)
+}
+
+func TestCustomCollectorBuilder_NamespaceAndSubsystem(t *testing.T) {
+	source := newMockMetricSource("postgres")
+	source.data.Gauges["connections_open"] = 7
+
+	builder := NewCustomCollectorBuilder(source).
+		WithOptions(
+			metrics.WithNamespace("db"),
+			metrics.WithSubsystem("postgres"),
+		)
+
+	require.NoError(t, builder.CollectOnce(context.Background()))
+
+	assert.Contains(t, builder.Metrics().ListMetrics(), "db_postgres_connections_open")
+
+	data, err := builder.Metrics().Export(metrics.ExportFormatPrometheus)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "db_postgres_connections_open 7\n")
+}
@@ -88,6 +88,21 @@
 //	}
 //	collector.Push(snapshot)
 //
+// Use PushContext to bound a push by a request context. If the context ends
+// before the snapshot is applied, it is discarded instead of outliving the request:
+//
+//	collector.PushContext(r.Context(), snapshot)
+//
+// Snapshots built by several sub-collectors can be combined with Merge before
+// a single push. Counters are summed, gauges are last-write-wins and
+// observations are appended:
+//
+//	snapshot := dbSnapshot()
+//	if err := snapshot.Merge(cacheSnapshot()); err != nil {
+//	    // both snapshots set the same label to different values
+//	}
+//	builder.Push(snapshot)
+//
 // # Counter Delta Tracking
 //
 // The builder automatically tracks counter deltas. If your datasource returns
@@ -101,6 +116,18 @@
 // If a counter reset is detected (new value < old value), the builder treats
 // the new value as the delta to avoid negative values.
 //
+// Counters that wrap around instead, such as uint64 counters overflowing,
+// are declared with WithCounterWrap. A decrease from a value in the top 10%
+// of the range is then recorded as a wrap:
+//
+//	builder.WithCounterWrap(math.MaxUint64)
+//
+// To report how fast a counter grows rather than its increase, wrap it with
+// NewRateSource, which exports the per-second rate over a rolling window as
+// a gauge:
+//
+//	source := collectors.NewRateSource("requests_per_second", requests.Value, time.Minute)
+//
 // # Lazy Metric Creation
 //
 // Metrics are created on-demand during collection. Your datasource can
@@ -122,6 +149,15 @@
 // Collection errors from your datasource are logged but don't stop the
 // collection loop. This allows transient errors without losing all metrics.
 //
+// A datasource may return a partial snapshot together with an error when only
+// some of its metric groups fail. The builder applies the partial snapshot and
+// still counts the collection as failed:
+//
+//	snapshot := &collectors.MetricSnapshot{Gauges: poolGauges}
+//	if err := s.collectReplication(snapshot); err != nil {
+//	    return snapshot, err // pool gauges are still recorded
+//	}
+//
 // # Best Practices
 //
 //  1. Keep Collect() fast - it's called on every interval
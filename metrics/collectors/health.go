@@ -0,0 +1,228 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xraph/go-utils/metrics"
+)
+
+// =============================================================================
+// HEALTH CHECK INTEGRATION
+// =============================================================================
+
+// StatsProvider is implemented by collector builders that expose collection
+// statistics. Both CustomCollectorBuilder and PushableCollectorBuilder satisfy it.
+type StatsProvider interface {
+	// Name returns the collector name
+	Name() string
+
+	// Stats returns the current collection statistics
+	Stats() BuilderStats
+}
+
+const (
+	// DefaultMaxMissedIntervals is the number of collection intervals that may
+	// pass without a collection before the collector is considered stalled.
+	DefaultMaxMissedIntervals = 3
+
+	// DefaultMaxConsecutiveErrors is the number of consecutive failed
+	// collections after which the collector is considered unhealthy.
+	DefaultMaxConsecutiveErrors = 3
+
+	// DefaultMaxErrorRate is the fraction of recent failed collections above
+	// which the collector is considered unhealthy.
+	DefaultMaxErrorRate = 0.5
+
+	// DefaultHealthCheckTimeout is the timeout reported by collector health checks.
+	DefaultHealthCheckTimeout = 5 * time.Second
+)
+
+// HealthCheckOption configures a collector health check.
+type HealthCheckOption func(*collectorHealthCheck)
+
+// WithMaxMissedIntervals sets how many intervals may pass without a
+// collection before the collector is reported as stalled.
+func WithMaxMissedIntervals(n int) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		if n > 0 {
+			h.maxMissedIntervals = n
+		}
+	}
+}
+
+// WithMaxConsecutiveErrors sets how many consecutive failed collections are
+// tolerated before the collector is reported as unhealthy.
+func WithMaxConsecutiveErrors(n int64) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		if n > 0 {
+			h.maxConsecutiveErrors = n
+		}
+	}
+}
+
+// WithMaxErrorRate sets the fraction of failed collections, among the last
+// ErrorRateWindow, above which the collector is reported as unhealthy.
+func WithMaxErrorRate(rate float64) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		if rate > 0 {
+			h.maxErrorRate = rate
+		}
+	}
+}
+
+// WithHealthCheckName overrides the health check name.
+func WithHealthCheckName(name string) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		h.name = name
+	}
+}
+
+// WithHealthCheckTimeout sets the timeout reported by the health check.
+func WithHealthCheckTimeout(timeout time.Duration) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		h.timeout = timeout
+	}
+}
+
+// WithHealthCheckCritical marks the health check as critical.
+func WithHealthCheckCritical(critical bool) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		h.critical = critical
+	}
+}
+
+// WithHealthCheckDependencies sets the dependencies reported by the health check.
+func WithHealthCheckDependencies(deps ...string) HealthCheckOption {
+	return func(h *collectorHealthCheck) {
+		h.dependencies = deps
+	}
+}
+
+// collectorHealthCheck reports whether a collector builder is collecting successfully.
+type collectorHealthCheck struct {
+	builder              StatsProvider
+	name                 string
+	timeout              time.Duration
+	critical             bool
+	dependencies         []string
+	maxMissedIntervals   int
+	maxConsecutiveErrors int64
+	maxErrorRate         float64
+}
+
+// HealthCheck returns a health check for the given collector builder.
+// The check is unhealthy when the builder is not running, when no collection
+// has happened within the configured number of intervals, or when recent
+// collections are failing. Isolated failures report degraded.
+func HealthCheck(builder StatsProvider, opts ...HealthCheckOption) metrics.HealthCheck {
+	h := &collectorHealthCheck{
+		builder:              builder,
+		name:                 "collector_" + builder.Name(),
+		timeout:              DefaultHealthCheckTimeout,
+		maxMissedIntervals:   DefaultMaxMissedIntervals,
+		maxConsecutiveErrors: DefaultMaxConsecutiveErrors,
+		maxErrorRate:         DefaultMaxErrorRate,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Name returns the health check name.
+func (h *collectorHealthCheck) Name() string {
+	return h.name
+}
+
+// Timeout returns the health check timeout.
+func (h *collectorHealthCheck) Timeout() time.Duration {
+	return h.timeout
+}
+
+// Critical returns whether the health check is critical.
+func (h *collectorHealthCheck) Critical() bool {
+	return h.critical
+}
+
+// Dependencies returns the health check dependencies.
+func (h *collectorHealthCheck) Dependencies() []string {
+	return h.dependencies
+}
+
+// Check inspects the builder's statistics and reports its health.
+func (h *collectorHealthCheck) Check(ctx context.Context) *metrics.HealthResult {
+	start := time.Now()
+	stats := h.builder.Stats()
+
+	result := metrics.NewHealthResult(h.name, metrics.HealthStatusHealthy, "collector is healthy").
+		WithCritical(h.critical).
+		WithDetails(map[string]any{
+			"collection_count":   stats.CollectionCount,
+			"error_count":        stats.ErrorCount,
+			"consecutive_errors": stats.ConsecutiveErrors,
+			"error_rate":         stats.ErrorRate(),
+			"interval":           stats.Interval.String(),
+		})
+
+	if !stats.LastCollectionTime.IsZero() {
+		result.WithDetail("last_collection_time", stats.LastCollectionTime)
+	}
+
+	if stats.LastError != "" {
+		result.WithDetail("last_error", stats.LastError)
+	}
+
+	h.evaluate(stats, start, result)
+
+	return result.WithDuration(time.Since(start))
+}
+
+// evaluate sets the result status and message from the builder statistics.
+func (h *collectorHealthCheck) evaluate(stats BuilderStats, now time.Time, result *metrics.HealthResult) {
+	if !stats.Started {
+		result.WithStatus(metrics.HealthStatusUnhealthy).WithMessage("collector is not running")
+
+		return
+	}
+
+	// Measure staleness from the last collection, or from start if the
+	// builder has not collected yet.
+	last := stats.LastCollectionTime
+	if last.IsZero() {
+		last = stats.StartTime
+	}
+
+	if stats.Interval > 0 {
+		window := stats.Interval * time.Duration(h.maxMissedIntervals)
+		if since := now.Sub(last); since > window {
+			result.WithStatus(metrics.HealthStatusUnhealthy).
+				WithMessage(fmt.Sprintf("collection stalled: no collection for %s (limit %s)", since.Round(time.Millisecond), window))
+
+			return
+		}
+	}
+
+	if stats.ConsecutiveErrors >= h.maxConsecutiveErrors {
+		result.WithStatus(metrics.HealthStatusUnhealthy).
+			WithMessage(fmt.Sprintf("%d consecutive collections failed", stats.ConsecutiveErrors))
+
+		return
+	}
+
+	// Require a minimum sample before judging the error rate so a single
+	// early failure only degrades the check.
+	if rate := stats.ErrorRate(); stats.RecentCollections >= h.maxConsecutiveErrors && rate > h.maxErrorRate {
+		result.WithStatus(metrics.HealthStatusUnhealthy).
+			WithMessage(fmt.Sprintf("collection error rate %.2f exceeds %.2f", rate, h.maxErrorRate))
+
+		return
+	}
+
+	if stats.ConsecutiveErrors > 0 {
+		result.WithStatus(metrics.HealthStatusDegraded).WithMessage("last collection failed")
+	}
+}
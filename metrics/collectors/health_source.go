@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"context"
+
+	"github.com/xraph/go-utils/metrics"
+)
+
+const (
+	// HealthCheckStatusMetric is the labeled gauge reporting each check's status.
+	HealthCheckStatusMetric = "health_check_status"
+
+	// HealthCheckDurationMetric is the labeled gauge reporting each check's
+	// last duration in seconds.
+	HealthCheckDurationMetric = "health_check_duration_seconds"
+
+	// HealthStatusMetric is the gauge reporting the overall health status.
+	HealthStatusMetric = "health_status"
+)
+
+// healthSource exposes health check results as metrics.
+type healthSource struct {
+	hm metrics.HealthManager
+}
+
+// NewHealthCollector returns a metric source that exports the health
+// manager's report as gauges, so health can be alerted on through the metrics
+// pipeline. Each check gets a health_check_status gauge labeled by check name
+// (1 healthy, 0.5 degraded, 0 unhealthy or unknown) and a
+// health_check_duration_seconds gauge. The overall status is exported as
+// health_status.
+//
+// The last report is used when available; otherwise checks are run once.
+//
+//	builder := collectors.NewCustomCollectorBuilder(collectors.NewHealthCollector(hm))
+//	builder.Start()
+func NewHealthCollector(hm metrics.HealthManager) CustomMetricSource {
+	return &healthSource{hm: hm}
+}
+
+// Name returns the source name.
+func (s *healthSource) Name() string {
+	return "health"
+}
+
+// Collect converts the latest health report into gauges.
+func (s *healthSource) Collect(ctx context.Context) (*MetricSnapshot, error) {
+	report := s.hm.LastReport()
+	if report == nil {
+		report = s.hm.Check(ctx)
+	}
+
+	snapshot := &MetricSnapshot{
+		Gauges: map[string]float64{
+			HealthStatusMetric: HealthStatusValue(report.Overall),
+		},
+		LabeledGauges: map[string][]LabeledValue{
+			HealthCheckStatusMetric:   make([]LabeledValue, 0, len(report.Services)),
+			HealthCheckDurationMetric: make([]LabeledValue, 0, len(report.Services)),
+		},
+		Timestamp: report.Timestamp,
+	}
+
+	for name, result := range report.Services {
+		labels := map[string]string{"check": name}
+
+		snapshot.LabeledGauges[HealthCheckStatusMetric] = append(snapshot.LabeledGauges[HealthCheckStatusMetric],
+			LabeledValue{Labels: labels, Value: HealthStatusValue(result.Status)})
+		snapshot.LabeledGauges[HealthCheckDurationMetric] = append(snapshot.LabeledGauges[HealthCheckDurationMetric],
+			LabeledValue{Labels: labels, Value: result.Duration.Seconds()})
+	}
+
+	return snapshot, nil
+}
+
+// HealthStatusValue maps a health status to a gauge value:
+// 1 for healthy, 0.5 for degraded, and 0 for unhealthy or unknown.
+func HealthStatusValue(status metrics.HealthStatus) float64 {
+	switch status {
+	case metrics.HealthStatusHealthy:
+		return 1
+	case metrics.HealthStatusDegraded:
+		return 0.5
+	default:
+		return 0
+	}
+}
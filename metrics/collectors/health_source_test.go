@@ -0,0 +1,116 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xraph/go-utils/metrics"
+)
+
+func newTestHealthReport() *metrics.HealthReport {
+	report := metrics.NewHealthReport()
+	report.AddResults([]*metrics.HealthResult{
+		metrics.NewHealthResult("db", metrics.HealthStatusHealthy, "ok").WithDuration(20 * time.Millisecond),
+		metrics.NewHealthResult("cache", metrics.HealthStatusDegraded, "slow").WithDuration(500 * time.Millisecond),
+		metrics.NewHealthResult("queue", metrics.HealthStatusUnhealthy, "down").WithDuration(time.Second),
+	})
+	report.Overall = metrics.HealthStatusDegraded
+
+	return report
+}
+
+func labeledValues(values []LabeledValue) map[string]float64 {
+	result := make(map[string]float64, len(values))
+	for _, v := range values {
+		result[v.Labels["check"]] = v.Value
+	}
+
+	return result
+}
+
+func TestHealthCollector_Collect(t *testing.T) {
+	hm := metrics.NewMockHealthManager()
+	report := newTestHealthReport()
+	hm.LastReportFunc = func() *metrics.HealthReport { return report }
+
+	source := NewHealthCollector(hm)
+	assert.Equal(t, "health", source.Name())
+
+	snapshot, err := source.Collect(context.Background())
+	require.NoError(t, err)
+
+	assert.InDelta(t, 0.5, snapshot.Gauges[HealthStatusMetric], 0)
+	assert.Equal(t, map[string]float64{
+		"db":    1,
+		"cache": 0.5,
+		"queue": 0,
+	}, labeledValues(snapshot.LabeledGauges[HealthCheckStatusMetric]))
+	assert.Equal(t, map[string]float64{
+		"db":    0.02,
+		"cache": 0.5,
+		"queue": 1,
+	}, labeledValues(snapshot.LabeledGauges[HealthCheckDurationMetric]))
+
+	assert.Equal(t, 0, hm.CheckCalls)
+}
+
+func TestHealthCollector_RunsChecksWithoutReport(t *testing.T) {
+	hm := metrics.NewMockHealthManager()
+	hm.CheckFunc = func(ctx context.Context) *metrics.HealthReport { return newTestHealthReport() }
+
+	snapshot, err := NewHealthCollector(hm).Collect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, hm.CheckCalls)
+	assert.Len(t, snapshot.LabeledGauges[HealthCheckStatusMetric], 3)
+}
+
+func TestHealthCollector_WithBuilder(t *testing.T) {
+	hm := metrics.NewMockHealthManager()
+	report := newTestHealthReport()
+	hm.LastReportFunc = func() *metrics.HealthReport { return report }
+
+	builder := NewCustomCollectorBuilder(NewHealthCollector(hm))
+	require.NoError(t, builder.CollectOnce(context.Background()))
+
+	var status map[string]any
+
+	for _, collector := range builder.Metrics().ListCollectors() {
+		if collector.Name() == HealthCheckStatusMetric {
+			status = collector.Collect()
+		}
+	}
+
+	require.NotNil(t, status)
+	assert.Equal(t, map[string]any{
+		"check=db":    1.0,
+		"check=cache": 0.5,
+		"check=queue": 0.0,
+	}, status)
+
+	assert.InDelta(t, 0.5, builder.gauges[HealthStatusMetric].Value(), 0)
+}
+
+func TestHealthCollector_RemovesUnregisteredChecks(t *testing.T) {
+	hm := metrics.NewMockHealthManager()
+	report := newTestHealthReport()
+	hm.LastReportFunc = func() *metrics.HealthReport { return report }
+
+	builder := NewCustomCollectorBuilder(NewHealthCollector(hm))
+	require.NoError(t, builder.CollectOnce(context.Background()))
+
+	// The queue check is unregistered before the next collection
+	report = newTestHealthReport()
+	delete(report.Services, "queue")
+	require.NoError(t, builder.CollectOnce(context.Background()))
+
+	assert.Equal(t, map[string]any{
+		"check=db":    1.0,
+		"check=cache": 0.5,
+	}, builder.gaugeVecs[HealthCheckStatusMetric].Collect())
+	assert.NotContains(t, builder.gaugeVecs[HealthCheckDurationMetric].Collect(), "check=queue")
+}
@@ -0,0 +1,186 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xraph/go-utils/metrics"
+)
+
+func TestCustomCollectorBuilder_Stats(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).WithInterval(time.Second)
+
+	ctx := context.Background()
+
+	require.NoError(t, builder.CollectOnce(ctx))
+
+	source.SetError(errors.New("collection failed"))
+	require.Error(t, builder.CollectOnce(ctx))
+	require.Error(t, builder.CollectOnce(ctx))
+
+	stats := builder.Stats()
+	assert.Equal(t, "test", stats.Name)
+	assert.False(t, stats.Started)
+	assert.Equal(t, time.Second, stats.Interval)
+	assert.Equal(t, int64(3), stats.CollectionCount)
+	assert.Equal(t, int64(2), stats.ErrorCount)
+	assert.Equal(t, int64(2), stats.ConsecutiveErrors)
+	assert.Equal(t, "collection failed", stats.LastError)
+	assert.False(t, stats.LastSuccessTime.IsZero())
+	assert.InDelta(t, 2.0/3.0, stats.ErrorRate(), 0.0001)
+
+	source.SetError(nil)
+	require.NoError(t, builder.CollectOnce(ctx))
+	assert.Equal(t, int64(0), builder.Stats().ConsecutiveErrors)
+}
+
+func TestCustomCollectorBuilder_ErrorRateWindow(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source)
+
+	ctx := context.Background()
+
+	source.SetError(errors.New("collection failed"))
+
+	for range ErrorRateWindow {
+		_ = builder.CollectOnce(ctx)
+	}
+
+	assert.InDelta(t, 1.0, builder.Stats().ErrorRate(), 0)
+
+	// Successes push the old failures out of the window
+	source.SetError(nil)
+
+	for range ErrorRateWindow / 2 {
+		require.NoError(t, builder.CollectOnce(ctx))
+	}
+
+	stats := builder.Stats()
+	assert.Equal(t, int64(ErrorRateWindow), stats.RecentCollections)
+	assert.Equal(t, int64(ErrorRateWindow/2), stats.RecentErrors)
+	assert.InDelta(t, 0.5, stats.ErrorRate(), 0)
+
+	for range ErrorRateWindow / 2 {
+		require.NoError(t, builder.CollectOnce(ctx))
+	}
+
+	stats = builder.Stats()
+	assert.InDelta(t, 0.0, stats.ErrorRate(), 0)
+
+	// Lifetime totals are kept
+	assert.Equal(t, int64(2*ErrorRateWindow), stats.CollectionCount)
+	assert.Equal(t, int64(ErrorRateWindow), stats.ErrorCount)
+}
+
+func TestPushableCollectorBuilder_PushStats(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewPushableCollectorBuilder(source).
+		WithInterval(time.Hour).
+		WithBufferSize(1)
+
+	// Fill the buffer without a running loop to force a drop.
+	builder.started.Store(true)
+
+	require.NoError(t, builder.Push(&MetricSnapshot{}))
+	require.ErrorIs(t, builder.Push(&MetricSnapshot{}), ErrPushBufferFull)
+
+	stats := builder.Stats()
+	assert.Equal(t, int64(1), stats.PushCount)
+	assert.Equal(t, int64(1), stats.DroppedPushes)
+}
+
+func TestHealthCheck_Healthy(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).WithInterval(50 * time.Millisecond)
+
+	require.NoError(t, builder.Start())
+
+	defer builder.Stop()
+
+	require.Eventually(t, func() bool {
+		return builder.Stats().CollectionCount > 0
+	}, time.Second, 10*time.Millisecond)
+
+	check := HealthCheck(builder, WithHealthCheckCritical(true))
+	assert.Equal(t, "collector_test", check.Name())
+	assert.True(t, check.Critical())
+	assert.Equal(t, DefaultHealthCheckTimeout, check.Timeout())
+
+	result := check.Check(context.Background())
+	assert.Equal(t, metrics.HealthStatusHealthy, result.Status)
+	assert.True(t, result.Critical)
+}
+
+func TestHealthCheck_NotStarted(t *testing.T) {
+	builder := NewCustomCollectorBuilder(newMockMetricSource("test"))
+
+	result := HealthCheck(builder).Check(context.Background())
+	assert.Equal(t, metrics.HealthStatusUnhealthy, result.Status)
+}
+
+func TestHealthCheck_Stalled(t *testing.T) {
+	builder := NewCustomCollectorBuilder(newMockMetricSource("test")).WithInterval(10 * time.Millisecond)
+
+	// Simulate a running builder whose last collection is long overdue.
+	builder.started.Store(true)
+	builder.stats.StartTime = time.Now().Add(-time.Second)
+	builder.stats.LastCollectionTime = time.Now().Add(-time.Second)
+	builder.stats.CollectionCount = 1
+
+	result := HealthCheck(builder, WithMaxMissedIntervals(3)).Check(context.Background())
+	assert.Equal(t, metrics.HealthStatusUnhealthy, result.Status)
+	assert.Contains(t, result.Message, "stalled")
+}
+
+func TestHealthCheck_Errors(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).WithInterval(time.Hour)
+	builder.started.Store(true)
+	builder.markStarted()
+
+	ctx := context.Background()
+	check := HealthCheck(builder, WithMaxConsecutiveErrors(2))
+
+	source.SetError(errors.New("collection failed"))
+	require.Error(t, builder.CollectOnce(ctx))
+
+	result := check.Check(ctx)
+	assert.Equal(t, metrics.HealthStatusDegraded, result.Status)
+	assert.Equal(t, "collection failed", result.Details["last_error"])
+
+	require.Error(t, builder.CollectOnce(ctx))
+
+	result = check.Check(ctx)
+	assert.Equal(t, metrics.HealthStatusUnhealthy, result.Status)
+	assert.Contains(t, result.Message, "consecutive")
+}
+
+func TestHealthCheck_ErrorRate(t *testing.T) {
+	source := newMockMetricSource("test")
+	builder := NewCustomCollectorBuilder(source).WithInterval(time.Hour)
+	builder.started.Store(true)
+	builder.markStarted()
+
+	ctx := context.Background()
+
+	// Alternate failures and successes so consecutive errors never accumulate.
+	for range 3 {
+		source.SetError(errors.New("collection failed"))
+		_ = builder.CollectOnce(ctx)
+		source.SetError(nil)
+		_ = builder.CollectOnce(ctx)
+	}
+
+	source.SetError(errors.New("collection failed"))
+	_ = builder.CollectOnce(ctx)
+
+	result := HealthCheck(builder, WithMaxErrorRate(0.5)).Check(ctx)
+	assert.Equal(t, metrics.HealthStatusUnhealthy, result.Status)
+	assert.Contains(t, result.Message, "error rate")
+}
@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateSample is a counter value read at a point in time.
+type rateSample struct {
+	at    time.Time
+	value float64
+}
+
+// rateSource exposes the rolling per-second rate of a counter as a gauge.
+type rateSource struct {
+	name      string
+	counterFn func() float64
+	window    time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	samples []rateSample
+}
+
+// NewRateSource returns a metric source that turns a counter into a rolling
+// rate. Every Collect reads counterFn and reports the gauge name as the
+// per-second increase over the samples taken within the last window:
+//
+//	source := collectors.NewRateSource("requests_per_second", requests.Value, time.Minute)
+//	builder := collectors.NewCustomCollectorBuilder(source).WithInterval(5 * time.Second)
+//
+// The rate is 0 until two samples are in the window, so the window should
+// span several collection intervals. A decrease is treated as a counter
+// reset, counting the new value as the increase since the previous sample.
+func NewRateSource(name string, counterFn func() float64, window time.Duration) CustomMetricSource {
+	return &rateSource{
+		name:      name,
+		counterFn: counterFn,
+		window:    window,
+		now:       time.Now,
+	}
+}
+
+// Name returns the gauge name.
+func (s *rateSource) Name() string {
+	return s.name
+}
+
+// Collect samples the counter and reports the rate over the window.
+func (s *rateSource) Collect(ctx context.Context) (*MetricSnapshot, error) {
+	now := s.now()
+	value := s.counterFn()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.samples = append(s.samples, rateSample{at: now, value: value})
+
+	// Drop samples that fell out of the window
+	cutoff := now.Add(-s.window)
+
+	first := 0
+	for first < len(s.samples)-1 && s.samples[first].at.Before(cutoff) {
+		first++
+	}
+
+	s.samples = append(s.samples[:0], s.samples[first:]...)
+
+	return &MetricSnapshot{
+		Gauges:    map[string]float64{s.name: s.rate()},
+		Timestamp: now,
+	}, nil
+}
+
+// rate returns the per-second increase across the samples. Must be called
+// with s.mu held.
+func (s *rateSource) rate() float64 {
+	if len(s.samples) < 2 {
+		return 0
+	}
+
+	var increase float64
+
+	for i := 1; i < len(s.samples); i++ {
+		delta := s.samples[i].value - s.samples[i-1].value
+		if delta < 0 {
+			delta = s.samples[i].value
+		}
+
+		increase += delta
+	}
+
+	elapsed := s.samples[len(s.samples)-1].at.Sub(s.samples[0].at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return increase / elapsed
+}
@@ -0,0 +1,107 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xraph/go-utils/metrics"
+)
+
+// fakeClock is a manually advanced clock for rate sources.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestRateSource(counter *float64, window time.Duration) (*rateSource, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	source := NewRateSource("requests_per_second", func() float64 { return *counter }, window).(*rateSource)
+	source.now = clock.Now
+
+	return source, clock
+}
+
+func collectRate(t *testing.T, source CustomMetricSource) float64 {
+	t.Helper()
+
+	snapshot, err := source.Collect(context.Background())
+	require.NoError(t, err)
+
+	return snapshot.Gauges["requests_per_second"]
+}
+
+func TestRateSource(t *testing.T) {
+	var counter float64
+
+	source, clock := newTestRateSource(&counter, 30*time.Second)
+	assert.Equal(t, "requests_per_second", source.Name())
+
+	// A single sample has no rate yet
+	assert.InDelta(t, 0, collectRate(t, source), 0)
+
+	// 10 requests per second for 30 seconds
+	for range 3 {
+		clock.Advance(10 * time.Second)
+		counter += 100
+		assert.InDelta(t, 10, collectRate(t, source), 1e-9)
+	}
+
+	// The traffic rises to 40 per second; the window still holds
+	// 20 seconds at the old rate
+	clock.Advance(10 * time.Second)
+	counter += 400
+	assert.InDelta(t, 20, collectRate(t, source), 1e-9)
+
+	// Once the old samples leave the window only the new rate remains
+	var rate float64
+
+	for range 3 {
+		clock.Advance(10 * time.Second)
+		counter += 400
+		rate = collectRate(t, source)
+	}
+
+	assert.InDelta(t, 40, rate, 1e-9)
+	assert.Len(t, source.samples, 4)
+}
+
+func TestRateSource_CounterReset(t *testing.T) {
+	counter := 1000.0
+
+	source, clock := newTestRateSource(&counter, time.Minute)
+	collectRate(t, source)
+
+	clock.Advance(10 * time.Second)
+	counter = 1100
+	collectRate(t, source)
+
+	// The counter restarted and counted 50 since
+	clock.Advance(10 * time.Second)
+	counter = 50
+	assert.InDelta(t, 7.5, collectRate(t, source), 1e-9)
+}
+
+func TestRateSource_Builder(t *testing.T) {
+	var counter float64
+
+	source, clock := newTestRateSource(&counter, time.Minute)
+	builder := NewCustomCollectorBuilder(source)
+
+	require.NoError(t, builder.CollectOnce(context.Background()))
+
+	clock.Advance(5 * time.Second)
+	counter = 25
+	require.NoError(t, builder.CollectOnce(context.Background()))
+
+	gauge, ok := builder.Metrics().ListMetrics()["requests_per_second"].(metrics.Gauge)
+	require.True(t, ok)
+	assert.InDelta(t, 5, gauge.Value(), 1e-9)
+}
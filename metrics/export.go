@@ -0,0 +1,550 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/xraph/go-utils/log"
+)
+
+// =============================================================================
+// JSON EXPORT
+// =============================================================================
+
+// JSONExportSchemaVersion is the version of the JSON export format produced by
+// Export(ExportFormatJSON).
+//
+// Versioning policy: the version is bumped whenever a field is removed,
+// renamed, or changes meaning or type. Adding new optional fields does not
+// bump the version, so consumers should ignore fields they do not know.
+// ParseJSONExport rejects versions it does not support.
+const JSONExportSchemaVersion = 1
+
+// Snapshot is the typed representation of a JSON export.
+// Metric maps are keyed by metric name.
+type Snapshot struct {
+	SchemaVersion int                       `json:"schema_version"`
+	Collector     string                    `json:"collector"`
+	Timestamp     time.Time                 `json:"timestamp"`
+	Counters      map[string]ValueSnapshot  `json:"counters"`
+	Gauges        map[string]ValueSnapshot  `json:"gauges"`
+	Histograms    map[string]StatsSnapshot  `json:"histograms"`
+	Summaries     map[string]StatsSnapshot  `json:"summaries"`
+	Timers        map[string]TimerSnapshot  `json:"timers"`
+	Collectors    map[string]map[string]any `json:"collectors,omitempty"`
+}
+
+// ValueSnapshot is the exported state of a counter or gauge.
+type ValueSnapshot struct {
+	Value   float64           `json:"value"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Updated time.Time         `json:"updated,omitzero"`
+}
+
+// StatsSnapshot is the exported state of a histogram or summary.
+// Quantiles are keyed by the quantile formatted as a decimal string (e.g. "0.95").
+// Buckets are only set for histograms.
+type StatsSnapshot struct {
+	Count     uint64             `json:"count"`
+	Sum       float64            `json:"sum"`
+	Min       float64            `json:"min"`
+	Max       float64            `json:"max"`
+	Mean      float64            `json:"mean"`
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+	Buckets   []BucketSnapshot   `json:"buckets,omitempty"`
+	Labels    map[string]string  `json:"labels,omitempty"`
+	Updated   time.Time          `json:"updated,omitzero"`
+}
+
+// BucketSnapshot is an exported histogram bucket. Buckets are ordered by
+// upper bound and end with the "+Inf" bucket, whose Count equals the
+// histogram's count. Le is the upper bound formatted like the Prometheus le
+// label, since JSON cannot encode +Inf as a number. Count is cumulative;
+// BucketCount is the bucket's own count, as returned by Histogram.Buckets.
+type BucketSnapshot struct {
+	Le          string `json:"le"`
+	Count       uint64 `json:"count"`
+	BucketCount uint64 `json:"bucket_count"`
+}
+
+// TimerSnapshot is the exported state of a timer. Durations are in milliseconds.
+type TimerSnapshot struct {
+	Count   uint64            `json:"count"`
+	SumMs   float64           `json:"sum_ms"`
+	MinMs   float64           `json:"min_ms"`
+	MaxMs   float64           `json:"max_ms"`
+	MeanMs  float64           `json:"mean_ms"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Updated time.Time         `json:"updated,omitzero"`
+}
+
+// MetricSnapshotEntry is the state of a single metric captured by Snapshot.
+// Value is set for counters and gauges; the aggregates are set for
+// histograms, summaries and timers. Timer aggregates are in the timer's
+// unit, named by Unit (milliseconds unless set with WithTimerUnit).
+// Quantiles are only set for summaries and are keyed like StatsSnapshot.
+type MetricSnapshotEntry struct {
+	Name      string             `json:"name"`
+	Type      MetricType         `json:"type"`
+	Value     float64            `json:"value,omitempty"`
+	Count     uint64             `json:"count,omitempty"`
+	Sum       float64            `json:"sum,omitempty"`
+	Min       float64            `json:"min,omitempty"`
+	Max       float64            `json:"max,omitempty"`
+	Mean      float64            `json:"mean,omitempty"`
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+	Unit      string             `json:"unit,omitempty"`
+	Labels    map[string]string  `json:"labels,omitempty"`
+	Updated   time.Time          `json:"updated,omitzero"`
+}
+
+// Snapshot returns the current values of all metrics keyed like ListMetrics.
+// The values are read under the collector's read lock, so no metric is
+// registered, removed or reset while the snapshot is taken. Observations
+// recorded concurrently may or may not be included.
+func (mc *metricsCollector) Snapshot() map[string]MetricSnapshotEntry {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	entries := make(map[string]MetricSnapshotEntry,
+		len(mc.counters)+len(mc.gauges)+len(mc.histograms)+len(mc.summaries)+len(mc.timers))
+
+	for key, counter := range mc.counters {
+		entries[key] = MetricSnapshotEntry{
+			Name:    counter.fullName(),
+			Type:    MetricTypeCounter,
+			Value:   counter.Value(),
+			Labels:  counter.exportLabels(),
+			Updated: counter.getTimestamp(),
+		}
+	}
+
+	for key, gauge := range mc.gauges {
+		entries[key] = MetricSnapshotEntry{
+			Name:    gauge.fullName(),
+			Type:    MetricTypeGauge,
+			Value:   gauge.Value(),
+			Labels:  gauge.exportLabels(),
+			Updated: gauge.getTimestamp(),
+		}
+	}
+
+	for key, histogram := range mc.histograms {
+		entries[key] = MetricSnapshotEntry{
+			Name:    histogram.fullName(),
+			Type:    MetricTypeHistogram,
+			Count:   histogram.Count(),
+			Sum:     histogram.Sum(),
+			Min:     histogram.Min(),
+			Max:     histogram.Max(),
+			Mean:    histogram.Mean(),
+			Labels:  histogram.exportLabels(),
+			Updated: histogram.getTimestamp(),
+		}
+	}
+
+	for key, summary := range mc.summaries {
+		quantiles := make(map[string]float64, len(summary.objectives))
+		for q := range summary.objectives {
+			quantiles[strconv.FormatFloat(q, 'f', -1, 64)] = summary.Quantile(q)
+		}
+
+		entries[key] = MetricSnapshotEntry{
+			Name:      summary.fullName(),
+			Type:      MetricTypeSummary,
+			Count:     summary.Count(),
+			Sum:       summary.Sum(),
+			Min:       summary.Min(),
+			Max:       summary.Max(),
+			Mean:      summary.Mean(),
+			Quantiles: quantiles,
+			Labels:    summary.exportLabels(),
+			Updated:   summary.getTimestamp(),
+		}
+	}
+
+	for key, timer := range mc.timers {
+		entries[key] = MetricSnapshotEntry{
+			Name:    timer.fullName(),
+			Type:    MetricTypeTimer,
+			Count:   timer.Count(),
+			Sum:     timer.toUnits(timer.Sum()),
+			Min:     timer.toUnits(timer.Min()),
+			Max:     timer.toUnits(timer.Max()),
+			Mean:    timer.toUnits(timer.Mean()),
+			Unit:    durationUnitName(timer.unit),
+			Labels:  timer.exportLabels(),
+			Updated: timer.getTimestamp(),
+		}
+	}
+
+	return entries
+}
+
+// ParseJSONExport parses data produced by Export(ExportFormatJSON).
+// Returns ErrUnsupportedSchemaVersion if the export was written with a schema
+// version this package does not understand.
+func ParseJSONExport(data []byte) (Snapshot, error) {
+	var header struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse JSON export: %w", err)
+	}
+
+	if header.SchemaVersion == nil {
+		return Snapshot{}, fmt.Errorf("%w: missing schema_version", ErrUnsupportedSchemaVersion)
+	}
+
+	if *header.SchemaVersion != JSONExportSchemaVersion {
+		return Snapshot{}, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, *header.SchemaVersion)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse JSON export: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// snapshot captures the current state of all metrics for export. It returns
+// ctx.Err() if ctx ends before every metric group is captured. Metrics and
+// collectors that panic while being read are left out and recorded as
+// errors, so one faulty metric does not fail the whole export.
+func (mc *metricsCollector) snapshot(ctx context.Context) (Snapshot, error) {
+	var failures []exportFailure
+
+	snapshot, err := mc.readSnapshot(ctx, &failures)
+	mc.recordExportFailures(failures)
+
+	return snapshot, err
+}
+
+// readSnapshot implements snapshot, adding the metrics that panicked to
+// failures. Custom collectors are read after mc.mu is released, since a
+// collector may call back into the collector, e.g. to create a metric.
+func (mc *metricsCollector) readSnapshot(ctx context.Context, failures *[]exportFailure) (Snapshot, error) {
+	snapshot, err := mc.readMetricsSnapshot(ctx, failures)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Snapshot{}, err
+	}
+
+	collectors := mc.activeCollectors()
+	if len(collectors) > 0 {
+		snapshot.Collectors = make(map[string]map[string]any, len(collectors))
+		for _, c := range collectors {
+			renderSafely(failures, c.name, func() {
+				snapshot.Collectors[c.name] = c.collector.Collect()
+			})
+		}
+	}
+
+	return snapshot, nil
+}
+
+// readMetricsSnapshot captures the registered metrics of a snapshot under
+// mc.mu, adding the metrics that panicked to failures.
+func (mc *metricsCollector) readMetricsSnapshot(ctx context.Context, failures *[]exportFailure) (Snapshot, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	snapshot := Snapshot{
+		SchemaVersion: JSONExportSchemaVersion,
+		Collector:     mc.name,
+		Timestamp:     time.Now(),
+		Counters:      make(map[string]ValueSnapshot, len(mc.counters)),
+		Gauges:        make(map[string]ValueSnapshot, len(mc.gauges)),
+		Histograms:    make(map[string]StatsSnapshot, len(mc.histograms)),
+		Summaries:     make(map[string]StatsSnapshot, len(mc.summaries)),
+		Timers:        make(map[string]TimerSnapshot, len(mc.timers)),
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Snapshot{}, err
+	}
+
+	for name, counter := range mc.counters {
+		renderSafely(failures, name, func() {
+			snapshot.Counters[name] = ValueSnapshot{
+				Value:   counter.Value(),
+				Labels:  counter.exportLabels(),
+				Updated: counter.getTimestamp(),
+			}
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Snapshot{}, err
+	}
+
+	for name, gauge := range mc.gauges {
+		renderSafely(failures, name, func() {
+			snapshot.Gauges[name] = ValueSnapshot{
+				Value:   gauge.Value(),
+				Labels:  gauge.exportLabels(),
+				Updated: gauge.getTimestamp(),
+			}
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Snapshot{}, err
+	}
+
+	for name, histogram := range mc.histograms {
+		renderSafely(failures, name, func() {
+			buckets := exportBuckets(histogram)
+
+			snapshot.Histograms[name] = StatsSnapshot{
+				Count:   buckets[len(buckets)-1].Count,
+				Sum:     finiteOrZero(histogram.Sum()),
+				Min:     finiteOrZero(histogram.Min()),
+				Max:     finiteOrZero(histogram.Max()),
+				Mean:    finiteOrZero(histogram.Mean()),
+				Buckets: buckets,
+				Labels:  histogram.exportLabels(),
+				Updated: histogram.getTimestamp(),
+			}
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Snapshot{}, err
+	}
+
+	for name, summary := range mc.summaries {
+		renderSafely(failures, name, func() {
+			quantiles := make(map[string]float64, len(summary.objectives))
+			for q := range summary.objectives {
+				quantiles[strconv.FormatFloat(q, 'f', -1, 64)] = finiteOrZero(summary.Quantile(q))
+			}
+
+			snapshot.Summaries[name] = StatsSnapshot{
+				Count:     summary.Count(),
+				Sum:       finiteOrZero(summary.Sum()),
+				Min:       finiteOrZero(summary.Min()),
+				Max:       finiteOrZero(summary.Max()),
+				Mean:      finiteOrZero(summary.Mean()),
+				Quantiles: quantiles,
+				Labels:    summary.exportLabels(),
+				Updated:   summary.getTimestamp(),
+			}
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Snapshot{}, err
+	}
+
+	for name, timer := range mc.timers {
+		renderSafely(failures, name, func() {
+			snapshot.Timers[name] = TimerSnapshot{
+				Count:   timer.Count(),
+				SumMs:   durationToMs(timer.Sum()),
+				MinMs:   durationToMs(timer.Min()),
+				MaxMs:   durationToMs(timer.Max()),
+				MeanMs:  durationToMs(timer.Mean()),
+				Labels:  timer.exportLabels(),
+				Updated: timer.getTimestamp(),
+			}
+		})
+	}
+
+	return snapshot, nil
+}
+
+// exportFailure is a metric or collector that panicked during an export.
+type exportFailure struct {
+	metric string
+	err    error
+}
+
+// renderSafely calls render, recovering a panic so that the metric is
+// skipped instead of aborting the export. The panic is added to failures.
+func renderSafely(failures *[]exportFailure, metric string, render func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			*failures = append(*failures, exportFailure{metric: metric, err: fmt.Errorf("%w: %v", ErrMetricPanic, r)})
+		}
+	}()
+
+	render()
+}
+
+// recordExportFailures logs the metrics skipped by an export and keeps them
+// for Stats. It must be called without mc.mu held, since exports read under
+// the read lock.
+func (mc *metricsCollector) recordExportFailures(failures []exportFailure) {
+	if len(failures) == 0 {
+		return
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for _, failure := range failures {
+		if mc.logger != nil {
+			mc.logger.Error("metric skipped in export", log.String("metric", failure.metric), log.Error(failure.err))
+		}
+
+		mc.trackError(failure.err)
+	}
+}
+
+// exportJSON encodes the current state of all metrics as JSON.
+func (mc *metricsCollector) exportJSON(ctx context.Context) ([]byte, error) {
+	snapshot, err := mc.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON export: %w", err)
+	}
+
+	return data, nil
+}
+
+// deltaBaseline is the total of a counter or timer reported by the previous
+// ExportDelta call. Timer sums are in milliseconds, like TimerSnapshot.
+type deltaBaseline struct {
+	value float64
+	count uint64
+	sum   float64
+}
+
+// exportDeltaJSON encodes a snapshot whose counters and timers hold the
+// increase since the previous call, then records the new totals as the
+// baseline for the next one.
+//
+// A total lower than its baseline means the metric was reset, so the whole
+// total is reported as the delta. Timer Min and Max stay cumulative because
+// they cannot be derived for an interval; Mean covers the interval only.
+func (mc *metricsCollector) exportDeltaJSON() ([]byte, error) {
+	mc.deltaMu.Lock()
+	defer mc.deltaMu.Unlock()
+
+	snapshot, err := mc.snapshot(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	baselines := make(map[string]deltaBaseline, len(snapshot.Counters)+len(snapshot.Timers))
+
+	for name, counter := range snapshot.Counters {
+		baselines[name] = deltaBaseline{value: counter.Value}
+
+		if prev, ok := mc.deltaBaselines[name]; ok && counter.Value >= prev.value {
+			counter.Value -= prev.value
+		}
+
+		snapshot.Counters[name] = counter
+	}
+
+	for name, timer := range snapshot.Timers {
+		baselines[name] = deltaBaseline{count: timer.Count, sum: timer.SumMs}
+
+		if prev, ok := mc.deltaBaselines[name]; ok && timer.Count >= prev.count {
+			timer.Count -= prev.count
+			timer.SumMs = max(timer.SumMs-prev.sum, 0)
+		}
+
+		timer.MeanMs = 0
+		if timer.Count > 0 {
+			timer.MeanMs = timer.SumMs / float64(timer.Count)
+		}
+
+		snapshot.Timers[name] = timer
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON export: %w", err)
+	}
+
+	mc.deltaBaselines = baselines
+
+	return data, nil
+}
+
+// exportLabels returns the merged const and dynamic labels of a metric,
+// or nil if it has none.
+func (mc *metricCore) exportLabels() map[string]string {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	if len(mc.constLabels) == 0 && len(mc.labels) == 0 {
+		return nil
+	}
+
+	labels := make(map[string]string, len(mc.constLabels)+len(mc.labels))
+	maps.Copy(labels, mc.constLabels)
+	maps.Copy(labels, mc.labels)
+
+	return labels
+}
+
+// exportBuckets returns the buckets of h in ascending order followed by the
+// +Inf bucket. The buckets are read before the count, so an observation
+// recorded in between cannot make the +Inf bucket smaller than the last
+// finite one; the +Inf count is raised to the cumulative count if it would be.
+func exportBuckets(h *histogramImpl) []BucketSnapshot {
+	counts := h.Buckets()
+	bounds := slices.Sorted(maps.Keys(counts))
+	buckets := make([]BucketSnapshot, 0, len(bounds)+1)
+
+	cumulative := uint64(0)
+	for _, bound := range bounds {
+		cumulative += counts[bound]
+		buckets = append(buckets, BucketSnapshot{
+			Le:          formatPrometheusValue(bound),
+			Count:       cumulative,
+			BucketCount: counts[bound],
+		})
+	}
+
+	total := max(h.Count(), cumulative)
+
+	return append(buckets, BucketSnapshot{
+		Le:          formatPrometheusValue(math.Inf(1)),
+		Count:       total,
+		BucketCount: total - cumulative,
+	})
+}
+
+// durationToMs converts a duration to fractional milliseconds.
+func durationToMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// finiteOrZero replaces NaN and infinite values, which JSON cannot encode, with zero.
+func finiteOrZero(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+
+	return v
+}
+
+// writeExportFile writes exported data to filename.
+func writeExportFile(data []byte, filename string) error {
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return nil
+}
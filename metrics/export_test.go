@@ -0,0 +1,396 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollector_ExportJSON(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	collector.Counter("requests_total", WithLabel("method", "GET")).Add(3)
+	collector.Gauge("queue_depth").Set(7)
+	collector.Histogram("payload_size").Observe(42)
+	collector.Summary("latency", WithPercentiles(0.5, 0.99)).Observe(10)
+	collector.Timer("db_query").Record(20 * time.Millisecond)
+
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	var raw map[string]any
+
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.InDelta(t, float64(JSONExportSchemaVersion), raw["schema_version"], 0)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, JSONExportSchemaVersion, snapshot.SchemaVersion)
+	assert.Equal(t, "test", snapshot.Collector)
+	assert.InDelta(t, 3.0, snapshot.Counters["requests_total"].Value, 0)
+	assert.Equal(t, "GET", snapshot.Counters["requests_total"].Labels["method"])
+	assert.InDelta(t, 7.0, snapshot.Gauges["queue_depth"].Value, 0)
+	assert.Equal(t, uint64(1), snapshot.Histograms["payload_size"].Count)
+	assert.InDelta(t, 42.0, snapshot.Histograms["payload_size"].Sum, 0)
+	assert.Contains(t, snapshot.Summaries["latency"].Quantiles, "0.99")
+	assert.Equal(t, uint64(1), snapshot.Timers["db_query"].Count)
+	assert.InDelta(t, 20.0, snapshot.Timers["db_query"].SumMs, 0.001)
+}
+
+func TestMetricsCollector_ExportJSONHistogramBuckets(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	histogram := collector.Histogram("payload_size", WithBuckets(10, 100, 1000))
+	for _, v := range []float64{5, 50, 60, 500, 5000, 7000} {
+		histogram.Observe(v)
+	}
+
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+
+	buckets := snapshot.Histograms["payload_size"].Buckets
+	assert.Equal(t, []BucketSnapshot{
+		{Le: "10", Count: 1, BucketCount: 1},
+		{Le: "100", Count: 3, BucketCount: 2},
+		{Le: "1000", Count: 4, BucketCount: 1},
+		{Le: "+Inf", Count: 6, BucketCount: 2},
+	}, buckets)
+
+	for i := 1; i < len(buckets); i++ {
+		assert.GreaterOrEqual(t, buckets[i].Count, buckets[i-1].Count)
+	}
+
+	assert.Equal(t, histogram.Count(), buckets[len(buckets)-1].Count)
+	assert.Equal(t, histogram.Count(), snapshot.Histograms["payload_size"].Count)
+
+	// The raw counts match Buckets
+	for bound, count := range histogram.Buckets() {
+		i := slices.IndexFunc(buckets, func(b BucketSnapshot) bool { return b.Le == formatPrometheusValue(bound) })
+		require.GreaterOrEqual(t, i, 0)
+		assert.Equal(t, count, buckets[i].BucketCount)
+	}
+
+	// Summaries have no buckets
+	collector.Summary("latency").Observe(1)
+
+	data, err = collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err = ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.Summaries["latency"].Buckets)
+}
+
+func TestMetricsCollector_ExportContext(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Add(3)
+	collector.Histogram("payload_size").Observe(42)
+
+	for _, format := range []ExportFormat{ExportFormatJSON, ExportFormatPrometheus} {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := collector.ExportContext(t.Context(), format)
+			require.NoError(t, err)
+			assert.NotEmpty(t, data)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			cancel()
+
+			data, err = collector.ExportContext(ctx, format)
+			require.ErrorIs(t, err, context.Canceled)
+			assert.Nil(t, data)
+		})
+	}
+}
+
+func TestMetricsCollector_SnapshotCancelled(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Inc()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 0)
+	defer cancel()
+
+	_, err := collector.(*metricsCollector).snapshot(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = collector.(*metricsCollector).exportPrometheus(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// reentrantCollector is a CustomCollector whose Collect creates a metric on
+// the Metrics it is registered with.
+type reentrantCollector struct {
+	metrics Metrics
+}
+
+func (c reentrantCollector) Name() string { return "reentrant" }
+func (c reentrantCollector) Reset() error { return nil }
+
+func (c reentrantCollector) Collect() map[string]any {
+	c.metrics.Counter("collect_calls_total").Inc()
+
+	return map[string]any{"calls": c.metrics.Counter("collect_calls_total").Value()}
+}
+
+func TestMetricsCollector_ExportReentrantCollector(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	require.NoError(t, collector.RegisterCollector(reentrantCollector{metrics: collector}))
+
+	done := make(chan Snapshot, 1)
+
+	go func() {
+		data, err := collector.Export(ExportFormatJSON)
+		assert.NoError(t, err)
+
+		snapshot, err := ParseJSONExport(data)
+		assert.NoError(t, err)
+
+		done <- snapshot
+	}()
+
+	select {
+	case snapshot := <-done:
+		assert.Contains(t, snapshot.Collectors, "reentrant")
+	case <-time.After(time.Second):
+		t.Fatal("export deadlocked on a collector calling back into the collector")
+	}
+}
+
+// panickingCollector is a CustomCollector whose Collect panics.
+type panickingCollector struct{}
+
+func (panickingCollector) Name() string            { return "broken" }
+func (panickingCollector) Collect() map[string]any { panic("collector callback failed") }
+func (panickingCollector) Reset() error            { return nil }
+
+func TestMetricsCollector_ExportSkipsPanickingMetric(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Add(3)
+	collector.Gauge("queue_depth").Set(7)
+
+	require.NoError(t, collector.RegisterCollector(panickingCollector{}))
+
+	// A histogram whose counts no longer match its buckets panics when read
+	broken := collector.Histogram("payload_size", WithBuckets(1, 2, 3)).(*histogramImpl)
+	broken.counts = broken.counts[:1]
+
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, snapshot.Counters["requests_total"].Value, 0)
+	assert.InDelta(t, 7.0, snapshot.Gauges["queue_depth"].Value, 0)
+	assert.NotContains(t, snapshot.Histograms, "payload_size")
+	assert.NotContains(t, snapshot.Collectors, "broken")
+
+	stats := collector.Stats()
+	assert.Equal(t, int64(2), stats.ErrorCount)
+	assert.Contains(t, stats.LastError, ErrMetricPanic.Error())
+
+	data, err = collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	output := string(data)
+	assert.Contains(t, output, "requests_total 3\n")
+	assert.Contains(t, output, "queue_depth 7\n")
+	assert.NotContains(t, output, "payload_size")
+	assert.NotContains(t, output, "broken")
+	assert.Equal(t, int64(4), collector.Stats().ErrorCount)
+
+	// The histogram lock was released despite the panic
+	require.True(t, broken.mu.TryLock())
+	broken.mu.Unlock()
+}
+
+func TestMetricsCollector_Snapshot(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	counter := collector.Counter("requests_total", WithNamespace("api"))
+	gauge := collector.Gauge("queue_depth")
+	histogram := collector.Histogram("payload_size")
+	summary := collector.Summary("latency", WithPercentiles(0.5))
+	timer := collector.Timer("db_query")
+
+	counter.Add(3)
+	gauge.Set(7)
+	histogram.Observe(10)
+	histogram.Observe(30)
+	summary.Observe(5)
+	timer.Record(20 * time.Millisecond)
+
+	snapshot := collector.Snapshot()
+
+	// Mutations after the call must not be reflected in the snapshot
+	counter.Add(100)
+	gauge.Set(0)
+	histogram.Observe(1000)
+	timer.Record(time.Second)
+
+	require.Len(t, snapshot, 5)
+
+	requests := snapshot["api_requests_total"]
+	assert.Equal(t, "api_requests_total", requests.Name)
+	assert.Equal(t, MetricTypeCounter, requests.Type)
+	assert.InDelta(t, 3.0, requests.Value, 0)
+
+	assert.Equal(t, MetricTypeGauge, snapshot["queue_depth"].Type)
+	assert.InDelta(t, 7.0, snapshot["queue_depth"].Value, 0)
+
+	payload := snapshot["payload_size"]
+	assert.Equal(t, MetricTypeHistogram, payload.Type)
+	assert.Equal(t, uint64(2), payload.Count)
+	assert.InDelta(t, 40.0, payload.Sum, 0)
+	assert.InDelta(t, 10.0, payload.Min, 0)
+	assert.InDelta(t, 30.0, payload.Max, 0)
+	assert.InDelta(t, 20.0, payload.Mean, 0)
+
+	latency := snapshot["latency"]
+	assert.Equal(t, MetricTypeSummary, latency.Type)
+	assert.Contains(t, latency.Quantiles, "0.5")
+
+	query := snapshot["db_query"]
+	assert.Equal(t, MetricTypeTimer, query.Type)
+	assert.Equal(t, uint64(1), query.Count)
+	assert.InDelta(t, 20.0, query.Sum, 0.001)
+
+	// A second snapshot reflects the mutations
+	later := collector.Snapshot()
+	assert.InDelta(t, 103.0, later["api_requests_total"].Value, 0)
+	assert.InDelta(t, 0.0, later["queue_depth"].Value, 0)
+	assert.Equal(t, uint64(3), later["payload_size"].Count)
+	assert.Equal(t, uint64(2), later["db_query"].Count)
+}
+
+func TestMetricsCollector_ExportDelta(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	counter := collector.Counter("requests_total")
+	gauge := collector.Gauge("queue_depth")
+	timer := collector.Timer("db_query")
+
+	counter.Add(10)
+	gauge.Set(5)
+	timer.Record(10 * time.Millisecond)
+	timer.Record(30 * time.Millisecond)
+
+	first := exportDelta(t, collector)
+	assert.InDelta(t, 10.0, first.Counters["requests_total"].Value, 0)
+	assert.Equal(t, uint64(2), first.Timers["db_query"].Count)
+	assert.InDelta(t, 40.0, first.Timers["db_query"].SumMs, 0.001)
+
+	counter.Add(3)
+	gauge.Set(8)
+	timer.Record(50 * time.Millisecond)
+
+	second := exportDelta(t, collector)
+	assert.InDelta(t, 3.0, second.Counters["requests_total"].Value, 0)
+	assert.InDelta(t, 8.0, second.Gauges["queue_depth"].Value, 0, "gauges stay absolute")
+	assert.Equal(t, uint64(1), second.Timers["db_query"].Count)
+	assert.InDelta(t, 50.0, second.Timers["db_query"].SumMs, 0.001)
+	assert.InDelta(t, 50.0, second.Timers["db_query"].MeanMs, 0.001)
+
+	// Export is unaffected by delta tracking
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	cumulative, err := ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.InDelta(t, 13.0, cumulative.Counters["requests_total"].Value, 0)
+
+	third := exportDelta(t, collector)
+	assert.InDelta(t, 0.0, third.Counters["requests_total"].Value, 0)
+	assert.Equal(t, uint64(0), third.Timers["db_query"].Count)
+	assert.InDelta(t, 0.0, third.Timers["db_query"].MeanMs, 0)
+}
+
+func TestMetricsCollector_ExportDeltaAfterReset(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	counter := collector.Counter("requests_total")
+	counter.Add(10)
+	exportDelta(t, collector)
+
+	require.NoError(t, collector.Reset())
+	counter.Add(4)
+
+	snapshot := exportDelta(t, collector)
+	assert.InDelta(t, 4.0, snapshot.Counters["requests_total"].Value, 0)
+}
+
+func TestMetricsCollector_ExportDeltaUnsupportedFormat(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	_, err := collector.ExportDelta(ExportFormatPrometheus)
+	assert.ErrorIs(t, err, ErrDeltaExportUnsupported)
+}
+
+func exportDelta(t *testing.T, collector Metrics) Snapshot {
+	t.Helper()
+
+	data, err := collector.ExportDelta(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+
+	return snapshot
+}
+
+func TestParseJSONExport_UnsupportedVersion(t *testing.T) {
+	_, err := ParseJSONExport([]byte(`{"schema_version": 999}`))
+	require.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+
+	_, err = ParseJSONExport([]byte(`{"counters": {}}`))
+	require.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+
+	_, err = ParseJSONExport([]byte(`not json`))
+	require.Error(t, err)
+}
+
+func TestMetricsCollector_ExportToFile(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Inc()
+
+	filename := filepath.Join(t.TempDir(), "metrics.json")
+	require.NoError(t, collector.ExportToFile(ExportFormatJSON, filename))
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, snapshot.Counters["requests_total"].Value, 0)
+}
+
+func TestMetricsCollector_SnapshotSummaryVariants(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	summary := collector.Summary("lat")
+	summary.Observe(1)
+	summary.WithLabels(map[string]string{"r": "a"}).Observe(2)
+	summary.WithLabels(map[string]string{"r": "b"}).Observe(3)
+
+	snapshot := collector.Snapshot()
+
+	// Every label variant gets its own entry
+	require.Len(t, snapshot, 3)
+
+	sums := make(map[string]float64, len(snapshot))
+	for _, entry := range snapshot {
+		assert.Equal(t, "lat", entry.Name)
+		sums[entry.Labels["r"]] = entry.Sum
+	}
+
+	assert.Equal(t, map[string]float64{"": 1, "a": 2, "b": 3}, sums)
+}
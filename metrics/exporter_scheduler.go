@@ -0,0 +1,240 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/xraph/go-utils/log"
+)
+
+// =============================================================================
+// EXPORTER SCHEDULER
+// =============================================================================
+
+// DefaultExportInterval is the interval scheduled exporters run at when their
+// MetricsExporterConfig sets none.
+const DefaultExportInterval = 10 * time.Second
+
+// StartExporters runs every exporter registered with WithExporter whose
+// MetricsExporterConfig in the collector's config is Enabled, exporting
+// ListMetrics at the configured Interval until ctx ends or the collector is
+// stopped or closed. The config is read once, when StartExporters is called.
+//
+// Each exporter has at most one export in flight. A tick that finds the
+// previous export still running is dropped and counted in the exporter's
+// DroppedExportCount, so a slow exporter falls behind instead of piling up
+// exports. Timing, bytes and errors are recorded in the ExporterStats
+// reported under the exporter's name in CollectorStats.ExporterStats.
+//
+// An enabled exporter config without a registered exporter is an error and
+// nothing is started. Calling StartExporters again fails with
+// ErrExportersStarted until Stop is called, even after ctx ends.
+func (mc *metricsCollector) StartExporters(ctx context.Context) error {
+	if mc.closed.Load() {
+		return ErrMetricsClosed
+	}
+
+	mc.mu.RLock()
+	config := mc.config
+	mc.mu.RUnlock()
+
+	intervals := make(map[string]time.Duration)
+
+	if config != nil {
+		for _, name := range slices.Sorted(maps.Keys(config.Exporters)) {
+			exporterConfig := config.Exporters[name]
+			if !exporterConfig.Enabled {
+				continue
+			}
+
+			if _, ok := mc.exporters[name]; !ok {
+				return fmt.Errorf("%w: %s", ErrExporterNotFound, name)
+			}
+
+			intervals[name] = exporterConfig.Interval
+			if intervals[name] <= 0 {
+				intervals[name] = DefaultExportInterval
+			}
+		}
+	}
+
+	mc.exportMu.Lock()
+	defer mc.exportMu.Unlock()
+
+	if mc.exportCancel != nil {
+		return ErrExportersStarted
+	}
+
+	ctx, mc.exportCancel = context.WithCancel(ctx)
+
+	for name, interval := range intervals {
+		mc.exportWG.Go(func() {
+			mc.runExporter(ctx, name, interval)
+		})
+	}
+
+	return nil
+}
+
+// stopExporters stops the exporter scheduler and waits for in-flight exports
+// until ctx ends. Cancelling the scheduler also cancels the context passed to
+// ContextExporter exports, so only exporters ignoring it can outlive ctx.
+func (mc *metricsCollector) stopExporters(ctx context.Context) error {
+	mc.exportMu.Lock()
+	cancel := mc.exportCancel
+	mc.exportCancel = nil
+	mc.exportMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		mc.exportWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stop exporters: %w", ctx.Err())
+	}
+}
+
+// runExporter exports to the named exporter on every tick until ctx ends,
+// dropping ticks while an export is in flight.
+func (mc *metricsCollector) runExporter(ctx context.Context, name string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var busy atomic.Bool
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !busy.CompareAndSwap(false, true) {
+				mc.updateExporterStats(name, func(s *ExporterStats) {
+					s.DroppedExportCount++
+				})
+
+				continue
+			}
+
+			mc.exportWG.Go(func() {
+				defer busy.Store(false)
+
+				mc.runExport(ctx, name, mc.ListMetrics())
+			})
+		}
+	}
+}
+
+// runExport exports metrics to the named exporter and records the outcome.
+// Exporters implementing ContextExporter are bounded by ctx.
+func (mc *metricsCollector) runExport(ctx context.Context, name string, metrics map[string]any) error {
+	start := time.Now()
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if exporter, ok := mc.exporters[name].(ContextExporter); ok {
+		data, err = exporter.ExportContext(ctx, metrics)
+	} else {
+		data, err = mc.exporters[name].Export(metrics)
+	}
+
+	mc.updateExporterStats(name, func(s *ExporterStats) {
+		recordExport(s, start, len(data), err)
+	})
+
+	if err != nil && mc.logger != nil {
+		mc.logger.Warn("metrics export failed", log.String("exporter", name), log.Error(err))
+	}
+
+	return err
+}
+
+// updateExporterStats applies fn to the scheduler statistics of the named
+// exporter.
+func (mc *metricsCollector) updateExporterStats(name string, fn func(s *ExporterStats)) {
+	mc.exportMu.Lock()
+	defer mc.exportMu.Unlock()
+
+	stats, ok := mc.exportStats[name]
+	if !ok {
+		stats = &ExporterStats{Format: mc.exporters[name].Format()}
+		mc.exportStats[name] = stats
+	}
+
+	fn(stats)
+}
+
+// exporterStats returns the statistics of every registered exporter, as
+// recorded by the collector, keyed by name.
+func (mc *metricsCollector) exporterStats() map[string]any {
+	if len(mc.exporters) == 0 {
+		return nil
+	}
+
+	mc.exportMu.Lock()
+	defer mc.exportMu.Unlock()
+
+	stats := make(map[string]any, len(mc.exporters))
+
+	for name, exporter := range mc.exporters {
+		if s, ok := mc.exportStats[name]; ok {
+			stats[name] = *s
+		} else {
+			stats[name] = ExporterStats{Format: exporter.Format()}
+		}
+	}
+
+	return stats
+}
+
+// recordExport updates s after an export attempt that started at start and,
+// on success, produced size bytes.
+func recordExport(s *ExporterStats, start time.Time, size int, err error) {
+	duration := time.Since(start)
+
+	s.ExportCount++
+	s.LastExportTime = start
+	s.LastExportDuration = duration
+	s.TotalExportDuration += duration
+	s.AverageExportDuration = s.TotalExportDuration / time.Duration(s.ExportCount)
+	s.MaxExportDuration = max(s.MaxExportDuration, duration)
+
+	if err != nil {
+		s.ErrorCount++
+		s.ConsecutiveErrors++
+		s.LastError = err.Error()
+		s.LastErrorTime = start
+	} else {
+		s.SuccessCount++
+		s.ConsecutiveErrors = 0
+		s.LastSuccessTime = start
+
+		n := int64(size)
+		if s.SuccessCount == 1 {
+			s.MinBytesExported = n
+		}
+
+		s.BytesExported += n
+		s.MinBytesExported = min(s.MinBytesExported, n)
+		s.MaxBytesExported = max(s.MaxBytesExported, n)
+		s.AverageBytesPerExport = float64(s.BytesExported) / float64(s.SuccessCount)
+	}
+
+	s.SuccessRate = float64(s.SuccessCount) / float64(s.ExportCount)
+}
@@ -0,0 +1,238 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scheduledExporter counts exports, optionally blocking each one until
+// release is closed.
+type scheduledExporter struct {
+	calls   atomic.Int64
+	release chan struct{}
+	err     error
+
+	mu   sync.Mutex
+	last map[string]any
+}
+
+func (e *scheduledExporter) Export(metrics map[string]any) ([]byte, error) {
+	e.calls.Add(1)
+
+	e.mu.Lock()
+	e.last = metrics
+	e.mu.Unlock()
+
+	if e.release != nil {
+		<-e.release
+	}
+
+	return []byte("payload"), e.err
+}
+
+func (e *scheduledExporter) Format() string       { return "scheduled" }
+func (e *scheduledExporter) Stats() ExporterStats { return ExporterStats{Format: "scheduled"} }
+
+func schedulerConfig(interval time.Duration, names ...string) *MetricsConfig {
+	exporters := make(map[string]MetricsExporterConfig[map[string]any])
+	for _, name := range names {
+		exporters[name] = MetricsExporterConfig[map[string]any]{Enabled: true, Interval: interval}
+	}
+
+	return &MetricsConfig{Exporters: exporters}
+}
+
+func schedulerStats(t *testing.T, m Metrics, name string) ExporterStats {
+	t.Helper()
+
+	stats, ok := m.Stats().ExporterStats[name].(ExporterStats)
+	require.True(t, ok, "no stats for exporter %s", name)
+
+	return stats
+}
+
+func TestMetricsCollector_StartExporters(t *testing.T) {
+	exporter := &scheduledExporter{}
+	idle := &scheduledExporter{}
+
+	config := schedulerConfig(10*time.Millisecond, "periodic")
+	config.Exporters["idle"] = MetricsExporterConfig[map[string]any]{Enabled: false, Interval: time.Millisecond}
+
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(config),
+		WithExporter("periodic", exporter),
+		WithExporter("idle", idle),
+	)
+	collector.Counter("jobs_total").Inc()
+
+	require.NoError(t, collector.StartExporters(t.Context()))
+	t.Cleanup(func() { _ = collector.Stop(context.Background()) })
+
+	assert.Eventually(t, func() bool { return exporter.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	exporter.mu.Lock()
+	assert.Contains(t, exporter.last, "jobs_total")
+	exporter.mu.Unlock()
+
+	require.NoError(t, collector.Stop(context.Background()))
+
+	stats := schedulerStats(t, collector, "periodic")
+	assert.Equal(t, "scheduled", stats.Format)
+	assert.GreaterOrEqual(t, stats.ExportCount, int64(3))
+	assert.Equal(t, stats.ExportCount, stats.SuccessCount)
+	assert.Equal(t, stats.SuccessCount*int64(len("payload")), stats.BytesExported)
+	assert.InDelta(t, 1, stats.SuccessRate, 0)
+	assert.False(t, stats.LastSuccessTime.IsZero())
+
+	// Disabled exporters are not scheduled but still report stats
+	assert.Zero(t, idle.calls.Load())
+	assert.Zero(t, schedulerStats(t, collector, "idle").ExportCount)
+
+	// Stop halts the scheduler
+	calls := exporter.calls.Load()
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, calls, exporter.calls.Load())
+}
+
+func TestMetricsCollector_StartExportersErrors(t *testing.T) {
+	exportErr := errors.New("endpoint unreachable")
+	exporter := &scheduledExporter{err: exportErr}
+
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(schedulerConfig(5*time.Millisecond, "failing")),
+		WithExporter("failing", exporter),
+	)
+
+	require.NoError(t, collector.StartExporters(t.Context()))
+	t.Cleanup(func() { _ = collector.Stop(context.Background()) })
+
+	assert.Eventually(t, func() bool {
+		return schedulerStats(t, collector, "failing").ErrorCount >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	stats := schedulerStats(t, collector, "failing")
+	assert.Equal(t, exportErr.Error(), stats.LastError)
+	assert.GreaterOrEqual(t, stats.ConsecutiveErrors, int64(2))
+	assert.Zero(t, stats.BytesExported)
+
+	// Starting twice fails
+	require.ErrorIs(t, collector.StartExporters(t.Context()), ErrExportersStarted)
+}
+
+func TestMetricsCollector_StartExportersNotRegistered(t *testing.T) {
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(schedulerConfig(time.Millisecond, "missing")),
+	)
+
+	err := collector.StartExporters(t.Context())
+	require.ErrorIs(t, err, ErrExporterNotFound)
+	assert.Contains(t, err.Error(), "missing")
+
+	require.NoError(t, collector.Close(context.Background()))
+	require.ErrorIs(t, collector.StartExporters(t.Context()), ErrMetricsClosed)
+}
+
+func TestMetricsCollector_StartExportersBackpressure(t *testing.T) {
+	exporter := &scheduledExporter{release: make(chan struct{})}
+
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(schedulerConfig(5*time.Millisecond, "slow")),
+		WithExporter("slow", exporter),
+	)
+
+	require.NoError(t, collector.StartExporters(t.Context()))
+
+	assert.Eventually(t, func() bool {
+		return schedulerStats(t, collector, "slow").DroppedExportCount >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	// Only the first export ran while it was blocked
+	assert.Equal(t, int64(1), exporter.calls.Load())
+
+	close(exporter.release)
+	require.NoError(t, collector.Stop(context.Background()))
+
+	stats := schedulerStats(t, collector, "slow")
+	assert.GreaterOrEqual(t, stats.ExportCount, int64(1))
+	assert.GreaterOrEqual(t, stats.DroppedExportCount, int64(3))
+}
+
+func TestMetricsCollector_StartExportersContext(t *testing.T) {
+	exporter := &scheduledExporter{}
+
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(schedulerConfig(5*time.Millisecond, "periodic")),
+		WithExporter("periodic", exporter),
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	require.NoError(t, collector.StartExporters(ctx))
+
+	assert.Eventually(t, func() bool { return exporter.calls.Load() >= 1 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	// Close waits for the scheduler and records the final export
+	require.NoError(t, collector.Close(context.Background()))
+
+	calls := exporter.calls.Load()
+	assert.Equal(t, calls, schedulerStats(t, collector, "periodic").ExportCount)
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, calls, exporter.calls.Load())
+}
+
+func TestMetricsCollector_StopExportersBounded(t *testing.T) {
+	exporter := &scheduledExporter{release: make(chan struct{})}
+	defer close(exporter.release)
+
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(schedulerConfig(5*time.Millisecond, "hung")),
+		WithExporter("hung", exporter),
+	)
+
+	require.NoError(t, collector.StartExporters(t.Context()))
+	assert.Eventually(t, func() bool { return exporter.calls.Load() >= 1 }, time.Second, 5*time.Millisecond)
+
+	// A hung export does not keep Stop waiting past its context
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, collector.Stop(ctx), context.DeadlineExceeded)
+}
+
+// cancellableExporter blocks every export until its context ends.
+type cancellableExporter struct {
+	scheduledExporter
+}
+
+func (e *cancellableExporter) ExportContext(ctx context.Context, metrics map[string]any) ([]byte, error) {
+	e.calls.Add(1)
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestMetricsCollector_StopCancelsContextExporter(t *testing.T) {
+	exporter := &cancellableExporter{}
+
+	collector := NewMetricsCollector("scheduler",
+		WithConfig(schedulerConfig(5*time.Millisecond, "remote")),
+		WithExporter("remote", exporter),
+	)
+
+	require.NoError(t, collector.StartExporters(t.Context()))
+	assert.Eventually(t, func() bool { return exporter.calls.Load() >= 1 }, time.Second, 5*time.Millisecond)
+
+	// Stopping cancels the in-flight export instead of waiting it out
+	require.NoError(t, collector.Stop(context.Background()))
+	assert.Equal(t, int64(1), schedulerStats(t, collector, "remote").ErrorCount)
+}
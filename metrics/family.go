@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// =============================================================================
+// METRIC FAMILIES
+// =============================================================================
+
+// MetricFamily is a metric together with all of its label variants, grouped
+// by fully qualified name and type. Metadata describes the family; its
+// Labels are left empty since they differ per variant.
+type MetricFamily struct {
+	Name     string          `json:"name"`
+	Type     MetricType      `json:"type"`
+	Metadata MetricMetadata  `json:"metadata"`
+	Variants []MetricVariant `json:"variants"`
+}
+
+// MetricVariant is one series of a MetricFamily. Labels are the variant's
+// dynamic labels; const labels are in the family's Metadata. Metric is the
+// Counter, Gauge, Histogram, Summary or Timer of the series.
+type MetricVariant struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Metric any               `json:"-"`
+}
+
+// familyKey identifies a family. Metrics of different types sharing a name
+// form separate families, as they do in exports.
+type familyKey struct {
+	name       string
+	metricType MetricType
+}
+
+// ListFamilies returns every registered metric grouped into families, e.g.
+// one family for http_requests_total with a variant per method and status.
+// Families are sorted by name and type, and variants by labels, the variant
+// without labels first.
+func (mc *metricsCollector) ListFamilies() []MetricFamily {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	families := make(map[familyKey]*MetricFamily)
+
+	addFamilies(families, mc.counters)
+	addFamilies(families, mc.gauges)
+	addFamilies(families, mc.histograms)
+	addFamilies(families, mc.summaries)
+	addFamilies(families, mc.timers)
+
+	result := make([]MetricFamily, 0, len(families))
+
+	for _, family := range families {
+		slices.SortFunc(family.Variants, func(a, b MetricVariant) int {
+			return cmp.Compare(TagsToString(a.Labels), TagsToString(b.Labels))
+		})
+
+		result = append(result, *family)
+	}
+
+	slices.SortFunc(result, func(a, b MetricFamily) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Type, b.Type))
+	})
+
+	return result
+}
+
+// addFamilies adds the metrics of one registry map to their families. Must
+// be called with mc.mu held.
+func addFamilies[M interface{ core() *metricCore }](families map[familyKey]*MetricFamily, metrics map[string]M) {
+	for _, metric := range metrics {
+		metadata := metric.core().describe()
+		key := familyKey{name: metadata.Name, metricType: metadata.Type}
+
+		family, ok := families[key]
+		if !ok {
+			family = &MetricFamily{Name: metadata.Name, Type: metadata.Type, Metadata: metadata}
+			family.Metadata.Labels = nil
+			families[key] = family
+		}
+
+		family.Variants = append(family.Variants, MetricVariant{
+			Labels: maps.Clone(metadata.Labels),
+			Metric: metric,
+		})
+	}
+}
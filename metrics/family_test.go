@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollector_ListFamilies(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	requests := collector.Counter("requests_total",
+		WithNamespace("api"),
+		WithDescription("Handled requests"),
+		WithConstLabels(map[string]string{"service": "users"}),
+	)
+	requests.Inc()
+	requests.WithLabels(map[string]string{"method": "POST"}).Add(2)
+	requests.WithLabels(map[string]string{"method": "GET"}).Add(5)
+
+	collector.Gauge("queue_depth").Set(3)
+
+	// A histogram sharing the counter's name is a separate family
+	collector.Histogram("requests_total", WithNamespace("api")).Observe(1)
+
+	families := collector.ListFamilies()
+	require.Len(t, families, 3)
+
+	counters := families[0]
+	assert.Equal(t, "api_requests_total", counters.Name)
+	assert.Equal(t, MetricTypeCounter, counters.Type)
+	assert.Equal(t, "Handled requests", counters.Metadata.Description)
+	assert.Equal(t, map[string]string{"service": "users"}, counters.Metadata.ConstLabels)
+	assert.Empty(t, counters.Metadata.Labels)
+
+	require.Len(t, counters.Variants, 3)
+	assert.Empty(t, counters.Variants[0].Labels)
+	assert.Same(t, requests, counters.Variants[0].Metric)
+	assert.Equal(t, map[string]string{"method": "GET"}, counters.Variants[1].Labels)
+	assert.Equal(t, map[string]string{"method": "POST"}, counters.Variants[2].Labels)
+
+	values := make([]float64, 0, len(counters.Variants))
+	for _, variant := range counters.Variants {
+		counter, ok := variant.Metric.(Counter)
+		require.True(t, ok)
+
+		values = append(values, counter.Value())
+	}
+
+	assert.Equal(t, []float64{1, 5, 2}, values)
+
+	assert.Equal(t, "api_requests_total", families[1].Name)
+	assert.Equal(t, MetricTypeHistogram, families[1].Type)
+	assert.Len(t, families[1].Variants, 1)
+
+	assert.Equal(t, "queue_depth", families[2].Name)
+	assert.Equal(t, MetricTypeGauge, families[2].Type)
+}
+
+func TestMetricsCollector_ListFamiliesEmpty(t *testing.T) {
+	assert.Empty(t, NewMetricsCollector("test").ListFamilies())
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"maps"
+	"slices"
 	"time"
 
 	"encoding/json"
@@ -684,6 +685,110 @@ func (a *HealthReportAnalyzer) Summary() map[string]any {
 	}
 }
 
+// HealthReportDiff describes how the health of checks changed between two
+// reports. Check names are sorted.
+type HealthReportDiff struct {
+	// PreviousOverall and CurrentOverall are the overall statuses of the two
+	// reports; PreviousOverall is empty when there was no previous report.
+	PreviousOverall HealthStatus `json:"previous_overall"`
+	CurrentOverall  HealthStatus `json:"current_overall"`
+
+	// NewlyFailed lists checks that are unhealthy now but were not,
+	// including new checks that are unhealthy.
+	NewlyFailed []string `json:"newly_failed,omitempty"`
+
+	// Recovered lists checks that are healthy now but were not.
+	Recovered []string `json:"recovered,omitempty"`
+
+	// Changed lists every check present in both reports whose status
+	// changed, including those in NewlyFailed and Recovered.
+	Changed []HealthStatusChange `json:"changed,omitempty"`
+
+	// Added and Removed list checks present in only one of the reports.
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// HealthStatusChange is the status transition of a single check.
+type HealthStatusChange struct {
+	Name string       `json:"name"`
+	From HealthStatus `json:"from"`
+	To   HealthStatus `json:"to"`
+}
+
+// OverallChanged reports whether the overall status changed.
+func (d HealthReportDiff) OverallChanged() bool {
+	return d.PreviousOverall != d.CurrentOverall
+}
+
+// HasChanges reports whether the overall status or any check changed, or
+// checks were added or removed.
+func (d HealthReportDiff) HasChanges() bool {
+	return d.OverallChanged() || len(d.Changed) > 0 || len(d.Added) > 0 || len(d.Removed) > 0
+}
+
+// Diff compares the analyzed report with prev, an earlier report, e.g. to
+// alert when a check goes unhealthy:
+//
+//	diff := NewHealthReportAnalyzer(current).Diff(previous)
+//	for _, name := range diff.NewlyFailed {
+//	    alert(name + " is unhealthy")
+//	}
+//
+// A nil prev is treated as a report without checks, so every check is added.
+func (a *HealthReportAnalyzer) Diff(prev *HealthReport) HealthReportDiff {
+	diff := HealthReportDiff{CurrentOverall: a.report.Overall}
+
+	var previous map[string]*HealthResult
+	if prev != nil {
+		diff.PreviousOverall = prev.Overall
+		previous = prev.Services
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(a.report.Services)) {
+		current := a.report.Services[name]
+		if current == nil {
+			continue
+		}
+
+		before, existed := previous[name]
+		if !existed || before == nil {
+			diff.Added = append(diff.Added, name)
+
+			if current.IsUnhealthy() {
+				diff.NewlyFailed = append(diff.NewlyFailed, name)
+			}
+
+			continue
+		}
+
+		if before.Status == current.Status {
+			continue
+		}
+
+		diff.Changed = append(diff.Changed, HealthStatusChange{Name: name, From: before.Status, To: current.Status})
+
+		switch {
+		case current.IsUnhealthy():
+			diff.NewlyFailed = append(diff.NewlyFailed, name)
+		case current.IsHealthy():
+			diff.Recovered = append(diff.Recovered, name)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(previous)) {
+		if previous[name] == nil {
+			continue
+		}
+
+		if current, ok := a.report.Services[name]; !ok || current == nil {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	return diff
+}
+
 // HealthCallback is a callback function for health status changes.
 type HealthCallback func(result *HealthResult)
 
@@ -745,9 +850,21 @@ type HealthConfig struct {
 	Endpoints        HealthEndpoints   `json:"endpoints"         yaml:"endpoints"`
 	Performance      HealthPerformance `json:"performance"       yaml:"performance"`
 	CriticalServices []string          `json:"critical_services" yaml:"critical_services"`
-	Tags             map[string]string `json:"tags"              yaml:"tags"`
-	Version          string            `json:"version"           yaml:"version"`
-	Environment      string            `json:"environment"       yaml:"environment"`
-	AutoRegister     bool              `json:"auto_register"     yaml:"auto_register"`
-	ExposeEndpoints  bool              `json:"expose_endpoints"  yaml:"expose_endpoints"`
+
+	// SensitiveDetailKeys lists glob patterns of detail keys whose values are
+	// masked in public health output; DefaultSensitiveDetailKeys is used when
+	// it is empty.
+	SensitiveDetailKeys []string `json:"sensitive_detail_keys" yaml:"sensitive_detail_keys"`
+
+	Tags            map[string]string `json:"tags"             yaml:"tags"`
+	Version         string            `json:"version"          yaml:"version"`
+	Environment     string            `json:"environment"      yaml:"environment"`
+	AutoRegister    bool              `json:"auto_register"    yaml:"auto_register"`
+	ExposeEndpoints bool              `json:"expose_endpoints" yaml:"expose_endpoints"`
+
+	// PersistencePath is the file the last report is written to in the
+	// background after each check, sanitized like public output and readable
+	// only by its owner, and loaded from on start when Features.Persistence
+	// is set. Persistence is disabled when it is empty.
+	PersistencePath string `json:"persistence_path" yaml:"persistence_path"`
 }
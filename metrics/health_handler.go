@@ -0,0 +1,236 @@
+package metrics
+
+import (
+	"maps"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// =============================================================================
+// HEALTH REPORT SANITIZATION
+// =============================================================================
+
+// HealthDetailMask replaces the values of sensitive health details.
+const HealthDetailMask = "[REDACTED]"
+
+// DefaultSensitiveDetailKeys are the detail key patterns masked when no
+// patterns are configured. Patterns use path.Match syntax and are matched
+// case-insensitively against detail keys.
+var DefaultSensitiveDetailKeys = []string{
+	"*password*",
+	"*passwd*",
+	"*secret*",
+	"*token*",
+	"*credential*",
+	"*api_key*",
+	"*apikey*",
+	"*dsn*",
+	"*connection_string*",
+	"*url*",
+	"*uri*",
+	"*host*",
+	"*addr*",
+}
+
+// Sanitized returns a copy of the report that is safe to serve publicly.
+// Detail and metadata values whose keys match one of patterns are replaced
+// by HealthDetailMask, including in nested maps and slices; the hostname is
+// masked when "hostname" matches. Check errors, which often embed connection
+// strings or addresses, are replaced by HealthDetailMask. In check messages,
+// URLs and key=value pairs whose key matches one of patterns are masked.
+// DefaultSensitiveDetailKeys is used when no patterns are given. The
+// original report is not modified.
+func (hr *HealthReport) Sanitized(patterns ...string) *HealthReport {
+	if len(patterns) == 0 {
+		patterns = DefaultSensitiveDetailKeys
+	}
+
+	sanitized := *hr
+	sanitized.Metadata = sanitizeDetails(hr.Metadata, patterns)
+
+	if sanitized.Hostname != "" && isSensitiveDetailKey("hostname", patterns) {
+		sanitized.Hostname = HealthDetailMask
+	}
+
+	if hr.Services != nil {
+		sanitized.Services = make(map[string]*HealthResult, len(hr.Services))
+		for name, result := range hr.Services {
+			if result == nil {
+				sanitized.Services[name] = nil
+
+				continue
+			}
+
+			clone := *result
+			clone.Message = sanitizeMessage(result.Message, patterns)
+			clone.Details = sanitizeDetails(result.Details, patterns)
+			clone.Tags = maps.Clone(result.Tags)
+
+			if clone.Error != "" {
+				clone.Error = HealthDetailMask
+			}
+
+			sanitized.Services[name] = &clone
+		}
+	}
+
+	return &sanitized
+}
+
+// sanitizeDetails returns a copy of details with sensitive values masked.
+func sanitizeDetails(details map[string]any, patterns []string) map[string]any {
+	if details == nil {
+		return nil
+	}
+
+	sanitized := make(map[string]any, len(details))
+
+	for key, value := range details {
+		if isSensitiveDetailKey(key, patterns) {
+			sanitized[key] = HealthDetailMask
+
+			continue
+		}
+
+		sanitized[key] = sanitizeDetailValue(value, patterns)
+	}
+
+	return sanitized
+}
+
+// sanitizeDetailValue returns a copy of a detail value with sensitive values
+// in nested maps and slices masked. Other values are returned as is.
+func sanitizeDetailValue(value any, patterns []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return sanitizeDetails(v, patterns)
+	case map[string]string:
+		sanitized := make(map[string]string, len(v))
+		for key, value := range v {
+			if isSensitiveDetailKey(key, patterns) {
+				value = HealthDetailMask
+			}
+
+			sanitized[key] = value
+		}
+
+		return sanitized
+	case []any:
+		sanitized := make([]any, len(v))
+		for i, value := range v {
+			sanitized[i] = sanitizeDetailValue(value, patterns)
+		}
+
+		return sanitized
+	case []map[string]any:
+		sanitized := make([]map[string]any, len(v))
+		for i, value := range v {
+			sanitized[i] = sanitizeDetails(value, patterns)
+		}
+
+		return sanitized
+	default:
+		return value
+	}
+}
+
+var (
+	// healthMessageURL matches URLs, which may carry credentials and hosts.
+	healthMessageURL = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s"']+`)
+
+	// healthMessagePair matches key=value pairs such as those of a DSN.
+	healthMessagePair = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_.-]*)=("[^"]*"|'[^']*'|[^\s,;]+)`)
+)
+
+// sanitizeMessage masks the URLs in a check message and the values of
+// key=value pairs whose key matches one of patterns.
+func sanitizeMessage(message string, patterns []string) string {
+	if message == "" {
+		return message
+	}
+
+	message = healthMessageURL.ReplaceAllString(message, HealthDetailMask)
+
+	return healthMessagePair.ReplaceAllStringFunc(message, func(pair string) string {
+		key, _, _ := strings.Cut(pair, "=")
+		if !isSensitiveDetailKey(key, patterns) {
+			return pair
+		}
+
+		return key + "=" + HealthDetailMask
+	})
+}
+
+// isSensitiveDetailKey reports whether key matches any of patterns.
+func isSensitiveDetailKey(key string, patterns []string) bool {
+	key = strings.ToLower(key)
+
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.ToLower(pattern), key); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// =============================================================================
+// HEALTH ENDPOINT
+// =============================================================================
+
+// HealthHandlerOption configures a handler created by HealthHandler.
+type HealthHandlerOption func(*healthHandlerOptions)
+
+type healthHandlerOptions struct {
+	fullDetails   bool
+	sensitiveKeys []string
+}
+
+// WithFullHealthDetails serves the report without sanitization. Only use it
+// for endpoints that are protected by authentication.
+func WithFullHealthDetails() HealthHandlerOption {
+	return func(o *healthHandlerOptions) {
+		o.fullDetails = true
+	}
+}
+
+// WithSensitiveDetailKeys sets the detail key patterns masked by the handler,
+// typically HealthConfig.SensitiveDetailKeys.
+func WithSensitiveDetailKeys(patterns ...string) HealthHandlerOption {
+	return func(o *healthHandlerOptions) {
+		o.sensitiveKeys = patterns
+	}
+}
+
+// HealthHandler returns an HTTP handler that serves the health report of hm as
+// JSON. It serves the last report, running the checks if none exists yet, and
+// responds with 503 when the overall status is unhealthy. Sensitive details
+// are masked unless WithFullHealthDetails is given.
+func HealthHandler(hm HealthManager, opts ...HealthHandlerOption) http.Handler {
+	options := &healthHandlerOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := hm.LastReport()
+		if report == nil {
+			report = hm.Check(r.Context())
+		}
+
+		if !options.fullDetails {
+			report = report.Sanitized(options.sensitiveKeys...)
+		}
+
+		status := http.StatusOK
+		if report.IsUnhealthy() {
+			status = http.StatusServiceUnavailable
+		}
+
+		if err := writeIndentedJSON(w, status, report); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
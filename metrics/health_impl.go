@@ -0,0 +1,797 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xraph/go-utils/log"
+)
+
+// DefaultHealthCheckTimeout is the timeout applied to health checks that do not
+// specify one when HealthConfig.Performance.DefaultTimeout is not set.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// =============================================================================
+// HEALTH MANAGER OPTIONS
+// =============================================================================
+
+// HealthOption is a functional option for configuring a health manager.
+type HealthOption func(*HealthOptions)
+
+// HealthOptions holds configuration for a health manager.
+type HealthOptions struct {
+	Name   string
+	Config *HealthConfig
+	Logger log.Logger
+}
+
+// WithHealthName sets the name of the health manager.
+func WithHealthName(name string) HealthOption {
+	return func(opts *HealthOptions) {
+		opts.Name = name
+	}
+}
+
+// WithHealthConfig sets the health configuration.
+func WithHealthConfig(config *HealthConfig) HealthOption {
+	return func(opts *HealthOptions) {
+		opts.Config = config
+	}
+}
+
+// WithHealthLogger sets the logger used to report background check failures.
+func WithHealthLogger(logger log.Logger) HealthOption {
+	return func(opts *HealthOptions) {
+		opts.Logger = logger
+	}
+}
+
+// =============================================================================
+// HEALTH MANAGER
+// =============================================================================
+
+// healthManager is the default HealthManager implementation.
+type healthManager struct {
+	name   string
+	logger log.Logger
+
+	mu          sync.RWMutex
+	config      HealthConfig
+	checks      map[string]HealthCheck
+	subscribers []HealthCallback
+	lastReport  *HealthReport
+	environment string
+	version     string
+	hostname    string
+	startTime   time.Time
+
+	// Background check loop
+	loopMu  sync.Mutex
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started atomic.Bool
+
+	// Persistence of the last report
+	persistMu      sync.Mutex
+	persistPending bool          // A newer report awaits writing
+	persistDone    chan struct{} // Closed when the running writer exits; nil if none runs
+}
+
+// NewHealthManager creates a new health manager.
+// Registered checks run on every call to Check and, once started, on the
+// interval configured in HealthConfig.Intervals.Check.
+func NewHealthManager(opts ...HealthOption) HealthManager {
+	options := &HealthOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	name := options.Name
+	if name == "" {
+		name = "health-manager"
+	}
+
+	var config HealthConfig
+	if options.Config != nil {
+		config = *options.Config
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &healthManager{
+		name:        name,
+		logger:      options.Logger,
+		config:      config,
+		checks:      make(map[string]HealthCheck),
+		environment: config.Environment,
+		version:     config.Version,
+		hostname:    hostname,
+		startTime:   time.Now(),
+	}
+}
+
+// HealthService interface implementation
+
+func (hm *healthManager) Name() string {
+	return hm.name
+}
+
+// Start starts the background check loop when a check interval is configured.
+// With Features.Persistence, the report persisted by a previous run is loaded
+// first and served by LastReport and Status until the first check completes.
+func (hm *healthManager) Start(ctx context.Context) error {
+	if hm.started.Swap(true) {
+		return ErrHealthManagerAlreadyStarted
+	}
+
+	hm.loadPersistedReport()
+	hm.startLoop()
+
+	return nil
+}
+
+// Stop stops the background check loop and waits for it to exit, then waits
+// for a pending write of the persisted report.
+func (hm *healthManager) Stop(ctx context.Context) error {
+	if !hm.started.Swap(false) {
+		return ErrHealthManagerNotStarted
+	}
+
+	hm.stopLoop()
+	hm.flushPersistence()
+
+	return nil
+}
+
+// Health reports an error when the manager is not started or the last report is unhealthy.
+func (hm *healthManager) Health(ctx context.Context) error {
+	if !hm.started.Load() {
+		return ErrHealthManagerNotStarted
+	}
+
+	if hm.Status().IsUnhealthy() {
+		return ErrHealthUnhealthy
+	}
+
+	return nil
+}
+
+// startLoop stops the background check goroutine, if one runs, and launches
+// a new one if the manager is started and an interval is configured. Both
+// happen under loopMu, so concurrent calls never leave two loops running.
+func (hm *healthManager) startLoop() {
+	hm.loopMu.Lock()
+	defer hm.loopMu.Unlock()
+
+	hm.stopLoopLocked()
+
+	hm.mu.RLock()
+	interval := hm.config.Intervals.Check
+	hm.mu.RUnlock()
+
+	// Stop may have run since the caller checked started
+	if interval <= 0 || !hm.started.Load() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hm.cancel = cancel
+
+	hm.wg.Add(1)
+
+	go hm.checkLoop(ctx, interval)
+}
+
+// stopLoop cancels the background check goroutine and waits for it to exit.
+func (hm *healthManager) stopLoop() {
+	hm.loopMu.Lock()
+	defer hm.loopMu.Unlock()
+
+	hm.stopLoopLocked()
+}
+
+// stopLoopLocked is stopLoop with loopMu held.
+func (hm *healthManager) stopLoopLocked() {
+	if hm.cancel != nil {
+		hm.cancel()
+		hm.cancel = nil
+	}
+
+	hm.wg.Wait()
+}
+
+// checkLoop runs all checks immediately and then on every interval.
+func (hm *healthManager) checkLoop(ctx context.Context, interval time.Duration) {
+	defer hm.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	hm.runBackgroundCheck(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			hm.runBackgroundCheck(ctx)
+		}
+	}
+}
+
+// runBackgroundCheck runs all checks and logs an unhealthy outcome.
+func (hm *healthManager) runBackgroundCheck(ctx context.Context) {
+	report := hm.Check(ctx)
+
+	if hm.logger != nil && report.IsUnhealthy() {
+		hm.logger.Warn("health check reported unhealthy",
+			log.String("manager", hm.name),
+			log.Int("unhealthy", report.UnhealthyCount()),
+			log.Int("failed_critical", report.FailedCriticalCount()))
+	}
+}
+
+// HealthChecker interface implementation
+
+// Check runs all registered checks and returns the aggregated report.
+// Checks are ordered by their declared dependencies: a check runs only after
+// its dependencies, and is reported as unknown without running when any of
+// them is unhealthy or unknown. Checks that are part of a dependency cycle are
+// reported as unhealthy. Independent checks run concurrently, bounded by
+// HealthConfig.Performance.MaxConcurrentChecks. Dependencies on checks that
+// are not registered are ignored. With Features.Persistence, the report is
+// written to HealthConfig.PersistencePath.
+func (hm *healthManager) Check(ctx context.Context) *HealthReport {
+	start := time.Now()
+
+	hm.mu.RLock()
+	checks := maps.Clone(hm.checks)
+	config := hm.config
+	hm.mu.RUnlock()
+
+	levels, cyclic := orderHealthChecks(checks)
+
+	var sem chan struct{}
+	if config.Performance.MaxConcurrentChecks > 0 {
+		sem = make(chan struct{}, config.Performance.MaxConcurrentChecks)
+	}
+
+	resultsByName := make(map[string]*HealthResult, len(checks))
+
+	for _, level := range levels {
+		levelResults := make([]*HealthResult, len(level))
+
+		var wg sync.WaitGroup
+
+		for i, check := range level {
+			// Results of earlier levels are complete and only read here.
+			if failed := failedDependency(check, resultsByName); failed != "" {
+				levelResults[i] = hm.skippedResult(check, failed, config)
+
+				continue
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if sem != nil {
+					sem <- struct{}{}
+					defer func() { <-sem }()
+				}
+
+				levelResults[i] = hm.runCheck(ctx, check, config)
+			}()
+		}
+
+		wg.Wait()
+
+		for i, check := range level {
+			resultsByName[check.Name()] = levelResults[i]
+		}
+	}
+
+	for _, check := range cyclic {
+		resultsByName[check.Name()] = NewHealthResult(check.Name(), HealthStatusUnhealthy,
+			"health check dependencies form a cycle").
+			WithError(ErrHealthCheckDependencyCycle).
+			WithCritical(hm.isCritical(check, config))
+	}
+
+	results := slices.Collect(maps.Values(resultsByName))
+
+	report := NewHealthReport()
+	report.AddResults(results)
+	report.Overall = aggregateHealthStatus(report, config.Thresholds)
+
+	hm.mu.Lock()
+	previous := hm.lastReport
+	report.WithVersion(hm.version).
+		WithEnvironment(hm.environment).
+		WithHostname(hm.hostname).
+		WithUptime(time.Since(hm.startTime)).
+		WithDuration(time.Since(start))
+	hm.lastReport = report
+	subscribers := slices.Clone(hm.subscribers)
+	hm.mu.Unlock()
+
+	hm.notify(previous, report, subscribers)
+	hm.schedulePersist(config)
+
+	return report
+}
+
+// CheckOne runs a single registered check by name.
+func (hm *healthManager) CheckOne(ctx context.Context, name string) *HealthResult {
+	hm.mu.RLock()
+	check, ok := hm.checks[name]
+	config := hm.config
+	hm.mu.RUnlock()
+
+	if !ok {
+		return NewHealthResult(name, HealthStatusUnknown, "health check not found").
+			WithError(ErrHealthCheckNotFound)
+	}
+
+	return hm.runCheck(ctx, check, config)
+}
+
+// Status returns the overall status of the last report, or unknown if no
+// checks have run yet.
+func (hm *healthManager) Status() HealthStatus {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	if hm.lastReport == nil {
+		return HealthStatusUnknown
+	}
+
+	return hm.lastReport.Overall
+}
+
+// runCheck executes a check, enforcing its timeout and recovering from panics.
+func (hm *healthManager) runCheck(ctx context.Context, check HealthCheck, config HealthConfig) *HealthResult {
+	start := time.Now()
+
+	timeout := check.Timeout()
+	if timeout <= 0 {
+		timeout = config.Performance.DefaultTimeout
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan *HealthResult, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- NewHealthResult(check.Name(), HealthStatusUnhealthy, "health check panicked").
+					WithError(fmt.Errorf("panic: %v", r))
+			}
+		}()
+
+		done <- check.Check(checkCtx)
+	}()
+
+	var result *HealthResult
+
+	select {
+	case result = <-done:
+		if result == nil {
+			result = NewHealthResult(check.Name(), HealthStatusUnknown, "health check returned no result")
+		}
+	case <-checkCtx.Done():
+		if err := ctx.Err(); err != nil {
+			result = NewHealthResult(check.Name(), HealthStatusUnhealthy, "health check cancelled").
+				WithError(err)
+		} else {
+			result = NewHealthResult(check.Name(), HealthStatusUnhealthy,
+				fmt.Sprintf("health check timed out after %s", timeout)).
+				WithError(ErrHealthCheckTimeout)
+		}
+	}
+
+	if result.Name == "" {
+		result.Name = check.Name()
+	}
+
+	result.Critical = hm.isCritical(check, config)
+
+	if result.Duration == 0 {
+		result.Duration = time.Since(start)
+	}
+
+	return result
+}
+
+// isCritical reports whether a check is critical, either by its own
+// declaration or by being listed in HealthConfig.CriticalServices.
+func (hm *healthManager) isCritical(check HealthCheck, config HealthConfig) bool {
+	return check.Critical() || slices.Contains(config.CriticalServices, check.Name())
+}
+
+// skippedResult builds the result for a check skipped because a dependency failed.
+func (hm *healthManager) skippedResult(check HealthCheck, dependency string, config HealthConfig) *HealthResult {
+	return NewHealthResult(check.Name(), HealthStatusUnknown,
+		fmt.Sprintf("dependency %s unhealthy", dependency)).
+		WithDetail("dependency", dependency).
+		WithCritical(hm.isCritical(check, config))
+}
+
+// failedDependency returns the name of the first dependency of check whose
+// result is unhealthy or unknown, or an empty string if none failed.
+func failedDependency(check HealthCheck, results map[string]*HealthResult) string {
+	for _, dep := range check.Dependencies() {
+		result, ok := results[dep]
+		if ok && (result.IsUnhealthy() || result.Status.IsUnknown()) {
+			return dep
+		}
+	}
+
+	return ""
+}
+
+// orderHealthChecks groups checks into levels so that every check comes after
+// the checks it depends on. Checks within a level are independent of each
+// other and sorted by name. Checks that cannot be ordered because they are
+// part of, or depend on, a dependency cycle are returned separately.
+func orderHealthChecks(checks map[string]HealthCheck) ([][]HealthCheck, []HealthCheck) {
+	pending := make(map[string]int, len(checks))
+	dependents := make(map[string][]string, len(checks))
+
+	for name, check := range checks {
+		pending[name] = 0
+
+		for _, dep := range slices.Compact(slices.Sorted(slices.Values(check.Dependencies()))) {
+			if _, ok := checks[dep]; !ok {
+				continue
+			}
+
+			pending[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var levels [][]HealthCheck
+
+	for len(pending) > 0 {
+		var ready []string
+
+		for name, count := range pending {
+			if count == 0 {
+				ready = append(ready, name)
+			}
+		}
+
+		if len(ready) == 0 {
+			break
+		}
+
+		slices.Sort(ready)
+
+		level := make([]HealthCheck, 0, len(ready))
+
+		for _, name := range ready {
+			delete(pending, name)
+			level = append(level, checks[name])
+
+			for _, dependent := range dependents[name] {
+				pending[dependent]--
+			}
+		}
+
+		levels = append(levels, level)
+	}
+
+	cyclic := make([]HealthCheck, 0, len(pending))
+	for _, name := range slices.Sorted(maps.Keys(pending)) {
+		cyclic = append(cyclic, checks[name])
+	}
+
+	return levels, cyclic
+}
+
+// notify invokes subscribers for every check whose status changed since the
+// previous report, in check name order. A check seen for the first time is
+// treated as transitioning from unknown. It must be called without holding mu.
+func (hm *healthManager) notify(previous, current *HealthReport, subscribers []HealthCallback) {
+	if len(subscribers) == 0 {
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(current.Services)) {
+		result := current.Services[name]
+
+		prevStatus := HealthStatusUnknown
+		if previous != nil {
+			if prev, ok := previous.Services[name]; ok {
+				prevStatus = prev.Status
+			}
+		}
+
+		if prevStatus == result.Status {
+			continue
+		}
+
+		for _, callback := range subscribers {
+			hm.invokeCallback(callback, result)
+		}
+	}
+}
+
+// invokeCallback runs a subscriber callback, recovering from panics so one
+// faulty subscriber does not prevent others from being notified.
+func (hm *healthManager) invokeCallback(callback HealthCallback, result *HealthResult) {
+	defer func() {
+		if r := recover(); r != nil && hm.logger != nil {
+			hm.logger.Error("health subscriber panicked",
+				log.String("check", result.Name),
+				log.String("panic", fmt.Sprint(r)))
+		}
+	}()
+
+	callback(result)
+}
+
+// aggregateHealthStatus computes the overall status of a report.
+// Any failed critical check makes the report unhealthy. Otherwise the
+// fraction of unhealthy checks is compared against the unhealthy threshold
+// and the fraction of non-healthy checks against the degraded threshold.
+// A zero threshold disables the unhealthy ratio and makes any non-healthy
+// check degrade the report.
+func aggregateHealthStatus(report *HealthReport, thresholds HealthThresholds) HealthStatus {
+	stats := report.Stats
+	if stats.Total == 0 {
+		return HealthStatusHealthy
+	}
+
+	if stats.FailedCritical > 0 {
+		return HealthStatusUnhealthy
+	}
+
+	total := float64(stats.Total)
+
+	if thresholds.Unhealthy > 0 && float64(stats.Unhealthy)/total >= thresholds.Unhealthy {
+		return HealthStatusUnhealthy
+	}
+
+	nonHealthy := stats.Total - stats.Healthy
+	if nonHealthy > 0 && float64(nonHealthy)/total >= thresholds.Degraded {
+		return HealthStatusDegraded
+	}
+
+	return HealthStatusHealthy
+}
+
+// HealthCheckRegistry interface implementation
+
+func (hm *healthManager) Register(check HealthCheck) error {
+	if check == nil {
+		return ErrHealthCheckNil
+	}
+
+	name := check.Name()
+	if name == "" {
+		return ErrHealthCheckNameEmpty
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	if _, exists := hm.checks[name]; exists {
+		return ErrHealthCheckAlreadyRegistered
+	}
+
+	hm.checks[name] = check
+
+	return nil
+}
+
+func (hm *healthManager) RegisterFn(name string, check HealthCheckFn) error {
+	if check == nil {
+		return ErrHealthCheckNil
+	}
+
+	return hm.Register(&healthCheckFunc{name: name, fn: check})
+}
+
+func (hm *healthManager) Unregister(name string) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	if _, exists := hm.checks[name]; !exists {
+		return ErrHealthCheckNotFound
+	}
+
+	delete(hm.checks, name)
+
+	return nil
+}
+
+func (hm *healthManager) ListChecks() map[string]HealthCheck {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	return maps.Clone(hm.checks)
+}
+
+// HealthReporter interface implementation
+
+func (hm *healthManager) LastReport() *HealthReport {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	return hm.lastReport
+}
+
+func (hm *healthManager) Stats() *HealthCheckerStats {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	stats := &HealthCheckerStats{
+		RegisteredChecks: len(hm.checks),
+		Subscribers:      len(hm.subscribers),
+		Started:          hm.started.Load(),
+		Uptime:           time.Since(hm.startTime),
+		OverallStatus:    HealthStatusUnknown,
+		LastReport:       hm.lastReport,
+	}
+
+	if hm.lastReport != nil {
+		stats.LastReportTime = hm.lastReport.Timestamp
+		stats.OverallStatus = hm.lastReport.Overall
+	}
+
+	return stats
+}
+
+// HealthMetadata interface implementation
+
+func (hm *healthManager) SetEnvironment(name string) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.environment = name
+}
+
+func (hm *healthManager) SetVersion(version string) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.version = version
+}
+
+func (hm *healthManager) SetHostname(hostname string) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.hostname = hostname
+}
+
+func (hm *healthManager) Environment() string {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	return hm.environment
+}
+
+func (hm *healthManager) Hostname() string {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	return hm.hostname
+}
+
+func (hm *healthManager) Version() string {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	return hm.version
+}
+
+func (hm *healthManager) StartTime() time.Time {
+	return hm.startTime
+}
+
+// HealthSubscriber interface implementation
+
+func (hm *healthManager) Subscribe(callback HealthCallback) error {
+	if callback == nil {
+		return ErrHealthCallbackNil
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.subscribers = append(hm.subscribers, callback)
+
+	return nil
+}
+
+// HealthConfigurable interface implementation
+
+// Reload replaces the configuration and restarts the background check loop
+// so a changed interval takes effect.
+func (hm *healthManager) Reload(config *HealthConfig) error {
+	if config == nil {
+		return ErrHealthConfigNil
+	}
+
+	hm.mu.Lock()
+	hm.config = *config
+
+	if config.Version != "" {
+		hm.version = config.Version
+	}
+
+	if config.Environment != "" {
+		hm.environment = config.Environment
+	}
+	hm.mu.Unlock()
+
+	if hm.started.Load() {
+		hm.startLoop()
+	}
+
+	return nil
+}
+
+// =============================================================================
+// FUNCTION HEALTH CHECK
+// =============================================================================
+
+// healthCheckFunc adapts a HealthCheckFn to the HealthCheck interface.
+type healthCheckFunc struct {
+	name string
+	fn   HealthCheckFn
+}
+
+func (h *healthCheckFunc) Name() string {
+	return h.name
+}
+
+func (h *healthCheckFunc) Check(ctx context.Context) *HealthResult {
+	return h.fn(ctx)
+}
+
+func (h *healthCheckFunc) Timeout() time.Duration {
+	return 0
+}
+
+func (h *healthCheckFunc) Critical() bool {
+	return false
+}
+
+func (h *healthCheckFunc) Dependencies() []string {
+	return nil
+}
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrHealthManagerAlreadyStarted  = &MetricError{Message: "health manager already started"}
+	ErrHealthManagerNotStarted      = &MetricError{Message: "health manager not started"}
+	ErrHealthUnhealthy              = &MetricError{Message: "health status is unhealthy"}
+	ErrHealthCheckNil               = &MetricError{Message: "health check is nil"}
+	ErrHealthCheckNameEmpty         = &MetricError{Message: "health check name is empty"}
+	ErrHealthCheckAlreadyRegistered = &MetricError{Message: "health check already registered"}
+	ErrHealthCheckNotFound          = &MetricError{Message: "health check not found"}
+	ErrHealthCheckTimeout           = &MetricError{Message: "health check timeout"}
+	ErrHealthCheckDependencyCycle   = &MetricError{Message: "health check dependency cycle"}
+	ErrHealthCallbackNil            = &MetricError{Message: "health callback is nil"}
+	ErrHealthConfigNil              = &MetricError{Message: "health config is nil"}
+)
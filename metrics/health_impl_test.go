@@ -0,0 +1,573 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHealthCheck is a configurable HealthCheck for tests.
+type testHealthCheck struct {
+	name     string
+	status   HealthStatus
+	delay    time.Duration
+	timeout  time.Duration
+	critical bool
+	deps     []string
+	calls    atomic.Int32
+	onCheck  func()
+}
+
+func (c *testHealthCheck) Name() string { return c.name }
+
+func (c *testHealthCheck) Check(ctx context.Context) *HealthResult {
+	c.calls.Add(1)
+
+	if c.onCheck != nil {
+		c.onCheck()
+	}
+
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+		}
+	}
+
+	return NewHealthResult(c.name, c.status, "test")
+}
+
+func (c *testHealthCheck) Timeout() time.Duration { return c.timeout }
+func (c *testHealthCheck) Critical() bool         { return c.critical }
+func (c *testHealthCheck) Dependencies() []string { return c.deps }
+
+// =============================================================================
+// HEALTH MANAGER TESTS
+// =============================================================================
+
+func TestHealthManager_AllPassing(t *testing.T) {
+	hm := NewHealthManager(WithHealthName("test"), WithHealthConfig(&HealthConfig{Version: "1.2.3"}))
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "db", status: HealthStatusHealthy, critical: true}))
+	require.NoError(t, hm.RegisterFn("cache", func(ctx context.Context) *HealthResult {
+		return NewHealthResult("cache", HealthStatusHealthy, "ok")
+	}))
+
+	assert.Equal(t, HealthStatusUnknown, hm.Status())
+	assert.Nil(t, hm.LastReport())
+
+	report := hm.Check(context.Background())
+
+	assert.Equal(t, HealthStatusHealthy, report.Overall)
+	assert.Equal(t, 2, report.Stats.Total)
+	assert.Equal(t, 2, report.Stats.Healthy)
+	assert.Equal(t, 1, report.Stats.Critical)
+	assert.Equal(t, "1.2.3", report.Version)
+	assert.Same(t, report, hm.LastReport())
+	assert.Equal(t, HealthStatusHealthy, hm.Status())
+
+	stats := hm.Stats()
+	assert.Equal(t, 2, stats.RegisteredChecks)
+	assert.Equal(t, HealthStatusHealthy, stats.OverallStatus)
+	assert.Equal(t, report.Timestamp, stats.LastReportTime)
+}
+
+func TestHealthManager_FailingCritical(t *testing.T) {
+	hm := NewHealthManager()
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "db", status: HealthStatusUnhealthy, critical: true}))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "cache", status: HealthStatusHealthy}))
+
+	report := hm.Check(context.Background())
+
+	assert.Equal(t, HealthStatusUnhealthy, report.Overall)
+	assert.Equal(t, 1, report.Stats.FailedCritical)
+}
+
+func TestHealthManager_FailingNonCritical(t *testing.T) {
+	hm := NewHealthManager()
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "db", status: HealthStatusHealthy}))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "cache", status: HealthStatusUnhealthy}))
+
+	report := hm.Check(context.Background())
+	assert.Equal(t, HealthStatusDegraded, report.Overall)
+}
+
+func TestHealthManager_CriticalServicesConfig(t *testing.T) {
+	hm := NewHealthManager(WithHealthConfig(&HealthConfig{CriticalServices: []string{"cache"}}))
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "cache", status: HealthStatusUnhealthy}))
+
+	report := hm.Check(context.Background())
+	assert.True(t, report.Services["cache"].Critical)
+	assert.Equal(t, HealthStatusUnhealthy, report.Overall)
+}
+
+func TestHealthManager_Thresholds(t *testing.T) {
+	hm := NewHealthManager(WithHealthConfig(&HealthConfig{
+		Thresholds: HealthThresholds{Degraded: 0.5, Unhealthy: 0.75},
+	}))
+
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, hm.Register(&testHealthCheck{name: name, status: HealthStatusHealthy}))
+	}
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "d", status: HealthStatusUnhealthy}))
+
+	// 1 of 4 failing is below the degraded threshold
+	assert.Equal(t, HealthStatusHealthy, hm.Check(context.Background()).Overall)
+
+	require.NoError(t, hm.Unregister("a"))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "a", status: HealthStatusUnhealthy}))
+
+	// 2 of 4 failing reaches the degraded threshold
+	assert.Equal(t, HealthStatusDegraded, hm.Check(context.Background()).Overall)
+
+	require.NoError(t, hm.Unregister("b"))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "b", status: HealthStatusUnhealthy}))
+
+	// 3 of 4 failing reaches the unhealthy threshold
+	assert.Equal(t, HealthStatusUnhealthy, hm.Check(context.Background()).Overall)
+}
+
+func TestHealthManager_Timeout(t *testing.T) {
+	hm := NewHealthManager()
+
+	require.NoError(t, hm.Register(&testHealthCheck{
+		name:     "slow",
+		status:   HealthStatusHealthy,
+		delay:    time.Second,
+		timeout:  20 * time.Millisecond,
+		critical: true,
+	}))
+
+	start := time.Now()
+	report := hm.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, HealthStatusUnhealthy, report.Overall)
+
+	result := report.Services["slow"]
+	require.NotNil(t, result)
+	assert.Equal(t, HealthStatusUnhealthy, result.Status)
+	assert.Contains(t, result.Message, "timed out")
+	assert.Equal(t, ErrHealthCheckTimeout.Error(), result.Error)
+}
+
+func TestHealthManager_ConcurrentChecksBounded(t *testing.T) {
+	const limit = 4
+
+	hm := NewHealthManager(WithHealthConfig(&HealthConfig{
+		Performance: HealthPerformance{MaxConcurrentChecks: limit},
+	}))
+
+	require.NoError(t, hm.Register(&testHealthCheck{
+		name:    "slow",
+		status:  HealthStatusHealthy,
+		delay:   time.Second,
+		timeout: 50 * time.Millisecond,
+	}))
+
+	var running, peak atomic.Int32
+
+	release := make(chan struct{})
+
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		require.NoError(t, hm.RegisterFn(name, func(ctx context.Context) *HealthResult {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+
+			<-release
+
+			return NewHealthResult(name, HealthStatusHealthy, "ok")
+		}))
+	}
+
+	reports := make(chan *HealthReport, 1)
+
+	go func() { reports <- hm.Check(context.Background()) }()
+
+	// The checks run side by side: every slot the slow check leaves is taken
+	// by a blocked check before any of them finishes
+	assert.Eventually(t, func() bool { return running.Load() >= limit-1 }, time.Second, time.Millisecond)
+	close(release)
+
+	report := <-reports
+
+	assert.GreaterOrEqual(t, peak.Load(), int32(limit-1))
+	assert.LessOrEqual(t, peak.Load(), int32(limit))
+	assert.Equal(t, 7, report.Stats.Total)
+	assert.Equal(t, 6, report.Stats.Healthy)
+	assert.Equal(t, HealthStatusUnhealthy, report.Services["slow"].Status)
+	assert.Equal(t, ErrHealthCheckTimeout.Error(), report.Services["slow"].Error)
+}
+
+func TestHealthManager_MaxConcurrentChecks(t *testing.T) {
+	const limit = 2
+
+	hm := NewHealthManager(WithHealthConfig(&HealthConfig{
+		Performance: HealthPerformance{MaxConcurrentChecks: limit},
+	}))
+
+	var running, peak atomic.Int32
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, hm.RegisterFn(name, func(ctx context.Context) *HealthResult {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			return NewHealthResult(name, HealthStatusHealthy, "ok")
+		}))
+	}
+
+	report := hm.Check(context.Background())
+
+	assert.Equal(t, 5, report.Stats.Healthy)
+	assert.LessOrEqual(t, peak.Load(), int32(limit))
+}
+
+func TestHealthManager_DefaultTimeoutFromConfig(t *testing.T) {
+	hm := NewHealthManager(WithHealthConfig(&HealthConfig{
+		Performance: HealthPerformance{DefaultTimeout: 20 * time.Millisecond},
+	}))
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "slow", status: HealthStatusHealthy, delay: time.Second}))
+
+	result := hm.CheckOne(context.Background(), "slow")
+	assert.Equal(t, HealthStatusUnhealthy, result.Status)
+}
+
+func TestHealthManager_Panic(t *testing.T) {
+	hm := NewHealthManager()
+
+	require.NoError(t, hm.RegisterFn("panics", func(ctx context.Context) *HealthResult {
+		panic("boom")
+	}))
+
+	result := hm.CheckOne(context.Background(), "panics")
+	assert.Equal(t, HealthStatusUnhealthy, result.Status)
+	assert.Contains(t, result.Error, "boom")
+}
+
+func TestHealthManager_Registry(t *testing.T) {
+	hm := NewHealthManager()
+
+	check := &testHealthCheck{name: "db", status: HealthStatusHealthy}
+
+	require.NoError(t, hm.Register(check))
+	assert.ErrorIs(t, hm.Register(check), ErrHealthCheckAlreadyRegistered)
+	assert.ErrorIs(t, hm.Register(nil), ErrHealthCheckNil)
+	assert.ErrorIs(t, hm.Register(&testHealthCheck{}), ErrHealthCheckNameEmpty)
+	assert.ErrorIs(t, hm.RegisterFn("fn", nil), ErrHealthCheckNil)
+
+	assert.Len(t, hm.ListChecks(), 1)
+
+	require.NoError(t, hm.Unregister("db"))
+	assert.ErrorIs(t, hm.Unregister("db"), ErrHealthCheckNotFound)
+
+	result := hm.CheckOne(context.Background(), "db")
+	assert.Equal(t, HealthStatusUnknown, result.Status)
+}
+
+func TestHealthManager_Subscribe(t *testing.T) {
+	hm := NewHealthManager()
+	check := &testHealthCheck{name: "db", status: HealthStatusHealthy}
+
+	require.NoError(t, hm.Register(check))
+
+	var (
+		mu      sync.Mutex
+		changes []HealthStatus
+	)
+
+	require.NoError(t, hm.Subscribe(func(result *HealthResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		changes = append(changes, result.Status)
+	}))
+
+	// A flapping check notifies on every transition but not on repeats
+	statuses := []HealthStatus{
+		HealthStatusHealthy,
+		HealthStatusHealthy,
+		HealthStatusUnhealthy,
+		HealthStatusUnhealthy,
+		HealthStatusDegraded,
+		HealthStatusHealthy,
+	}
+
+	for _, status := range statuses {
+		check.status = status
+		hm.Check(context.Background())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, []HealthStatus{
+		HealthStatusHealthy,
+		HealthStatusUnhealthy,
+		HealthStatusDegraded,
+		HealthStatusHealthy,
+	}, changes)
+	assert.Equal(t, 1, hm.Stats().Subscribers)
+	assert.ErrorIs(t, hm.Subscribe(nil), ErrHealthCallbackNil)
+}
+
+func TestHealthManager_SubscribePanicIsolated(t *testing.T) {
+	hm := NewHealthManager()
+	require.NoError(t, hm.Register(&testHealthCheck{name: "db", status: HealthStatusHealthy}))
+
+	var calls atomic.Int32
+
+	require.NoError(t, hm.Subscribe(func(result *HealthResult) {
+		panic("subscriber failure")
+	}))
+	require.NoError(t, hm.Subscribe(func(result *HealthResult) {
+		calls.Add(1)
+	}))
+
+	assert.NotPanics(t, func() {
+		hm.Check(context.Background())
+	})
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHealthManager_SubscribeCanUseManager(t *testing.T) {
+	hm := NewHealthManager()
+	require.NoError(t, hm.Register(&testHealthCheck{name: "db", status: HealthStatusHealthy}))
+
+	var checks atomic.Int32
+
+	// Callbacks run without the registry lock held, so they may call back into the manager.
+	require.NoError(t, hm.Subscribe(func(result *HealthResult) {
+		checks.Store(int32(len(hm.ListChecks())))
+	}))
+
+	done := make(chan struct{})
+
+	go func() {
+		hm.Check(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("callback deadlocked on the manager")
+	}
+
+	assert.Equal(t, int32(1), checks.Load())
+}
+
+func TestHealthManager_Lifecycle(t *testing.T) {
+	hm := NewHealthManager(WithHealthConfig(&HealthConfig{
+		Intervals: HealthIntervals{Check: 10 * time.Millisecond},
+	}))
+
+	check := &testHealthCheck{name: "db", status: HealthStatusHealthy}
+	require.NoError(t, hm.Register(check))
+
+	ctx := context.Background()
+
+	assert.ErrorIs(t, hm.Health(ctx), ErrHealthManagerNotStarted)
+	assert.ErrorIs(t, hm.Stop(ctx), ErrHealthManagerNotStarted)
+
+	require.NoError(t, hm.Start(ctx))
+	assert.ErrorIs(t, hm.Start(ctx), ErrHealthManagerAlreadyStarted)
+
+	require.Eventually(t, func() bool {
+		return check.calls.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, hm.Health(ctx))
+	assert.True(t, hm.Stats().Started)
+
+	require.NoError(t, hm.Stop(ctx))
+	assert.False(t, hm.Stats().Started)
+
+	calls := check.calls.Load()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, calls, check.calls.Load())
+}
+
+func TestHealthManager_Reload(t *testing.T) {
+	hm := NewHealthManager()
+
+	check := &testHealthCheck{name: "db", status: HealthStatusHealthy}
+	require.NoError(t, hm.Register(check))
+
+	ctx := context.Background()
+
+	require.NoError(t, hm.Start(ctx))
+
+	defer hm.Stop(ctx)
+
+	// No interval configured, so no background checks run
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), check.calls.Load())
+
+	assert.ErrorIs(t, hm.Reload(nil), ErrHealthConfigNil)
+	require.NoError(t, hm.Reload(&HealthConfig{
+		Intervals: HealthIntervals{Check: 10 * time.Millisecond},
+		Version:   "2.0.0",
+	}))
+
+	require.Eventually(t, func() bool {
+		return check.calls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, "2.0.0", hm.Version())
+}
+
+func TestHealthManager_ConcurrentReload(t *testing.T) {
+	hm := NewHealthManager()
+
+	check := &testHealthCheck{name: "db", status: HealthStatusHealthy}
+	require.NoError(t, hm.Register(check))
+
+	config := &HealthConfig{Intervals: HealthIntervals{Check: time.Millisecond}}
+
+	var wg sync.WaitGroup
+
+	// Reloads race each other and a start/stop cycle
+	for range 8 {
+		wg.Go(func() {
+			for range 20 {
+				assert.NoError(t, hm.Reload(config))
+			}
+		})
+	}
+
+	wg.Go(func() {
+		for range 20 {
+			_ = hm.Start(t.Context())
+			_ = hm.Stop(t.Context())
+		}
+	})
+
+	wg.Wait()
+
+	// Every loop started by the reloads ends with Stop
+	require.NoError(t, hm.Start(t.Context()))
+	require.NoError(t, hm.Reload(config))
+	require.NoError(t, hm.Stop(t.Context()))
+
+	// Let checks abandoned by a cancelled loop settle first
+	time.Sleep(10 * time.Millisecond)
+
+	calls := check.calls.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, calls, check.calls.Load())
+}
+
+func TestHealthManager_DependencyChain(t *testing.T) {
+	hm := NewHealthManager()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			order = append(order, name)
+		}
+	}
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "api", status: HealthStatusHealthy, deps: []string{"cache"}, onCheck: record("api")}))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "cache", status: HealthStatusHealthy, deps: []string{"db"}, onCheck: record("cache")}))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "db", status: HealthStatusHealthy, deps: []string{"unregistered"}, onCheck: record("db")}))
+
+	report := hm.Check(context.Background())
+
+	assert.Equal(t, HealthStatusHealthy, report.Overall)
+	assert.Equal(t, 3, report.Stats.Healthy)
+	assert.Equal(t, []string{"db", "cache", "api"}, order)
+}
+
+func TestHealthManager_FailedDependencySkipsDependents(t *testing.T) {
+	hm := NewHealthManager()
+
+	db := &testHealthCheck{name: "db", status: HealthStatusUnhealthy}
+	cache := &testHealthCheck{name: "cache", status: HealthStatusHealthy, deps: []string{"db"}}
+	api := &testHealthCheck{name: "api", status: HealthStatusHealthy, deps: []string{"cache"}}
+	other := &testHealthCheck{name: "other", status: HealthStatusHealthy}
+
+	for _, check := range []*testHealthCheck{db, cache, api, other} {
+		require.NoError(t, hm.Register(check))
+	}
+
+	report := hm.Check(context.Background())
+
+	assert.Equal(t, HealthStatusUnhealthy, report.Services["db"].Status)
+
+	assert.Equal(t, HealthStatusUnknown, report.Services["cache"].Status)
+	assert.Equal(t, "dependency db unhealthy", report.Services["cache"].Message)
+	assert.Equal(t, int32(0), cache.calls.Load())
+
+	// Skips propagate through the chain
+	assert.Equal(t, HealthStatusUnknown, report.Services["api"].Status)
+	assert.Equal(t, "dependency cache unhealthy", report.Services["api"].Message)
+	assert.Equal(t, int32(0), api.calls.Load())
+
+	assert.Equal(t, HealthStatusHealthy, report.Services["other"].Status)
+}
+
+func TestHealthManager_DependencyCycle(t *testing.T) {
+	hm := NewHealthManager()
+
+	require.NoError(t, hm.Register(&testHealthCheck{name: "a", status: HealthStatusHealthy, deps: []string{"b"}}))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "b", status: HealthStatusHealthy, deps: []string{"a"}}))
+	require.NoError(t, hm.Register(&testHealthCheck{name: "c", status: HealthStatusHealthy}))
+
+	done := make(chan *HealthReport, 1)
+
+	go func() {
+		done <- hm.Check(context.Background())
+	}()
+
+	var report *HealthReport
+
+	select {
+	case report = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Check did not return with a dependency cycle")
+	}
+
+	for _, name := range []string{"a", "b"} {
+		result := report.Services[name]
+		require.NotNil(t, result)
+		assert.Equal(t, HealthStatusUnhealthy, result.Status)
+		assert.Equal(t, ErrHealthCheckDependencyCycle.Error(), result.Error)
+	}
+
+	assert.Equal(t, HealthStatusHealthy, report.Services["c"].Status)
+}
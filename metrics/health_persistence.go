@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xraph/go-utils/log"
+)
+
+// =============================================================================
+// HEALTH REPORT PERSISTENCE
+// =============================================================================
+
+// persistencePath returns the file the last report is persisted to, or "" if
+// persistence is disabled in config.
+func persistencePath(config HealthConfig) string {
+	if !config.Features.Persistence {
+		return ""
+	}
+
+	return config.PersistencePath
+}
+
+// schedulePersist arranges for the last report to be written to the
+// configured persistence path without blocking the caller, so Check latency
+// does not depend on the disk. Reports produced while a write is in progress
+// are coalesced: only the newest one is written next.
+func (hm *healthManager) schedulePersist(config HealthConfig) {
+	if persistencePath(config) == "" {
+		return
+	}
+
+	hm.persistMu.Lock()
+	defer hm.persistMu.Unlock()
+
+	hm.persistPending = true
+	if hm.persistDone != nil {
+		return
+	}
+
+	hm.persistDone = make(chan struct{})
+
+	go hm.persistLoop(hm.persistDone)
+}
+
+// persistLoop writes the last report until no newer one is pending, then
+// closes done.
+func (hm *healthManager) persistLoop(done chan struct{}) {
+	defer close(done)
+
+	for {
+		hm.persistMu.Lock()
+		if !hm.persistPending {
+			hm.persistDone = nil
+			hm.persistMu.Unlock()
+
+			return
+		}
+
+		hm.persistPending = false
+		hm.persistMu.Unlock()
+
+		hm.mu.RLock()
+		report := hm.lastReport
+		config := hm.config
+		hm.mu.RUnlock()
+
+		hm.persistReport(report, config)
+	}
+}
+
+// flushPersistence waits until pending writes of the persisted report are done.
+func (hm *healthManager) flushPersistence() {
+	hm.persistMu.Lock()
+	done := hm.persistDone
+	hm.persistMu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// persistReport writes report to the configured persistence path. The report
+// is sanitized with HealthConfig.SensitiveDetailKeys first, the same way the
+// public health endpoint serves it, so no credentials end up on disk.
+// Failures are logged; they do not fail the check.
+func (hm *healthManager) persistReport(report *HealthReport, config HealthConfig) {
+	path := persistencePath(config)
+	if path == "" || report == nil {
+		return
+	}
+
+	data, err := report.Sanitized(config.SensitiveDetailKeys...).ToJSON()
+	if err == nil {
+		err = writeFileAtomic(path, data)
+	}
+
+	if err != nil && hm.logger != nil {
+		hm.logger.Warn("failed to persist health report",
+			log.String("manager", hm.name), log.String("path", path), log.Error(err))
+	}
+}
+
+// loadPersistedReport restores the last report from the configured
+// persistence path, so a restarted manager reports the last known status
+// until its first check completes. It does nothing if a check already ran,
+// persistence is disabled or nothing was persisted yet; an unreadable file
+// is logged and ignored.
+func (hm *healthManager) loadPersistedReport() {
+	hm.mu.RLock()
+	path := persistencePath(hm.config)
+	hm.mu.RUnlock()
+
+	if path == "" {
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+
+	var report *HealthReport
+	if err == nil {
+		report, err = FromJSON(data)
+	}
+
+	if err != nil {
+		if hm.logger != nil {
+			hm.logger.Warn("failed to load persisted health report",
+				log.String("manager", hm.name), log.String("path", path), log.Error(err))
+		}
+
+		return
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	if hm.lastReport == nil {
+		hm.lastReport = report
+	}
+}
+
+// writeFileAtomic writes data to path through a temporary file in the same
+// directory that is renamed over path, so readers never see a partial file.
+// The file is only readable by its owner.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	// Removing fails harmlessly once the file is renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// healthReport builds a report with the given overall status and check
+// statuses.
+func healthReport(overall HealthStatus, checks map[string]HealthStatus) *HealthReport {
+	report := NewHealthReport()
+	report.Overall = overall
+
+	for name, status := range checks {
+		report.AddResult(NewHealthResult(name, status, ""))
+	}
+
+	return report
+}
+
+func TestHealthReportAnalyzer_Diff(t *testing.T) {
+	prev := healthReport(HealthStatusHealthy, map[string]HealthStatus{
+		"database": HealthStatusHealthy,
+		"cache":    HealthStatusUnhealthy,
+		"queue":    HealthStatusDegraded,
+		"search":   HealthStatusHealthy,
+		"legacy":   HealthStatusHealthy,
+	})
+	current := healthReport(HealthStatusUnhealthy, map[string]HealthStatus{
+		"database": HealthStatusUnhealthy,
+		"cache":    HealthStatusHealthy,
+		"queue":    HealthStatusDegraded,
+		"search":   HealthStatusDegraded,
+		"payments": HealthStatusUnhealthy,
+	})
+
+	diff := NewHealthReportAnalyzer(current).Diff(prev)
+
+	assert.Equal(t, []string{"database", "payments"}, diff.NewlyFailed)
+	assert.Equal(t, []string{"cache"}, diff.Recovered)
+	assert.Equal(t, []HealthStatusChange{
+		{Name: "cache", From: HealthStatusUnhealthy, To: HealthStatusHealthy},
+		{Name: "database", From: HealthStatusHealthy, To: HealthStatusUnhealthy},
+		{Name: "search", From: HealthStatusHealthy, To: HealthStatusDegraded},
+	}, diff.Changed)
+	assert.Equal(t, []string{"payments"}, diff.Added)
+	assert.Equal(t, []string{"legacy"}, diff.Removed)
+
+	assert.Equal(t, HealthStatusHealthy, diff.PreviousOverall)
+	assert.Equal(t, HealthStatusUnhealthy, diff.CurrentOverall)
+	assert.True(t, diff.OverallChanged())
+	assert.True(t, diff.HasChanges())
+}
+
+func TestHealthReportAnalyzer_DiffSingleFlip(t *testing.T) {
+	prev := healthReport(HealthStatusHealthy, map[string]HealthStatus{
+		"database": HealthStatusHealthy,
+		"cache":    HealthStatusHealthy,
+	})
+	current := healthReport(HealthStatusHealthy, map[string]HealthStatus{
+		"database": HealthStatusUnhealthy,
+		"cache":    HealthStatusHealthy,
+	})
+
+	diff := NewHealthReportAnalyzer(current).Diff(prev)
+	assert.Equal(t, []string{"database"}, diff.NewlyFailed)
+	assert.Empty(t, diff.Recovered)
+	assert.Len(t, diff.Changed, 1)
+	assert.False(t, diff.OverallChanged())
+
+	// And back again
+	diff = NewHealthReportAnalyzer(prev).Diff(current)
+	assert.Empty(t, diff.NewlyFailed)
+	assert.Equal(t, []string{"database"}, diff.Recovered)
+}
+
+func TestHealthReportAnalyzer_DiffUnchanged(t *testing.T) {
+	checks := map[string]HealthStatus{"database": HealthStatusHealthy}
+
+	diff := NewHealthReportAnalyzer(healthReport(HealthStatusHealthy, checks)).
+		Diff(healthReport(HealthStatusHealthy, checks))
+
+	assert.False(t, diff.HasChanges())
+	assert.Empty(t, diff.NewlyFailed)
+	assert.Empty(t, diff.Recovered)
+	assert.Empty(t, diff.Changed)
+}
+
+func TestHealthReportAnalyzer_DiffNilPrevious(t *testing.T) {
+	current := healthReport(HealthStatusUnhealthy, map[string]HealthStatus{
+		"database": HealthStatusUnhealthy,
+		"cache":    HealthStatusHealthy,
+	})
+
+	diff := NewHealthReportAnalyzer(current).Diff(nil)
+
+	assert.Empty(t, diff.PreviousOverall)
+	assert.Equal(t, []string{"cache", "database"}, diff.Added)
+	assert.Equal(t, []string{"database"}, diff.NewlyFailed)
+	assert.Empty(t, diff.Recovered)
+	assert.Empty(t, diff.Changed)
+	assert.True(t, diff.HasChanges())
+}
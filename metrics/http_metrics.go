@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// HTTP METRICS MIDDLEWARE
+// =============================================================================
+
+// Metric names recorded by NewHTTPMetricsMiddleware.
+const (
+	HTTPRequestsTotalMetric    = "http_requests_total"
+	HTTPRequestsInFlightMetric = "http_requests_in_flight"
+	HTTPRequestDurationMetric  = "http_request_duration"
+)
+
+// HTTPMetricsOption configures NewHTTPMetricsMiddleware.
+type HTTPMetricsOption func(*httpMetricsOptions)
+
+type httpMetricsOptions struct {
+	normalizePaths bool
+	metricOpts     []MetricOption
+}
+
+// WithPathNormalization replaces numeric path segments with "{id}" when the
+// route falls back to the request path, so /users/42 and /users/43 are
+// recorded as the single route /users/{id}. Use it when requests are not
+// served by a router that reports the matched pattern.
+func WithPathNormalization() HTTPMetricsOption {
+	return func(opts *httpMetricsOptions) {
+		opts.normalizePaths = true
+	}
+}
+
+// WithHTTPMetricOptions applies metric options, such as a namespace, to every
+// metric recorded by the middleware.
+func WithHTTPMetricOptions(opts ...MetricOption) HTTPMetricsOption {
+	return func(o *httpMetricsOptions) {
+		o.metricOpts = append(o.metricOpts, opts...)
+	}
+}
+
+// NewHTTPMetricsMiddleware returns middleware that records, for every
+// request handled by the wrapped handler:
+//   - HTTPRequestsTotalMetric, a counter labeled by method, route and status
+//     class ("2xx", "5xx", ...)
+//   - HTTPRequestsInFlightMetric, a gauge of requests currently being served
+//   - HTTPRequestDurationMetric, a timer labeled by method and route
+//
+// The route is taken from ContextWithRoute, then from the pattern matched by
+// http.ServeMux, and falls back to the request path. The counter and timer
+// families are registered with m as custom collectors so they appear in
+// exports; middleware created twice for the same m shares them.
+func NewHTTPMetricsMiddleware(m Metrics, opts ...HTTPMetricsOption) func(http.Handler) http.Handler {
+	options := &httpMetricsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	requests := registerVec(m, NewCounterVec(HTTPRequestsTotalMetric,
+		[]string{"method", "route", "class"}, options.metricOpts...))
+	durations := registerVec(m, NewTimerVec(HTTPRequestDurationMetric,
+		[]string{"method", "route"}, options.metricOpts...))
+	inFlight := m.Gauge(HTTPRequestsInFlightMetric, options.metricOpts...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			route := requestRoute(r, options.normalizePaths)
+
+			requests.WithLabelValues(r.Method, route, StatusClass(sw.status)).Inc()
+			durations.WithLabelValues(r.Method, route).Record(time.Since(start))
+		})
+	}
+}
+
+// registerVec registers a metric family with m. If a collector with the same
+// name and type is already registered, that collector is returned instead so
+// several middleware instances record into one family.
+func registerVec[V CustomCollector](m Metrics, vec V) V {
+	if err := m.RegisterCollector(vec); err == nil {
+		return vec
+	}
+
+	for _, collector := range m.ListCollectors() {
+		if existing, ok := collector.(V); ok && existing.Name() == vec.Name() {
+			return existing
+		}
+	}
+
+	return vec
+}
+
+type routeContextKey struct{}
+
+// ContextWithRoute returns a copy of ctx carrying the route pattern that
+// matched the request. Routers that resolve the route before
+// NewHTTPMetricsMiddleware runs use it to label requests by pattern rather
+// than by path.
+func ContextWithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeContextKey{}, route)
+}
+
+// RouteFromContext returns the route stored by ContextWithRoute.
+func RouteFromContext(ctx context.Context) (string, bool) {
+	route, ok := ctx.Value(routeContextKey{}).(string)
+
+	return route, ok && route != ""
+}
+
+// requestRoute returns the route label for a served request.
+// http.ServeMux sets r.Pattern on the request it is given, so the pattern is
+// visible once the wrapped handler has returned.
+func requestRoute(r *http.Request, normalize bool) string {
+	if route, ok := RouteFromContext(r.Context()); ok {
+		return route
+	}
+
+	if r.Pattern != "" {
+		// Patterns may be prefixed by a method ("GET /users/{id}"), which is
+		// already a label of its own
+		if _, route, ok := strings.Cut(r.Pattern, " "); ok {
+			return strings.TrimSpace(route)
+		}
+
+		return r.Pattern
+	}
+
+	if normalize {
+		return normalizePath(r.URL.Path)
+	}
+
+	return r.URL.Path
+}
+
+// normalizePath replaces every all-digit segment of path with "{id}".
+func normalizePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// statusWriter records the status code written by a handler.
+type statusWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush passes through to the underlying writer so streaming handlers keep
+// working behind the middleware.
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
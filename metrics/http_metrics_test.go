@@ -0,0 +1,191 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMetricsMiddleware(t *testing.T) {
+	collector := NewMetricsCollector("http")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+
+	handler := NewHTTPMetricsMiddleware(collector)(mux)
+
+	for _, path := range []string{"/users/1", "/users/2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	requests := httpRequestsVec(t, collector)
+	assert.InDelta(t, 2.0, requests.WithLabelValues("GET", "/users/{id}", "2xx").Value(), 0)
+	assert.InDelta(t, 1.0, requests.WithLabelValues("POST", "/users", "5xx").Value(), 0)
+
+	durations := httpDurationsVec(t, collector)
+	assert.Equal(t, uint64(2), durations.WithLabelValues("GET", "/users/{id}").Count())
+	assert.Equal(t, uint64(1), durations.WithLabelValues("POST", "/users").Count())
+
+	assert.InDelta(t, 0.0, collector.Gauge(HTTPRequestsInFlightMetric).Value(), 0)
+
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.Contains(t, snapshot.Collectors[HTTPRequestsTotalMetric], "class=2xx,method=GET,route=/users/{id}")
+}
+
+func TestHTTPMetricsMiddleware_PrometheusExport(t *testing.T) {
+	collector := NewMetricsCollector("http")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /a", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /b", func(w http.ResponseWriter, r *http.Request) {})
+
+	handler := NewHTTPMetricsMiddleware(collector)(mux)
+
+	for _, path := range []string{"/a", "/b"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	out := string(data)
+
+	// Label values stay labels of one family of the right type instead of
+	// becoming part of per-route metric names.
+	assert.Equal(t, 1, strings.Count(out, "# TYPE http_requests_total "))
+	assert.Contains(t, out, "# TYPE http_requests_total counter\n")
+	assert.Contains(t, out, `http_requests_total{class="2xx",method="GET",route="/a"} 1`+"\n")
+	assert.Contains(t, out, `http_requests_total{class="2xx",method="GET",route="/b"} 1`+"\n")
+	assert.Contains(t, out, "# TYPE http_request_duration histogram\n")
+	assert.Contains(t, out, `http_request_duration_count{method="GET",route="/a"} 1`+"\n")
+	assert.NotContains(t, out, "http_requests_total_class")
+}
+
+func TestHTTPMetricsMiddleware_InFlight(t *testing.T) {
+	collector := NewMetricsCollector("http")
+
+	var inFlight float64
+
+	handler := NewHTTPMetricsMiddleware(collector)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight = collector.Gauge(HTTPRequestsInFlightMetric).Value()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.InDelta(t, 1.0, inFlight, 0)
+	assert.InDelta(t, 0.0, collector.Gauge(HTTPRequestsInFlightMetric).Value(), 0)
+}
+
+func TestHTTPMetricsMiddleware_RouteFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []HTTPMetricsOption
+		request  func() *http.Request
+		expected string
+	}{
+		{
+			name:     "path",
+			request:  func() *http.Request { return httptest.NewRequest(http.MethodGet, "/orders/42/items/7", nil) },
+			expected: "/orders/42/items/7",
+		},
+		{
+			name:     "normalized path",
+			opts:     []HTTPMetricsOption{WithPathNormalization()},
+			request:  func() *http.Request { return httptest.NewRequest(http.MethodGet, "/orders/42/items/7", nil) },
+			expected: "/orders/{id}/items/{id}",
+		},
+		{
+			name: "context route",
+			opts: []HTTPMetricsOption{WithPathNormalization()},
+			request: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+
+				return r.WithContext(ContextWithRoute(r.Context(), "/orders/:id"))
+			},
+			expected: "/orders/:id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := NewMetricsCollector("http")
+
+			handler := NewHTTPMetricsMiddleware(collector, tt.opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), tt.request())
+
+			requests := httpRequestsVec(t, collector)
+			assert.InDelta(t, 1.0, requests.WithLabelValues("GET", tt.expected, "4xx").Value(), 0)
+		})
+	}
+}
+
+func TestHTTPMetricsMiddleware_SharedFamilies(t *testing.T) {
+	collector := NewMetricsCollector("http")
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	first := NewHTTPMetricsMiddleware(collector)(ok)
+	second := NewHTTPMetricsMiddleware(collector)(ok)
+
+	first.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	second.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.InDelta(t, 2.0, httpRequestsVec(t, collector).WithLabelValues("GET", "/", "2xx").Value(), 0)
+}
+
+func TestHTTPMetricsMiddleware_Flush(t *testing.T) {
+	collector := NewMetricsCollector("http")
+
+	handler := NewHTTPMetricsMiddleware(collector)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, http.NewResponseController(w).Flush())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	assert.True(t, rec.Flushed)
+}
+
+func httpRequestsVec(t *testing.T, m Metrics) *CounterVec {
+	t.Helper()
+
+	for _, collector := range m.ListCollectors() {
+		if vec, ok := collector.(*CounterVec); ok && vec.Name() == HTTPRequestsTotalMetric {
+			return vec
+		}
+	}
+
+	t.Fatalf("%s not registered", HTTPRequestsTotalMetric)
+
+	return nil
+}
+
+func httpDurationsVec(t *testing.T, m Metrics) *TimerVec {
+	t.Helper()
+
+	for _, collector := range m.ListCollectors() {
+		if vec, ok := collector.(*TimerVec); ok && vec.Name() == HTTPRequestDurationMetric {
+			return vec
+		}
+	}
+
+	t.Fatalf("%s not registered", HTTPRequestDurationMetric)
+
+	return nil
+}
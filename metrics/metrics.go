@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"context"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/xraph/go-utils/di"
@@ -42,6 +45,12 @@ type Exemplar struct {
 	Labels    map[string]string `json:"labels,omitempty"`
 }
 
+// GaugePoint is a gauge value retained by WithHistory.
+type GaugePoint struct {
+	Value     float64   `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // =============================================================================
 // METRIC METADATA
 // =============================================================================
@@ -74,7 +83,8 @@ var (
 	}
 
 	// DefaultDurationBuckets are optimized for timer metrics measuring durations.
-	// Values are in milliseconds, covering microsecond to multi-second ranges.
+	// Values are in the timer's unit (milliseconds unless set with
+	// WithTimerUnit), covering microsecond to multi-second ranges.
 	// Suitable for API latency, database query time, and processing duration.
 	DefaultDurationBuckets = []float64{
 		1, 2, 5, 10, 25, 50, 100, 250, 500,
@@ -120,12 +130,22 @@ type MetricsLimits struct {
 	BufferSize int `json:"buffer_size" yaml:"buffer_size"`
 }
 
+// MetricsBuckets configures the bucket boundaries histograms and timers are
+// created with when no bucket option is given. Timer buckets are in the
+// timer's unit. Empty slices keep DefaultHistogramBuckets and
+// DefaultDurationBuckets.
+type MetricsBuckets struct {
+	Histogram []float64 `json:"histogram" yaml:"histogram"`
+	Timer     []float64 `json:"timer"     yaml:"timer"`
+}
+
 // MetricsConfig configures metrics collection.
 type MetricsConfig struct {
 	Enabled    bool                                             `json:"enabled"    yaml:"enabled"`
 	Features   MetricsFeatures                                  `json:"features"   yaml:"features"`
 	Collection MetricsCollection                                `json:"collection" yaml:"collection"`
 	Limits     MetricsLimits                                    `json:"limits"     yaml:"limits"`
+	Buckets    MetricsBuckets                                   `json:"buckets"    yaml:"buckets"`
 	Storage    *MetricsStorageConfig[map[string]any]            `json:"storage"    yaml:"storage"`
 	Exporters  map[string]MetricsExporterConfig[map[string]any] `json:"exporters"  yaml:"exporters"`
 }
@@ -155,6 +175,30 @@ type MetricOptions struct {
 	MaxAge      time.Duration // Sliding window duration for time-based metrics
 	AgeBuckets  uint32        // Number of time-based rotation buckets
 	BufCap      uint32        // Buffer capacity for observations
+	SampleRate  float64       // Fraction of observations recorded, in (0, 1); 0 records all
+
+	// Summary-specific configuration
+	ReservoirSize int // Keep a uniform random sample of this many observations
+
+	// Native (exponential) histogram buckets
+	NativeBuckets bool // Record exponential buckets instead of explicit boundaries
+	NativeSchema  int  // Resolution of native buckets (MinNativeSchema-MaxNativeSchema)
+
+	// Interpolate quantiles linearly within explicit buckets
+	QuantileInterpolation bool
+
+	// Gauge-specific configuration
+	History int // Number of recent values a gauge retains
+
+	// Timer-specific configuration
+	TimerUnit       time.Duration   // Unit timers record in; buckets are expressed in it
+	DurationBuckets []time.Duration // Timer bucket boundaries as durations, overriding Buckets
+
+	// Exporters run by the collector, keyed by name
+	Exporters map[string]Exporter
+
+	// Health manager checked by GatherAll
+	HealthManager HealthManager
 
 	Logger log.Logger
 	Config *MetricsConfig
@@ -174,6 +218,28 @@ func WithLogger(logger log.Logger) MetricOption {
 	}
 }
 
+// WithExporter registers exporter with a metrics collector under name. The
+// collector only exports to it when told to: StartExporters runs it on the
+// interval of its enabled MetricsExporterConfig, and Close runs every
+// registered exporter one final time.
+func WithExporter(name string, exporter Exporter) MetricOption {
+	return func(opts *MetricOptions) {
+		if opts.Exporters == nil {
+			opts.Exporters = make(map[string]Exporter)
+		}
+
+		opts.Exporters[name] = exporter
+	}
+}
+
+// WithHealthManager configures a metrics collector to include a health
+// report from hm in GatherAll.
+func WithHealthManager(hm HealthManager) MetricOption {
+	return func(opts *MetricOptions) {
+		opts.HealthManager = hm
+	}
+}
+
 // WithLabel adds a single label to the metric.
 func WithLabel(key, value string) MetricOption {
 	return func(opts *MetricOptions) {
@@ -242,6 +308,22 @@ func WithConstLabels(labels map[string]string) MetricOption {
 	}
 }
 
+// WithConstLabel adds a single constant label to the metric. Unlike
+// WithConstLabels, it keeps the const labels set so far, including the
+// collector's configured default tags, and only replaces a label with the
+// same key:
+//
+//	collector.Counter("requests_total", metrics.WithConstLabel("instance", "i-123"))
+func WithConstLabel(key, value string) MetricOption {
+	return func(opts *MetricOptions) {
+		labels := make(map[string]string, len(opts.ConstLabels)+1)
+		maps.Copy(labels, opts.ConstLabels)
+		labels[key] = value
+
+		opts.ConstLabels = labels
+	}
+}
+
 // =============================================================================
 // HISTOGRAM OPTIONS
 // =============================================================================
@@ -296,8 +378,32 @@ func WithExponentialBuckets(start, factor float64, count int) MetricOption {
 	}
 }
 
-// WithPercentiles sets the specific percentiles to track for histogram metrics.
-// Percentiles should be between 0.0 and 1.0.
+// WithNativeBuckets switches a histogram to native exponential buckets.
+// Bucket boundaries are powers of 2^(2^-schema) and are allocated on demand,
+// giving a constant relative error across the whole value range. Higher
+// schemas are more precise; the schema is clamped to
+// [MinNativeSchema, MaxNativeSchema]. Explicit buckets are ignored.
+// Example: WithNativeBuckets(3) grows each bucket by a factor of about 1.09.
+func WithNativeBuckets(schema int) MetricOption {
+	return func(opts *MetricOptions) {
+		opts.NativeBuckets = true
+		opts.NativeSchema = schema
+	}
+}
+
+// WithQuantileInterpolation makes histogram (and timer) quantiles interpolate
+// linearly within the bucket that contains the requested rank, instead of
+// returning that bucket's upper boundary. Estimates are clamped to the
+// observed minimum and maximum. Native buckets always interpolate.
+func WithQuantileInterpolation(enabled bool) MetricOption {
+	return func(opts *MetricOptions) {
+		opts.QuantileInterpolation = enabled
+	}
+}
+
+// WithPercentiles sets the specific percentiles to track for summary metrics.
+// Percentiles must be strictly between 0.0 and 1.0; others are dropped with a
+// warning, and the defaults are tracked if none remain.
 // Example: WithPercentiles(0.5, 0.95, 0.99) tracks 50th, 95th, and 99th percentiles.
 func WithPercentiles(percentiles ...float64) MetricOption {
 	return func(opts *MetricOptions) {
@@ -332,6 +438,85 @@ func WithBufCap(capacity uint32) MetricOption {
 	}
 }
 
+// WithSampleRate makes histograms and timers record only a random fraction
+// rate of their observations, for hot paths where recording every one is
+// too expensive. Each recorded observation stands for 1/rate observations,
+// so Count, Sum and bucket counts are upsampled estimates of the true values
+// and quantiles are approximate; Min, Max and exemplars only reflect the
+// recorded observations. Rates outside (0, 1) record every observation,
+// which is the default.
+// Example: WithSampleRate(0.1) records about one observation in ten.
+func WithSampleRate(rate float64) MetricOption {
+	return func(opts *MetricOptions) {
+		if rate > 0 && rate < 1 {
+			opts.SampleRate = rate
+		} else {
+			opts.SampleRate = 0
+		}
+	}
+}
+
+// WithReservoirSampling bounds the observations a summary keeps to a uniform
+// random sample of size values (Vitter's Algorithm R), instead of every value
+// or, with WithBufCap, the most recent ones. Count, Sum, Mean and quantiles
+// still cover every observation, but Min, Max and StdDev become estimates
+// computed from the sample. Non-positive sizes are ignored.
+// Example: WithReservoirSampling(1024).
+func WithReservoirSampling(size int) MetricOption {
+	return func(opts *MetricOptions) {
+		if size > 0 {
+			opts.ReservoirSize = size
+		}
+	}
+}
+
+// WithHistory makes a gauge retain its last n values with their timestamps,
+// readable with Gauge.History, e.g. to draw a sparkline without a
+// time-series backend. Every update is retained, including Add, Inc and Dec,
+// in a fixed ring of n points, so memory stays bounded. Gauges keep no
+// history by default, and non-positive sizes are ignored. The option is
+// ignored by other metric types.
+// Example: WithHistory(60).
+func WithHistory(n int) MetricOption {
+	return func(opts *MetricOptions) {
+		if n > 0 {
+			opts.History = n
+		}
+	}
+}
+
+// WithTimerUnit sets the unit a timer records durations in. Bucket
+// boundaries, including the default duration buckets, are interpreted in
+// this unit, so a microsecond timer resolves sub-millisecond latencies that
+// a millisecond timer would lump into its first bucket. Describe().Unit and
+// Snapshot report the unit. Non-positive units are ignored; the default is
+// time.Millisecond.
+// Example: WithTimerUnit(time.Microsecond).
+func WithTimerUnit(unit time.Duration) MetricOption {
+	return func(opts *MetricOptions) {
+		if unit > 0 {
+			opts.TimerUnit = unit
+		}
+	}
+}
+
+// WithDurationBuckets sets timer bucket boundaries as durations. Each
+// boundary is converted to the timer's unit when the timer is created, so the
+// option can be combined with WithTimerUnit in any order. Boundaries are
+// sorted and duplicates removed; non-positive durations are dropped. The
+// option takes precedence over WithBuckets and is ignored by other metric
+// types.
+// Example: WithDurationBuckets(time.Millisecond, 10*time.Millisecond, time.Second).
+func WithDurationBuckets(buckets ...time.Duration) MetricOption {
+	return func(opts *MetricOptions) {
+		sorted := slices.Sorted(slices.Values(buckets))
+		sorted = slices.Compact(sorted)
+		sorted = slices.DeleteFunc(sorted, func(d time.Duration) bool { return d <= 0 })
+
+		opts.DurationBuckets = sorted
+	}
+}
+
 // =============================================================================
 // COMPOSITE OPTIONS (Convenience functions with sensible defaults)
 // =============================================================================
@@ -346,7 +531,7 @@ func WithDefaultHistogramBuckets() MetricOption {
 }
 
 // WithDefaultTimerBuckets applies sensible bucket boundaries for timer metrics.
-// These buckets are optimized for duration measurements (in milliseconds)
+// These buckets are optimized for duration measurements (in the timer's unit)
 // and are suitable for API latency, database queries, and processing time.
 func WithDefaultTimerBuckets() MetricOption {
 	return func(opts *MetricOptions) {
@@ -381,13 +566,19 @@ type MetricFactory interface {
 	// Gauge creates a gauge metric.
 	Gauge(name string, opts ...MetricOption) Gauge
 
-	// Histogram creates a histogram metric.
+	// Histogram creates a histogram metric, or returns the registered one
+	// with the same name and labels. The first registration fixes the
+	// buckets: a later call that explicitly requests different buckets, or
+	// native instead of explicit buckets, still gets the registered
+	// histogram and is recorded as ErrBucketConflict in the collector's
+	// error stats. Calls without bucket options never conflict.
 	Histogram(name string, opts ...MetricOption) Histogram
 
 	// Summary creates a summary metric.
 	Summary(name string, opts ...MetricOption) Summary
 
-	// Timer creates a timer metric.
+	// Timer creates a timer metric, or returns the registered one with the
+	// same name and labels. Conflicting buckets are handled as for Histogram.
 	Timer(name string, opts ...MetricOption) Timer
 }
 
@@ -396,8 +587,22 @@ type MetricExporter interface {
 	// Export exports metrics in the specified format.
 	Export(format ExportFormat) ([]byte, error)
 
+	// ExportContext exports metrics like Export, but returns ctx.Err() as
+	// soon as ctx is done, e.g. when the request that triggered the export
+	// is aborted, instead of exporting the remaining metrics.
+	ExportContext(ctx context.Context, format ExportFormat) ([]byte, error)
+
 	// ExportToFile exports metrics to a file.
 	ExportToFile(format ExportFormat, filename string) error
+
+	// ExportDelta exports metrics like Export, but counters and timers report
+	// the increase since the previous ExportDelta call instead of cumulative
+	// totals. Gauges, histograms and summaries are exported as-is.
+	ExportDelta(format ExportFormat) ([]byte, error)
+
+	// StartExporters exports to each exporter enabled in the config on its
+	// interval until ctx ends or the collector is stopped or closed.
+	StartExporters(ctx context.Context) error
 }
 
 // CollectorRegistry manages custom metric collectors.
@@ -410,6 +615,17 @@ type CollectorRegistry interface {
 
 	// ListCollectors returns all registered collectors.
 	ListCollectors() []CustomCollector
+
+	// EnableCollector re-enables a collector disabled with DisableCollector.
+	EnableCollector(name string) error
+
+	// DisableCollector skips a registered collector during collection
+	// without unregistering it.
+	DisableCollector(name string) error
+
+	// ListActiveCollectors returns the registered collectors that are
+	// currently collected.
+	ListActiveCollectors() []CustomCollector
 }
 
 // MetricRepository provides queries and introspection of metrics.
@@ -423,8 +639,34 @@ type MetricRepository interface {
 	// ListMetricsByTag returns metrics filtered by tag.
 	ListMetricsByTag(tagKey, tagValue string) map[string]any
 
+	// ListFamilies returns all metrics grouped by fully qualified name and
+	// type, each family listing its label variants.
+	ListFamilies() []MetricFamily
+
+	// MetricNames returns the sorted names of all registered metrics
+	// without exposing metric handles.
+	MetricNames() []string
+
+	// Snapshot returns the current values of all metrics keyed by their fully
+	// qualified name, followed by their labels for label variants. The set of
+	// metrics cannot change while it is captured.
+	Snapshot() map[string]MetricSnapshotEntry
+
 	// Stats returns collector statistics.
 	Stats() CollectorStats
+
+	// GatherAll returns the collector statistics together with a health
+	// report from the HealthManager set with WithHealthManager, so a single
+	// scrape covers both. The report is nil when no health manager is set.
+	GatherAll(ctx context.Context) (CollectorStats, *HealthReport)
+
+	// Aggregate returns the sum of a counter or gauge across all of its
+	// label variants.
+	Aggregate(name string) (float64, error)
+
+	// AggregateHistogram merges a histogram and all of its label variants,
+	// adding bucket counts per boundary.
+	AggregateHistogram(name string) (HistogramAggregate, error)
 }
 
 // MetricManager handles metric lifecycle and configuration.
@@ -437,6 +679,11 @@ type MetricManager interface {
 
 	// Reload reloads the metrics configuration at runtime.
 	Reload(config *MetricsConfig) error
+
+	// Close stops the background work of registered collectors, runs every
+	// configured exporter one final time and leaves the metrics unusable:
+	// factories return no-op metrics afterwards. Calling it again is a no-op.
+	Close(ctx context.Context) error
 }
 
 // Metrics is the composite interface providing full metrics functionality.
@@ -505,12 +752,28 @@ type Gauge interface {
 	// SetToCurrentTime sets the gauge to the current Unix timestamp in seconds.
 	SetToCurrentTime()
 
+	// SetToCurrentTimeNanos sets the gauge to the current Unix timestamp in
+	// nanoseconds.
+	SetToCurrentTimeNanos()
+
+	// SetTime sets the gauge to t as a Unix timestamp in nanoseconds.
+	// A float64 holds current timestamps to within 256ns.
+	SetTime(t time.Time)
+
+	// TimeValue returns the gauge value read as a Unix timestamp in
+	// nanoseconds, as set by SetTime or SetToCurrentTimeNanos.
+	TimeValue() time.Time
+
 	// Value returns the current gauge value.
 	Value() float64
 
 	// Timestamp returns the time of the last update.
 	Timestamp() time.Time
 
+	// History returns the most recent values, oldest first, as retained with
+	// WithHistory. It is nil for gauges created without it.
+	History() []GaugePoint
+
 	// Describe returns metadata about this gauge.
 	Describe() MetricMetadata
 
@@ -563,6 +826,11 @@ type Histogram interface {
 	// the cumulative counts of observations that fall into each bucket.
 	Buckets() map[float64]uint64
 
+	// NativeBuckets returns a snapshot of the exponential buckets of a
+	// histogram created with WithNativeBuckets, or nil for histograms
+	// using explicit bucket boundaries.
+	NativeBuckets() *NativeHistogram
+
 	// Exemplars returns recent exemplars recorded with this histogram.
 	// Returns up to the last N exemplars (implementation-defined).
 	Exemplars() []Exemplar
@@ -595,10 +863,21 @@ type HistogramWithStats interface {
 	HistogramStats
 }
 
-// TimedHistogram supports time-based observations.
+// DurationObserver records the time elapsed since a start time. Histograms
+// and timers both implement it, so code timing an operation can accept
+// either: defer o.ObserveDuration(time.Now()).
+type DurationObserver interface {
+	// ObserveDuration records time.Since(start).
+	ObserveDuration(start time.Time)
+}
+
+// TimedHistogram supports time-based observations. The histograms returned
+// by the factories implement it, observing durations in the unit set with
+// WithUnit. Timers implement DurationObserver but not TimedHistogram, since
+// their statistics are durations rather than float64 values.
 type TimedHistogram interface {
 	Histogram
-	ObserveDuration(start time.Time)
+	DurationObserver
 }
 
 // =============================================================================
@@ -644,6 +923,10 @@ type Summary interface {
 	// StdDev returns the standard deviation of observations.
 	StdDev() float64
 
+	// Objectives returns the tracked quantiles mapped to their allowed
+	// error margin, e.g. {0.5: 0.01, 0.99: 0.01}.
+	Objectives() map[float64]float64
+
 	// Describe returns metadata about this summary.
 	Describe() MetricMetadata
 
@@ -668,6 +951,10 @@ type Timer interface {
 	// Usage: defer timer.Time()()
 	Time() func()
 
+	// ObserveDuration records the time elapsed since start.
+	// Usage: defer timer.ObserveDuration(time.Now())
+	ObserveDuration(start time.Time)
+
 	// Count returns the total number of recorded durations.
 	Count() uint64
 
@@ -699,6 +986,19 @@ type Timer interface {
 	// Alias for Percentile for consistency with Histogram/Summary.
 	Quantile(q float64) time.Duration
 
+	// Buckets returns a map of bucket upper bounds to the number of
+	// recorded durations that fell into each bucket.
+	Buckets() map[time.Duration]uint64
+
+	// CumulativeBuckets returns a map of bucket upper bounds to the number
+	// of recorded durations less than or equal to each bound.
+	CumulativeBuckets() map[time.Duration]uint64
+
+	// Histogram returns a read-only view of the histogram the timer records
+	// into, with values in the timer's unit. Observations and Reset through
+	// the view have no effect on the timer.
+	Histogram() Histogram
+
 	// Exemplars returns recent exemplars recorded with this timer.
 	// Returns up to the last N exemplars (implementation-defined).
 	Exemplars() []Exemplar
@@ -727,6 +1027,13 @@ type ToggleableCollector interface {
 	IsEnabled() bool
 }
 
+// ClosableCollector is a collector that runs background work, such as a
+// polling loop, which Close on the owning Metrics stops.
+type ClosableCollector interface {
+	CustomCollector
+	Close(ctx context.Context) error
+}
+
 // =============================================================================
 // EXPORTER INTERFACE
 // =============================================================================
@@ -743,6 +1050,16 @@ type Exporter interface {
 	Stats() ExporterStats
 }
 
+// ContextExporter is an Exporter whose export can be bounded by a context.
+// The metrics collector calls ExportContext instead of Export when an
+// exporter implements it, so exports end with the collector's context.
+type ContextExporter interface {
+	Exporter
+
+	// ExportContext is like Export, but bounded by ctx.
+	ExportContext(ctx context.Context, metrics map[string]any) ([]byte, error)
+}
+
 // ExporterStats contains statistics about a metrics exporter.
 type ExporterStats struct {
 	Format string `json:"format"`
@@ -802,10 +1119,11 @@ type CollectorStats struct {
 	ActiveCustomCollectors int `json:"active_custom_collectors"` // Currently active custom collectors
 
 	// Resource usage
-	DroppedMetrics int64  `json:"dropped_metrics"` // Metrics dropped due to buffer limits
-	MemoryUsage    uint64 `json:"memory_usage"`    // Approximate memory usage in bytes
-	BufferSize     int    `json:"buffer_size"`     // Current buffer size
-	BufferCapacity int    `json:"buffer_capacity"` // Maximum buffer capacity
+	DroppedMetrics      int64  `json:"dropped_metrics"`      // Metrics dropped due to buffer limits
+	DroppedObservations int64  `json:"dropped_observations"` // Histogram and timer observations rejected as NaN or infinite
+	MemoryUsage         uint64 `json:"memory_usage"`         // Approximate memory usage in bytes
+	BufferSize          int    `json:"buffer_size"`          // Current buffer size
+	BufferCapacity      int    `json:"buffer_capacity"`      // Maximum buffer capacity
 
 	// Cardinality tracking
 	LabelCardinality    int `json:"label_cardinality"`     // Current unique label combinations
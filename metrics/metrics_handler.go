@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/xraph/go-utils/log"
+)
+
+// =============================================================================
+// METRICS ENDPOINT
+// =============================================================================
+
+// PrometheusContentType is the content type of the Prometheus text format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// MetricsHandlerOption configures a handler created by NewMetricsHandler.
+type MetricsHandlerOption func(*metricsHandlerOptions)
+
+type metricsHandlerOptions struct {
+	username string
+	password string
+	auth     bool
+	logger   log.Logger
+}
+
+// WithBasicAuth requires requests to the handler to authenticate with HTTP
+// basic auth using username and password. Other requests are answered with
+// 401 Unauthorized.
+func WithBasicAuth(username, password string) MetricsHandlerOption {
+	return func(o *metricsHandlerOptions) {
+		o.username = username
+		o.password = password
+		o.auth = true
+	}
+}
+
+// WithHandlerLogger sets the logger failed exports are logged to. It defaults
+// to the global logger.
+func WithHandlerLogger(logger log.Logger) MetricsHandlerOption {
+	return func(o *metricsHandlerOptions) {
+		o.logger = logger
+	}
+}
+
+// NewMetricsHandler returns an HTTP handler for a /metrics endpoint that
+// serves the export of m in the format picked from the Accept header:
+// application/json gets ExportFormatJSON, while text/plain,
+// application/openmetrics-text and anything else get ExportFormatPrometheus,
+// the format scrapers expect. Media ranges are weighed by their q values.
+// The export is bound to the request context; if it fails, the error is
+// logged and the handler responds with a bare 500, keeping internal details
+// away from scrapers.
+//
+//	mux.Handle("/metrics", metrics.NewMetricsHandler(collector,
+//	    metrics.WithBasicAuth("scraper", os.Getenv("METRICS_PASSWORD"))))
+func NewMetricsHandler(m Metrics, opts ...MetricsHandlerOption) http.Handler {
+	options := &metricsHandlerOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.logger == nil {
+		options.logger = log.GetGlobalLogger()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if options.auth && !options.authorized(r) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics", charset="UTF-8"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+			return
+		}
+
+		format := negotiateExportFormat(r.Header.Get("Accept"))
+
+		data, err := m.ExportContext(r.Context(), format)
+		if err != nil {
+			options.logger.Error("metrics export failed", log.String("format", string(format)), log.Error(err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		if format == ExportFormatJSON {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", PrometheusContentType)
+		}
+
+		_, _ = w.Write(data)
+	})
+}
+
+// authorized reports whether r carries the configured basic auth
+// credentials. Both are compared in constant time.
+func (o *metricsHandlerOptions) authorized(r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(o.username))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(o.password))
+
+	return usernameMatch&passwordMatch == 1
+}
+
+// negotiateExportFormat returns the export format preferred by an Accept
+// header: the supported media range with the highest q value, the earliest
+// one on ties. ExportFormatPrometheus is the fallback.
+func negotiateExportFormat(accept string) ExportFormat {
+	format := ExportFormatPrometheus
+	best := 0.0
+
+	for mediaRange := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		var candidate ExportFormat
+
+		switch mediaType {
+		case "application/json":
+			candidate = ExportFormatJSON
+		case "text/plain", "application/openmetrics-text":
+			candidate = ExportFormatPrometheus
+		default:
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		if q > best {
+			format, best = candidate, q
+		}
+	}
+
+	return format
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xraph/go-utils/log"
+)
+
+func TestNewMetricsHandler_Accept(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Add(3)
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{name: "no accept header", contentType: PrometheusContentType},
+		{name: "any", accept: "*/*", contentType: PrometheusContentType},
+		{name: "text plain", accept: "text/plain; version=0.0.4", contentType: PrometheusContentType},
+		{name: "openmetrics", accept: "application/openmetrics-text; version=1.0.0", contentType: PrometheusContentType},
+		{name: "json", accept: "application/json", contentType: "application/json"},
+		{name: "json preferred by q", accept: "text/plain;q=0.5, application/json", contentType: "application/json"},
+		{name: "text preferred by q", accept: "application/json;q=0.2, text/plain;q=0.9", contentType: PrometheusContentType},
+		{name: "unsupported", accept: "application/xml", contentType: PrometheusContentType},
+		{name: "json refused", accept: "application/json;q=0", contentType: PrometheusContentType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rec := httptest.NewRecorder()
+			NewMetricsHandler(collector).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.contentType, rec.Header().Get("Content-Type"))
+
+			if tt.contentType == "application/json" {
+				snapshot, err := ParseJSONExport(rec.Body.Bytes())
+				require.NoError(t, err)
+				assert.InDelta(t, 3.0, snapshot.Counters["requests_total"].Value, 0)
+			} else {
+				assert.Contains(t, rec.Body.String(), "requests_total 3\n")
+				assert.False(t, json.Valid(rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestNewMetricsHandler_ExportError(t *testing.T) {
+	mock := NewMockMetrics()
+	mock.ExportContextFunc = func(ctx context.Context, format ExportFormat) ([]byte, error) {
+		return nil, errors.New("export failed")
+	}
+
+	logger := log.NewTestLogger()
+
+	rec := httptest.NewRecorder()
+	NewMetricsHandler(mock, WithHandlerLogger(logger)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// The error is logged, not sent to the client
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "export failed")
+	assert.True(t, logger.(*log.TestLogger).AssertHasLog("ERROR", "metrics export failed"))
+}
+
+func TestNewMetricsHandler_BasicAuth(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Inc()
+
+	handler := NewMetricsHandler(collector, WithBasicAuth("scraper", "s3cret"))
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		noAuth   bool
+		status   int
+	}{
+		{name: "valid credentials", username: "scraper", password: "s3cret", status: http.StatusOK},
+		{name: "wrong password", username: "scraper", password: "guess", status: http.StatusUnauthorized},
+		{name: "wrong username", username: "admin", password: "s3cret", status: http.StatusUnauthorized},
+		{name: "no credentials", noAuth: true, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if !tt.noAuth {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+
+			if tt.status == http.StatusUnauthorized {
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+				assert.NotContains(t, rec.Body.String(), "requests_total")
+			} else {
+				assert.Contains(t, rec.Body.String(), "requests_total 1\n")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// METRICS HEALTH CHECK
+// =============================================================================
+
+// MetricsHealthCheckName is the name of the health check returned by
+// NewMetricsHealthCheck.
+const MetricsHealthCheckName = "metrics"
+
+// MetricsHealthCheck is a HealthCheck reporting the health of a metrics
+// collector, so the metrics subsystem can be registered with a HealthManager
+// like any other dependency.
+type MetricsHealthCheck struct {
+	metrics  Metrics
+	critical bool
+}
+
+var _ HealthCheck = (*MetricsHealthCheck)(nil)
+
+// NewMetricsHealthCheck returns a non-critical health check for m. Check is
+// unhealthy when m.Health fails, e.g. because the collector is not started
+// or closed, or when its Stats report it degraded; healthy otherwise.
+func NewMetricsHealthCheck(m Metrics) *MetricsHealthCheck {
+	return &MetricsHealthCheck{metrics: m}
+}
+
+// WithCritical sets whether a failing check impacts the overall health.
+func (c *MetricsHealthCheck) WithCritical(critical bool) *MetricsHealthCheck {
+	c.critical = critical
+
+	return c
+}
+
+// Name returns MetricsHealthCheckName.
+func (c *MetricsHealthCheck) Name() string {
+	return MetricsHealthCheckName
+}
+
+// Check reports the collector's health, with its active metric count, error
+// count and last error as details.
+func (c *MetricsHealthCheck) Check(ctx context.Context) *HealthResult {
+	start := time.Now()
+	err := c.metrics.Health(ctx)
+	stats := c.metrics.Stats()
+
+	result := NewHealthResult(c.Name(), HealthStatusHealthy, "metrics collector is healthy").
+		WithCritical(c.critical).
+		WithDetail("active_metrics", stats.ActiveMetrics).
+		WithDetail("error_count", stats.ErrorCount).
+		WithDetail("last_error", stats.LastError)
+
+	switch {
+	case err != nil:
+		result.WithMessage("metrics collector is unavailable").WithError(err)
+	case stats.Degraded:
+		result.WithStatus(HealthStatusUnhealthy).WithMessage("metrics collector is degraded")
+	}
+
+	return result.WithDuration(time.Since(start))
+}
+
+// Timeout returns 0, leaving the timeout to the health manager.
+func (c *MetricsHealthCheck) Timeout() time.Duration {
+	return 0
+}
+
+// Critical reports whether the check was marked critical with WithCritical.
+func (c *MetricsHealthCheck) Critical() bool {
+	return c.critical
+}
+
+// Dependencies returns nil; the check has no dependencies.
+func (c *MetricsHealthCheck) Dependencies() []string {
+	return nil
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsHealthCheck_Started(t *testing.T) {
+	collector := NewMetricsCollector("health")
+	require.NoError(t, collector.Start(context.Background()))
+
+	collector.Counter("jobs_total").Inc()
+	collector.Gauge("queue_depth").Set(3)
+
+	check := NewMetricsHealthCheck(collector)
+	assert.Equal(t, MetricsHealthCheckName, check.Name())
+	assert.False(t, check.Critical())
+
+	result := check.Check(context.Background())
+	assert.Equal(t, HealthStatusHealthy, result.Status)
+	assert.False(t, result.Critical)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, 2, result.Details["active_metrics"])
+	assert.Empty(t, result.Details["last_error"])
+}
+
+func TestMetricsHealthCheck_Stopped(t *testing.T) {
+	collector := NewMetricsCollector("health")
+
+	check := NewMetricsHealthCheck(collector).WithCritical(true)
+	assert.True(t, check.Critical())
+
+	result := check.Check(context.Background())
+	assert.Equal(t, HealthStatusUnhealthy, result.Status)
+	assert.True(t, result.Critical)
+	assert.Equal(t, ErrNotStarted.Error(), result.Error)
+
+	require.NoError(t, collector.Start(context.Background()))
+	require.NoError(t, collector.Close(context.Background()))
+
+	result = check.Check(context.Background())
+	assert.Equal(t, HealthStatusUnhealthy, result.Status)
+	assert.Equal(t, ErrMetricsClosed.Error(), result.Error)
+}
+
+func TestMetricsHealthCheck_Degraded(t *testing.T) {
+	mock := NewMockMetrics()
+	mock.StatsFunc = func() CollectorStats {
+		return CollectorStats{
+			Started:       true,
+			ActiveMetrics: 4,
+			ErrorCount:    7,
+			LastError:     "label cardinality limit exceeded",
+			Degraded:      true,
+		}
+	}
+
+	result := NewMetricsHealthCheck(mock).Check(context.Background())
+	assert.Equal(t, HealthStatusUnhealthy, result.Status)
+	assert.Equal(t, "metrics collector is degraded", result.Message)
+	assert.Equal(t, 4, result.Details["active_metrics"])
+	assert.Equal(t, int64(7), result.Details["error_count"])
+	assert.Equal(t, "label cardinality limit exceeded", result.Details["last_error"])
+}
+
+func TestMetricsHealthCheck_Manager(t *testing.T) {
+	collector := NewMetricsCollector("health")
+	manager := NewHealthManager()
+
+	require.NoError(t, manager.Register(NewMetricsHealthCheck(collector)))
+
+	// A failing non-critical check does not fail the report
+	report := manager.Check(context.Background())
+	assert.NotEqual(t, HealthStatusUnhealthy, report.Overall)
+	assert.Equal(t, HealthStatusUnhealthy, report.Services[MetricsHealthCheckName].Status)
+}
@@ -2,8 +2,12 @@ package metrics
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"maps"
 	"math"
+	"math/rand/v2"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -20,12 +24,13 @@ import (
 
 // Ensure all metric implementations satisfy their respective interfaces.
 var (
-	_ Counter   = (*counterImpl)(nil)
-	_ Gauge     = (*gaugeImpl)(nil)
-	_ Histogram = (*histogramImpl)(nil)
-	_ Summary   = (*summaryImpl)(nil)
-	_ Timer     = (*timerImpl)(nil)
-	_ Metrics   = (*metricsCollector)(nil)
+	_ Counter        = (*counterImpl)(nil)
+	_ Gauge          = (*gaugeImpl)(nil)
+	_ Histogram      = (*histogramImpl)(nil)
+	_ TimedHistogram = (*histogramImpl)(nil)
+	_ Summary        = (*summaryImpl)(nil)
+	_ Timer          = (*timerImpl)(nil)
+	_ Metrics        = (*metricsCollector)(nil)
 )
 
 // =============================================================================
@@ -44,6 +49,9 @@ type metricCore struct {
 	constLabels map[string]string
 	labels      map[string]string
 	timestamp   atomic.Value // stores time.Time
+
+	opts  []MetricOption    // Options the metric was created with, reused by WithLabels
+	owner *metricsCollector // Collector the metric is registered with; nil if standalone
 }
 
 // newMetricCore creates a new metric core with options applied.
@@ -62,6 +70,7 @@ func newMetricCore(name string, metricType MetricType, opts ...MetricOption) *me
 		subsystem:   options.Subsystem,
 		constLabels: options.ConstLabels,
 		labels:      options.Labels,
+		opts:        slices.Clip(opts),
 	}
 
 	mc.timestamp.Store(time.Now())
@@ -82,37 +91,31 @@ func (mc *metricCore) describe() MetricMetadata {
 		Namespace:   mc.namespace,
 		Subsystem:   mc.subsystem,
 		ConstLabels: mc.constLabels,
+		Labels:      maps.Clone(mc.labels),
 	}
 }
 
-// fullName returns the fully qualified metric name.
+// fullName returns the fully qualified metric name: namespace, subsystem and
+// name joined with underscores. Empty namespace or subsystem parts are
+// skipped, so no leading or doubled underscores are produced.
 func (mc *metricCore) fullName() string {
-	parts := make([]string, 0, 3)
-	if mc.namespace != "" {
-		parts = append(parts, mc.namespace)
-	}
+	return qualifiedName(mc.namespace, mc.subsystem, mc.name)
+}
 
-	if mc.subsystem != "" {
-		parts = append(parts, mc.subsystem)
+// qualifiedName joins the non-empty namespace and subsystem with name.
+func qualifiedName(namespace, subsystem, name string) string {
+	parts := make([]string, 0, 3)
+	if namespace != "" {
+		parts = append(parts, namespace)
 	}
 
-	parts = append(parts, mc.name)
-
-	name := ""
-
-	var nameSb84 strings.Builder
-
-	for i, part := range parts {
-		if i > 0 {
-			nameSb84.WriteString("_")
-		}
-
-		nameSb84.WriteString(part)
+	if subsystem != "" {
+		parts = append(parts, subsystem)
 	}
 
-	name += nameSb84.String()
+	parts = append(parts, name)
 
-	return name
+	return strings.Join(parts, "_")
 }
 
 // updateTimestamp updates the timestamp to current time.
@@ -125,6 +128,77 @@ func (mc *metricCore) getTimestamp() time.Time {
 	return mc.timestamp.Load().(time.Time)
 }
 
+// core returns the shared fields of a metric implementation.
+func (mc *metricCore) core() *metricCore {
+	return mc
+}
+
+// labeledVariant implements WithLabels for every metric type. The variant is
+// created with the parent's options, so it shares its metadata and
+// configuration, and carries the parent's labels overridden by labels.
+//
+// Variants of a metric registered with a collector are registered with the
+// same collector under the family name and a label fingerprint: asking twice
+// for the same labels returns the same variant, and every variant is listed
+// and exported. If the collector's cardinality limit is reached the parent is
+// returned instead. Variants of standalone metrics are standalone.
+func labeledVariant[M interface{ core() *metricCore }](
+	parent M,
+	labels map[string]string,
+	registry func(*metricsCollector) map[string]M,
+	create func(opts ...MetricOption) M,
+) M {
+	pc := parent.core()
+
+	pc.mu.RLock()
+	merged := maps.Clone(pc.labels)
+	pc.mu.RUnlock()
+
+	if merged == nil {
+		merged = make(map[string]string, len(labels))
+	}
+
+	maps.Copy(merged, labels)
+
+	if maps.Equal(merged, pc.labels) {
+		return parent
+	}
+
+	opts := append(slices.Clip(pc.opts), WithLabels(merged))
+
+	owner := pc.owner
+	if owner == nil {
+		return create(opts...)
+	}
+
+	family := pc.fullName()
+	key := family + "{" + TagsToString(merged) + "}"
+
+	owner.mu.Lock()
+	defer owner.mu.Unlock()
+
+	metrics := registry(owner)
+	if variant, exists := metrics[key]; exists {
+		return variant
+	}
+
+	if err := validateLabelKeys(labels); err != nil {
+		owner.recordError(err, family)
+
+		return parent
+	}
+
+	if err := owner.recordCardinality(family, merged); err != nil {
+		return parent
+	}
+
+	variant := create(opts...)
+	variant.core().owner = owner
+	metrics[key] = variant
+
+	return variant
+}
+
 // =============================================================================
 // EXEMPLAR STORE - Lock-free ring buffer for exemplars
 // =============================================================================
@@ -171,7 +245,7 @@ func (es *exemplarStore) GetAll() []Exemplar {
 type counterImpl struct {
 	*metricCore
 
-	value     atomic.Uint64 // stores float64 bits
+	value     AtomicFloat64
 	exemplars *exemplarStore
 }
 
@@ -192,18 +266,8 @@ func (c *counterImpl) Add(delta float64) {
 		return // Counters can't decrease
 	}
 
-	for {
-		oldBits := c.value.Load()
-		oldVal := math.Float64frombits(oldBits)
-		newVal := oldVal + delta
-		newBits := math.Float64bits(newVal)
-
-		if c.value.CompareAndSwap(oldBits, newBits) {
-			c.updateTimestamp()
-
-			break
-		}
-	}
+	c.value.Add(delta)
+	c.updateTimestamp()
 }
 
 func (c *counterImpl) AddWithExemplar(delta float64, exemplar Exemplar) {
@@ -212,7 +276,7 @@ func (c *counterImpl) AddWithExemplar(delta float64, exemplar Exemplar) {
 }
 
 func (c *counterImpl) Value() float64 {
-	return math.Float64frombits(c.value.Load())
+	return c.value.Load()
 }
 
 func (c *counterImpl) Timestamp() time.Time {
@@ -228,18 +292,12 @@ func (c *counterImpl) Describe() MetricMetadata {
 }
 
 func (c *counterImpl) WithLabels(labels map[string]string) Counter {
-	// Create a new counter with merged labels
-	NewCounter := NewCounter(c.name, WithLabels(labels))
-	NewCounter.description = c.description
-	NewCounter.unit = c.unit
-	NewCounter.namespace = c.namespace
-	NewCounter.subsystem = c.subsystem
-
-	return NewCounter
+	return labeledVariant(c, labels, func(mc *metricsCollector) map[string]*counterImpl { return mc.counters },
+		func(opts ...MetricOption) *counterImpl { return NewCounter(c.name, opts...) })
 }
 
 func (c *counterImpl) Reset() error {
-	c.value.Store(0)
+	c.value.Set(0)
 	c.updateTimestamp()
 
 	return nil
@@ -253,19 +311,32 @@ func (c *counterImpl) Reset() error {
 type gaugeImpl struct {
 	*metricCore
 
-	value atomic.Uint64 // stores float64 bits
+	value   AtomicFloat64
+	history *gaugeHistory // nil unless created WithHistory
 }
 
 // NewGauge creates a new gauge.
 func NewGauge(name string, opts ...MetricOption) *gaugeImpl {
-	return &gaugeImpl{
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	g := &gaugeImpl{
 		metricCore: newMetricCore(name, MetricTypeGauge, opts...),
 	}
+
+	if options.History > 0 {
+		g.history = &gaugeHistory{points: make([]GaugePoint, 0, options.History)}
+	}
+
+	return g
 }
 
 func (g *gaugeImpl) Set(value float64) {
-	g.value.Store(math.Float64bits(value))
+	g.value.Set(value)
 	g.updateTimestamp()
+	g.history.record(value)
 }
 
 func (g *gaugeImpl) Inc() {
@@ -277,18 +348,9 @@ func (g *gaugeImpl) Dec() {
 }
 
 func (g *gaugeImpl) Add(delta float64) {
-	for {
-		oldBits := g.value.Load()
-		oldVal := math.Float64frombits(oldBits)
-		newVal := oldVal + delta
-		newBits := math.Float64bits(newVal)
-
-		if g.value.CompareAndSwap(oldBits, newBits) {
-			g.updateTimestamp()
-
-			break
-		}
-	}
+	newVal := g.value.Add(delta)
+	g.updateTimestamp()
+	g.history.record(newVal)
 }
 
 func (g *gaugeImpl) Sub(delta float64) {
@@ -300,8 +362,20 @@ func (g *gaugeImpl) SetToCurrentTime() {
 	g.Set(float64(now.Unix()))
 }
 
+func (g *gaugeImpl) SetToCurrentTimeNanos() {
+	g.SetTime(time.Now())
+}
+
+func (g *gaugeImpl) SetTime(t time.Time) {
+	g.Set(float64(t.UnixNano()))
+}
+
+func (g *gaugeImpl) TimeValue() time.Time {
+	return time.Unix(0, int64(g.Value()))
+}
+
 func (g *gaugeImpl) Value() float64 {
-	return math.Float64frombits(g.value.Load())
+	return g.value.Load()
 }
 
 func (g *gaugeImpl) Timestamp() time.Time {
@@ -312,23 +386,79 @@ func (g *gaugeImpl) Describe() MetricMetadata {
 	return g.describe()
 }
 
-func (g *gaugeImpl) WithLabels(labels map[string]string) Gauge {
-	NewGauge := NewGauge(g.name, WithLabels(labels))
-	NewGauge.description = g.description
-	NewGauge.unit = g.unit
-	NewGauge.namespace = g.namespace
-	NewGauge.subsystem = g.subsystem
+// History returns the retained values, oldest first. It is nil unless the
+// gauge was created WithHistory.
+func (g *gaugeImpl) History() []GaugePoint {
+	return g.history.snapshot()
+}
 
-	return NewGauge
+func (g *gaugeImpl) WithLabels(labels map[string]string) Gauge {
+	return labeledVariant(g, labels, func(mc *metricsCollector) map[string]*gaugeImpl { return mc.gauges },
+		func(opts ...MetricOption) *gaugeImpl { return NewGauge(g.name, opts...) })
 }
 
+// Reset sets the gauge to zero and clears its history.
 func (g *gaugeImpl) Reset() error {
-	g.value.Store(0)
+	g.value.Set(0)
 	g.updateTimestamp()
+	g.history.clear()
 
 	return nil
 }
 
+// gaugeHistory is a fixed-size ring of the most recent gauge values. Once
+// full, each new value overwrites the oldest. A nil history records nothing.
+type gaugeHistory struct {
+	mu     sync.Mutex
+	points []GaugePoint
+	next   int // Index the next point is written to once points is full
+}
+
+func (h *gaugeHistory) record(value float64) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// The time is taken under the lock so timestamps follow the ring order
+	point := GaugePoint{Value: value, Timestamp: time.Now()}
+
+	if len(h.points) < cap(h.points) {
+		h.points = append(h.points, point)
+
+		return
+	}
+
+	h.points[h.next] = point
+	h.next = (h.next + 1) % len(h.points)
+}
+
+// snapshot returns the points oldest first.
+func (h *gaugeHistory) snapshot() []GaugePoint {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return slices.Concat(h.points[h.next:], h.points[:h.next])
+}
+
+func (h *gaugeHistory) clear() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.points = h.points[:0]
+	h.next = 0
+}
+
 // =============================================================================
 // HISTOGRAM IMPLEMENTATION
 // =============================================================================
@@ -340,99 +470,129 @@ type histogramImpl struct {
 	mu        sync.RWMutex
 	buckets   []float64       // Sorted bucket boundaries
 	counts    []atomic.Uint64 // Bucket counts
-	sum       atomic.Uint64   // Sum of observations (float64 bits)
+	sum       AtomicFloat64   // Sum of observations
 	count     atomic.Uint64   // Total count
-	min       atomic.Uint64   // Minimum value (float64 bits)
-	max       atomic.Uint64   // Maximum value (float64 bits)
+	min       AtomicFloat64   // Minimum value
+	max       AtomicFloat64   // Maximum value
+	native    *nativeBuckets  // Exponential buckets, nil unless WithNativeBuckets
 	exemplars *exemplarStore
+
+	interpolate bool          // Interpolate quantiles within buckets
+	dropped     atomic.Uint64 // Observations rejected as NaN or infinite
+	sampleRate  float64       // Fraction of observations recorded, 0 if all are
 }
 
-// NewHistogram creates a new histogram.
+// NewHistogram creates a new histogram. Bucket boundaries are sorted, with
+// duplicates and non-finite values removed; if none remain,
+// DefaultHistogramBuckets are used.
 func NewHistogram(name string, opts ...MetricOption) *histogramImpl {
 	options := &MetricOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
 
-	buckets := options.Buckets
-	if len(buckets) == 0 {
-		buckets = DefaultHistogramBuckets
+	sortedBuckets, _ := cleanBuckets(options.Buckets)
+	if len(sortedBuckets) == 0 {
+		sortedBuckets = slices.Clone(DefaultHistogramBuckets)
 	}
 
-	// Ensure buckets are sorted
-	sortedBuckets := make([]float64, len(buckets))
-	copy(sortedBuckets, buckets)
-	sort.Float64s(sortedBuckets)
-
 	counts := make([]atomic.Uint64, len(sortedBuckets)+1) // +1 for +Inf bucket
 
 	h := &histogramImpl{
-		metricCore: newMetricCore(name, MetricTypeHistogram, opts...),
-		buckets:    sortedBuckets,
-		counts:     counts,
-		exemplars:  newExemplarStore(),
+		metricCore:  newMetricCore(name, MetricTypeHistogram, opts...),
+		buckets:     sortedBuckets,
+		counts:      counts,
+		exemplars:   newExemplarStore(),
+		interpolate: options.QuantileInterpolation,
+		sampleRate:  options.SampleRate,
+	}
+
+	// Native histograms replace explicit boundaries with exponential buckets
+	if options.NativeBuckets {
+		h.buckets = nil
+		h.counts = make([]atomic.Uint64, 1)
+		h.native = newNativeBuckets(options.NativeSchema)
 	}
 
-	// Initialize min to max float64, max to 0
-	h.min.Store(math.Float64bits(math.MaxFloat64))
-	h.max.Store(0)
+	// Initialize min and max to the extremes so negative observations register
+	h.min.Set(math.MaxFloat64)
+	h.max.Set(-math.MaxFloat64)
 
 	return h
 }
 
+// cleanBuckets returns buckets sorted, without duplicates and without NaN or
+// infinite boundaries, which would break the bucket search; +Inf is implied
+// by the overflow bucket. corrected reports whether anything was removed.
+func cleanBuckets(buckets []float64) (cleaned []float64, corrected bool) {
+	cleaned = slices.DeleteFunc(slices.Clone(buckets), func(b float64) bool {
+		return math.IsNaN(b) || math.IsInf(b, 0)
+	})
+
+	slices.Sort(cleaned)
+	cleaned = slices.Compact(cleaned)
+
+	return cleaned, len(cleaned) != len(buckets)
+}
+
 func (h *histogramImpl) Observe(value float64) {
 	h.ObserveWithExemplar(value, Exemplar{})
 }
 
-func (h *histogramImpl) ObserveWithExemplar(value float64, exemplar Exemplar) {
-	// Update count
-	h.count.Add(1)
+// ObserveDuration observes the time elapsed since start in the histogram's
+// unit: nanoseconds, microseconds, milliseconds, minutes or hours for the
+// units "ns", "us", "ms", "min" and "h", and seconds otherwise.
+// Usage: defer h.ObserveDuration(time.Now()).
+func (h *histogramImpl) ObserveDuration(start time.Time) {
+	h.Observe(float64(time.Since(start)) / float64(unitDuration(h.unit)))
+}
 
-	// Update sum
-	for {
-		oldBits := h.sum.Load()
-		oldSum := math.Float64frombits(oldBits)
-		newSum := oldSum + value
-		newBits := math.Float64bits(newSum)
+// ObserveWithExemplar records value. NaN and infinite values are rejected
+// rather than clamped, since no finite value would represent them faithfully
+// and a single one would poison Sum, Mean and StdDev; rejected values are
+// counted in CollectorStats.DroppedObservations. Finite negative values are
+// recorded as usual. With WithSampleRate, observations that are not sampled
+// are discarded, exemplar included.
+func (h *histogramImpl) ObserveWithExemplar(value float64, exemplar Exemplar) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		h.dropped.Add(1)
 
-		if h.sum.CompareAndSwap(oldBits, newBits) {
-			break
-		}
+		return
 	}
 
-	// Update min
-	valueBits := math.Float64bits(value)
+	n := h.sampledCount()
+	if n == 0 {
+		return
+	}
 
-	for {
-		oldMinBits := h.min.Load()
+	// Update count
+	h.count.Add(n)
 
-		oldMin := math.Float64frombits(oldMinBits)
-		if value >= oldMin {
-			break
-		}
+	h.sum.Add(value * float64(n))
 
-		if h.min.CompareAndSwap(oldMinBits, valueBits) {
+	// Update min
+	for {
+		oldMin := h.min.Load()
+		if value >= oldMin || h.min.CompareAndSwap(oldMin, value) {
 			break
 		}
 	}
 
 	// Update max
 	for {
-		oldMaxBits := h.max.Load()
-
-		oldMax := math.Float64frombits(oldMaxBits)
-		if value <= oldMax {
-			break
-		}
-
-		if h.max.CompareAndSwap(oldMaxBits, valueBits) {
+		oldMax := h.max.Load()
+		if value <= oldMax || h.max.CompareAndSwap(oldMax, value) {
 			break
 		}
 	}
 
-	// Find bucket using binary search
-	idx := sort.SearchFloat64s(h.buckets, value)
-	h.counts[idx].Add(1)
+	if h.native != nil {
+		h.native.observe(value, n)
+	} else {
+		// Find bucket using binary search
+		idx := sort.SearchFloat64s(h.buckets, value)
+		h.counts[idx].Add(n)
+	}
 
 	// Store exemplar if provided
 	if exemplar.TraceID != "" || exemplar.SpanID != "" {
@@ -444,12 +604,35 @@ func (h *histogramImpl) ObserveWithExemplar(value float64, exemplar Exemplar) {
 	h.updateTimestamp()
 }
 
+// sampledCount returns the number of observations one observation is recorded
+// as: 1 without WithSampleRate, otherwise 0 if it is not sampled, or about
+// 1/rate if it is. A fractional 1/rate is rounded up or down at random so
+// that the expected count is exact.
+func (h *histogramImpl) sampledCount() uint64 {
+	if h.sampleRate == 0 {
+		return 1
+	}
+
+	if rand.Float64() >= h.sampleRate {
+		return 0
+	}
+
+	weight := 1 / h.sampleRate
+	n := uint64(weight)
+
+	if rand.Float64() < weight-float64(n) {
+		n++
+	}
+
+	return n
+}
+
 func (h *histogramImpl) Count() uint64 {
 	return h.count.Load()
 }
 
 func (h *histogramImpl) Sum() float64 {
-	return math.Float64frombits(h.sum.Load())
+	return h.sum.Load()
 }
 
 func (h *histogramImpl) Mean() float64 {
@@ -472,6 +655,15 @@ func (h *histogramImpl) StdDev() float64 {
 	mean := h.Mean()
 	variance := 0.0
 
+	if h.native != nil {
+		for _, span := range h.native.spans() {
+			diff := (span.lower+span.upper)/2 - mean
+			variance += float64(span.count) * diff * diff
+		}
+
+		return math.Sqrt(variance / float64(count))
+	}
+
 	// Estimate variance from bucket midpoints
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -500,9 +692,7 @@ func (h *histogramImpl) StdDev() float64 {
 }
 
 func (h *histogramImpl) Min() float64 {
-	minBits := h.min.Load()
-
-	minVal := math.Float64frombits(minBits)
+	minVal := h.min.Load()
 	if minVal == math.MaxFloat64 {
 		return 0 // No observations yet
 	}
@@ -511,7 +701,12 @@ func (h *histogramImpl) Min() float64 {
 }
 
 func (h *histogramImpl) Max() float64 {
-	return math.Float64frombits(h.max.Load())
+	maxVal := h.max.Load()
+	if maxVal == -math.MaxFloat64 {
+		return 0 // No observations yet
+	}
+
+	return maxVal
 }
 
 func (h *histogramImpl) Percentile(percentile float64) float64 {
@@ -524,6 +719,16 @@ func (h *histogramImpl) Percentile(percentile float64) float64 {
 		return 0
 	}
 
+	// Native buckets are narrow enough to interpolate within, clamped to
+	// the observed range so the estimate never leaves it
+	if h.native != nil {
+		return min(max(h.native.quantile(percentile, count), h.Min()), h.Max())
+	}
+
+	if h.interpolate {
+		return min(max(h.interpolatedPercentile(percentile, count), h.Min()), h.Max())
+	}
+
 	// Find the bucket containing the percentile
 	targetRank := uint64(float64(count) * percentile)
 	cumulative := uint64(0)
@@ -546,11 +751,50 @@ func (h *histogramImpl) Percentile(percentile float64) float64 {
 	return 0
 }
 
+// interpolatedPercentile estimates a percentile by locating the bucket that
+// contains its rank and interpolating linearly between the bucket's bounds,
+// assuming observations are spread evenly within it. The first bucket starts
+// at the observed minimum, and ranks in the +Inf bucket resolve to the
+// observed maximum.
+func (h *histogramImpl) interpolatedPercentile(percentile float64, count uint64) float64 {
+	rank := percentile * float64(count)
+	lower := h.Min()
+	cumulative := uint64(0)
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for i, upper := range h.buckets {
+		n := h.counts[i].Load()
+		if n > 0 && float64(cumulative+n) >= rank {
+			lower = min(lower, upper)
+
+			return lower + (upper-lower)*(rank-float64(cumulative))/float64(n)
+		}
+
+		cumulative += n
+		lower = max(lower, upper)
+	}
+
+	return h.Max()
+}
+
 func (h *histogramImpl) Quantile(q float64) float64 {
 	return h.Percentile(q)
 }
 
 func (h *histogramImpl) Buckets() map[float64]uint64 {
+	if h.native != nil {
+		spans := h.native.spans()
+		buckets := make(map[float64]uint64, len(spans))
+
+		for _, span := range spans {
+			buckets[span.upper] = span.count
+		}
+
+		return buckets
+	}
+
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -563,6 +807,14 @@ func (h *histogramImpl) Buckets() map[float64]uint64 {
 	return buckets
 }
 
+func (h *histogramImpl) NativeBuckets() *NativeHistogram {
+	if h.native == nil {
+		return nil
+	}
+
+	return h.native.snapshot()
+}
+
 func (h *histogramImpl) Exemplars() []Exemplar {
 	return h.exemplars.GetAll()
 }
@@ -572,25 +824,24 @@ func (h *histogramImpl) Describe() MetricMetadata {
 }
 
 func (h *histogramImpl) WithLabels(labels map[string]string) Histogram {
-	newHist := NewHistogram(h.name, WithLabels(labels), WithBuckets(h.buckets...))
-	newHist.description = h.description
-	newHist.unit = h.unit
-	newHist.namespace = h.namespace
-	newHist.subsystem = h.subsystem
-
-	return newHist
+	return labeledVariant(h, labels, func(mc *metricsCollector) map[string]*histogramImpl { return mc.histograms },
+		func(opts ...MetricOption) *histogramImpl { return NewHistogram(h.name, opts...) })
 }
 
 func (h *histogramImpl) Reset() error {
 	h.count.Store(0)
-	h.sum.Store(0)
-	h.min.Store(math.Float64bits(math.MaxFloat64))
-	h.max.Store(0)
+	h.sum.Set(0)
+	h.min.Set(math.MaxFloat64)
+	h.max.Set(-math.MaxFloat64)
 
 	for i := range h.counts {
 		h.counts[i].Store(0)
 	}
 
+	if h.native != nil {
+		h.native.reset()
+	}
+
 	h.updateTimestamp()
 
 	return nil
@@ -608,11 +859,12 @@ type summaryImpl struct {
 	objectives map[float64]float64 // Quantile -> error margin
 	stream     *quantile.Stream
 	count      atomic.Uint64
-	sum        atomic.Uint64
+	sum        AtomicFloat64
 	values     []float64 // For accurate calculations
 	maxAge     time.Duration
 	ageBuckets uint32
 	bufCap     uint32
+	reservoir  int // Reservoir sample size; 0 keeps values as configured by bufCap
 }
 
 // NewSummary creates a new summary.
@@ -622,17 +874,14 @@ func NewSummary(name string, opts ...MetricOption) *summaryImpl {
 		opt(options)
 	}
 
-	objectives := make(map[float64]float64)
+	percentiles, _ := cleanPercentiles(options.Percentiles)
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
 
-	if len(options.Percentiles) > 0 {
-		for _, p := range options.Percentiles {
-			objectives[p] = 0.01 // 1% error margin
-		}
-	} else {
-		// Default percentiles
-		for _, p := range DefaultPercentiles {
-			objectives[p] = 0.01
-		}
+	objectives := make(map[float64]float64, len(percentiles))
+	for _, p := range percentiles {
+		objectives[p] = 0.01 // 1% error margin
 	}
 
 	s := &summaryImpl{
@@ -643,49 +892,80 @@ func NewSummary(name string, opts ...MetricOption) *summaryImpl {
 		maxAge:     options.MaxAge,
 		ageBuckets: options.AgeBuckets,
 		bufCap:     options.BufCap,
+		reservoir:  options.ReservoirSize,
 	}
 
 	return s
 }
 
+// cleanPercentiles returns percentiles sorted, without duplicates and without
+// values outside (0, 1), which no quantile stream can satisfy. corrected
+// reports whether anything was removed.
+func cleanPercentiles(percentiles []float64) (cleaned []float64, corrected bool) {
+	cleaned = slices.DeleteFunc(slices.Clone(percentiles), func(p float64) bool {
+		return math.IsNaN(p) || p <= 0 || p >= 1
+	})
+
+	slices.Sort(cleaned)
+	cleaned = slices.Compact(cleaned)
+
+	return cleaned, len(cleaned) != len(percentiles)
+}
+
+// Objectives returns the quantiles the summary tracks, mapped to their
+// allowed error margin.
+func (s *summaryImpl) Objectives() map[float64]float64 {
+	return maps.Clone(s.objectives)
+}
+
 func (s *summaryImpl) Observe(value float64) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.count.Add(1)
-
-	// Update sum
-	for {
-		oldBits := s.sum.Load()
-		oldSum := math.Float64frombits(oldBits)
-		newSum := oldSum + value
-		newBits := math.Float64bits(newSum)
+	n := s.count.Add(1)
 
-		if s.sum.CompareAndSwap(oldBits, newBits) {
-			break
-		}
-	}
+	s.sum.Add(value)
 
 	// Add to stream for quantile calculation
 	s.stream.Insert(value)
 
 	// Store value for accurate calculations
-	s.values = append(s.values, value)
+	if s.reservoir > 0 {
+		s.sample(value, n)
+	} else {
+		s.values = append(s.values, value)
 
-	// Limit buffer size
-	if s.bufCap > 0 && len(s.values) > int(s.bufCap) {
-		s.values = s.values[len(s.values)-int(s.bufCap):]
+		// Limit buffer size
+		if s.bufCap > 0 && len(s.values) > int(s.bufCap) {
+			s.values = s.values[len(s.values)-int(s.bufCap):]
+		}
 	}
 
 	s.updateTimestamp()
 }
 
+// sample adds the n-th observation to the reservoir: the first reservoir
+// values are kept, after which each value replaces a random slot with
+// probability reservoir/n, keeping the sample uniform over all observations.
+// Must be called with s.mu held.
+func (s *summaryImpl) sample(value float64, n uint64) {
+	if len(s.values) < s.reservoir {
+		s.values = append(s.values, value)
+
+		return
+	}
+
+	if j := rand.Uint64N(n); j < uint64(s.reservoir) {
+		s.values[j] = value
+	}
+}
+
 func (s *summaryImpl) Count() uint64 {
 	return s.count.Load()
 }
 
 func (s *summaryImpl) Sum() float64 {
-	return math.Float64frombits(s.sum.Load())
+	return s.sum.Load()
 }
 
 func (s *summaryImpl) Mean() float64 {
@@ -765,18 +1045,19 @@ func (s *summaryImpl) StdDev() float64 {
 	return math.Sqrt(variance)
 }
 
+// objectiveQuantiles returns the quantiles the summary tracks, in ascending
+// order.
+func (s *summaryImpl) objectiveQuantiles() []float64 {
+	return slices.Sorted(maps.Keys(s.objectives))
+}
+
 func (s *summaryImpl) Describe() MetricMetadata {
 	return s.describe()
 }
 
 func (s *summaryImpl) WithLabels(labels map[string]string) Summary {
-	NewSummary := NewSummary(s.name, WithLabels(labels))
-	NewSummary.description = s.description
-	NewSummary.unit = s.unit
-	NewSummary.namespace = s.namespace
-	NewSummary.subsystem = s.subsystem
-
-	return NewSummary
+	return labeledVariant(s, labels, func(mc *metricsCollector) map[string]*summaryImpl { return mc.summaries },
+		func(opts ...MetricOption) *summaryImpl { return NewSummary(s.name, opts...) })
 }
 
 func (s *summaryImpl) Reset() error {
@@ -784,7 +1065,7 @@ func (s *summaryImpl) Reset() error {
 	defer s.mu.Unlock()
 
 	s.count.Store(0)
-	s.sum.Store(0)
+	s.sum.Set(0)
 	s.stream.Reset()
 	s.values = make([]float64, 0, 1000)
 	s.updateTimestamp()
@@ -796,12 +1077,43 @@ func (s *summaryImpl) Reset() error {
 // TIMER IMPLEMENTATION
 // =============================================================================
 
-// timerImpl is a timer metric implementation.
+// timerImpl is a timer metric implementation. Durations are stored in the
+// histogram as multiples of unit.
 type timerImpl struct {
 	*metricCore
 
 	histogram *histogramImpl
 	exemplars *exemplarStore
+	unit      time.Duration
+}
+
+// hasFiniteBucket reports whether any boundary survives cleanBuckets.
+func hasFiniteBucket(buckets []float64) bool {
+	return slices.ContainsFunc(buckets, func(b float64) bool {
+		return !math.IsNaN(b) && !math.IsInf(b, 0)
+	})
+}
+
+// timerHistogram is the read-only view of a timer's histogram returned by
+// Timer.Histogram. Writes are ignored so the view cannot skew the timer.
+type timerHistogram struct {
+	*histogramImpl
+
+	timer *timerImpl
+}
+
+func (h *timerHistogram) Observe(value float64)                                {}
+func (h *timerHistogram) ObserveDuration(start time.Time)                      {}
+func (h *timerHistogram) ObserveWithExemplar(value float64, exemplar Exemplar) {}
+
+// Reset fails with ErrReadOnlyHistogram; reset the timer instead.
+func (h *timerHistogram) Reset() error {
+	return ErrReadOnlyHistogram
+}
+
+// WithLabels returns the view of the timer's variant with labels.
+func (h *timerHistogram) WithLabels(labels map[string]string) Histogram {
+	return h.timer.WithLabels(labels).Histogram()
 }
 
 // NewTimer creates a new timer.
@@ -811,30 +1123,49 @@ func NewTimer(name string, opts ...MetricOption) *timerImpl {
 		opt(options)
 	}
 
+	unit := options.TimerUnit
+	if unit <= 0 {
+		unit = time.Millisecond
+	}
+
 	// Use duration buckets if not specified
-	if len(options.Buckets) == 0 {
-		options.Buckets = DefaultDurationBuckets
+	histOpts := opts
+
+	switch {
+	case options.NativeBuckets:
+		// Native buckets need no boundaries
+	case len(options.DurationBuckets) > 0:
+		buckets := make([]float64, len(options.DurationBuckets))
+		for i, d := range options.DurationBuckets {
+			buckets[i] = float64(d) / float64(unit)
+		}
+
+		histOpts = append(slices.Clip(opts), WithBuckets(buckets...))
+	case !hasFiniteBucket(options.Buckets):
+		histOpts = append(slices.Clip(opts), WithBuckets(DefaultDurationBuckets...))
 	}
 
 	t := &timerImpl{
 		metricCore: newMetricCore(name, MetricTypeTimer, opts...),
-		histogram:  NewHistogram(name+"_duration", opts...),
+		histogram:  NewHistogram(name+"_duration", histOpts...),
 		exemplars:  newExemplarStore(),
+		unit:       unit,
+	}
+
+	if t.metricCore.unit == "" {
+		t.metricCore.unit = durationUnitName(unit)
 	}
 
 	return t
 }
 
 func (t *timerImpl) Record(duration time.Duration) {
-	// Convert to milliseconds
-	ms := float64(duration.Nanoseconds()) / 1e6
-	t.histogram.Observe(ms)
+	t.histogram.Observe(t.toUnits(duration))
 	t.updateTimestamp()
 }
 
 func (t *timerImpl) RecordWithExemplar(duration time.Duration, exemplar Exemplar) {
-	ms := float64(duration.Nanoseconds()) / 1e6
-	t.histogram.ObserveWithExemplar(ms, exemplar)
+	t.histogram.ObserveWithExemplar(t.toUnits(duration), exemplar)
 	t.exemplars.Add(exemplar)
 	t.updateTimestamp()
 }
@@ -847,6 +1178,10 @@ func (t *timerImpl) Time() func() {
 	}
 }
 
+func (t *timerImpl) ObserveDuration(start time.Time) {
+	t.Record(time.Since(start))
+}
+
 func (t *timerImpl) Count() uint64 {
 	return t.histogram.Count()
 }
@@ -856,45 +1191,68 @@ func (t *timerImpl) Value() time.Duration {
 }
 
 func (t *timerImpl) Sum() time.Duration {
-	ms := t.histogram.Sum()
-
-	return time.Duration(ms * 1e6) // Convert ms to nanoseconds
+	return t.toDuration(t.histogram.Sum())
 }
 
 func (t *timerImpl) Mean() time.Duration {
-	ms := t.histogram.Mean()
-
-	return time.Duration(ms * 1e6)
+	return t.toDuration(t.histogram.Mean())
 }
 
 func (t *timerImpl) StdDev() time.Duration {
-	ms := t.histogram.StdDev()
-
-	return time.Duration(ms * 1e6)
+	return t.toDuration(t.histogram.StdDev())
 }
 
 func (t *timerImpl) Min() time.Duration {
-	ms := t.histogram.Min()
-
-	return time.Duration(ms * 1e6)
+	return t.toDuration(t.histogram.Min())
 }
 
 func (t *timerImpl) Max() time.Duration {
-	ms := t.histogram.Max()
-
-	return time.Duration(ms * 1e6)
+	return t.toDuration(t.histogram.Max())
 }
 
 func (t *timerImpl) Percentile(percentile float64) time.Duration {
-	ms := t.histogram.Quantile(percentile)
-
-	return time.Duration(ms * 1e6)
+	return t.toDuration(t.histogram.Quantile(percentile))
 }
 
 func (t *timerImpl) Quantile(q float64) time.Duration {
 	return t.Percentile(q)
 }
 
+func (t *timerImpl) Buckets() map[time.Duration]uint64 {
+	counts := t.histogram.Buckets()
+	buckets := make(map[time.Duration]uint64, len(counts))
+
+	for boundary, count := range counts {
+		buckets[t.toDuration(boundary)] = count
+	}
+
+	return buckets
+}
+
+func (t *timerImpl) CumulativeBuckets() map[time.Duration]uint64 {
+	counts := t.histogram.Buckets()
+	buckets := make(map[time.Duration]uint64, len(counts))
+	cumulative := uint64(0)
+
+	for _, boundary := range slices.Sorted(maps.Keys(counts)) {
+		cumulative += counts[boundary]
+		buckets[t.toDuration(boundary)] = cumulative
+	}
+
+	return buckets
+}
+
+// nativeSeconds returns the native buckets of the timer rescaled from its
+// unit to seconds, or nil when the timer has explicit buckets.
+func (t *timerImpl) nativeSeconds() *NativeHistogram {
+	native := t.histogram.NativeBuckets()
+	if native == nil {
+		return nil
+	}
+
+	return native.scale(float64(t.unit) / float64(time.Second))
+}
+
 func (t *timerImpl) Exemplars() []Exemplar {
 	return t.exemplars.GetAll()
 }
@@ -903,14 +1261,15 @@ func (t *timerImpl) Describe() MetricMetadata {
 	return t.describe()
 }
 
-func (t *timerImpl) WithLabels(labels map[string]string) Timer {
-	NewTimer := NewTimer(t.name, WithLabels(labels))
-	NewTimer.description = t.description
-	NewTimer.unit = t.unit
-	NewTimer.namespace = t.namespace
-	NewTimer.subsystem = t.subsystem
+// Histogram returns a read-only view of the underlying histogram. Its values
+// and bucket boundaries are in the timer's unit, milliseconds by default.
+func (t *timerImpl) Histogram() Histogram {
+	return &timerHistogram{histogramImpl: t.histogram, timer: t}
+}
 
-	return NewTimer
+func (t *timerImpl) WithLabels(labels map[string]string) Timer {
+	return labeledVariant(t, labels, func(mc *metricsCollector) map[string]*timerImpl { return mc.timers },
+		func(opts ...MetricOption) *timerImpl { return NewTimer(t.name, opts...) })
 }
 
 func (t *timerImpl) Reset() error {
@@ -923,6 +1282,58 @@ func (t *timerImpl) Reset() error {
 	return nil
 }
 
+// toUnits converts a duration to the fractional number of timer units
+// recorded in the histogram.
+func (t *timerImpl) toUnits(d time.Duration) float64 {
+	return float64(d) / float64(t.unit)
+}
+
+// toDuration converts a value recorded in timer units back to a duration.
+func (t *timerImpl) toDuration(v float64) time.Duration {
+	return time.Duration(v * float64(t.unit))
+}
+
+// durationUnitName returns the conventional symbol for a timer unit, falling
+// back to the duration's string form for uncommon units.
+func durationUnitName(unit time.Duration) string {
+	switch unit {
+	case time.Nanosecond:
+		return "ns"
+	case time.Microsecond:
+		return "us"
+	case time.Millisecond:
+		return "ms"
+	case time.Second:
+		return "s"
+	case time.Minute:
+		return "min"
+	case time.Hour:
+		return "h"
+	default:
+		return unit.String()
+	}
+}
+
+// unitDuration is the inverse of durationUnitName for the common units. Other
+// units, including the empty unit, are taken to be seconds, the base unit for
+// durations in Prometheus.
+func unitDuration(name string) time.Duration {
+	switch name {
+	case "ns":
+		return time.Nanosecond
+	case "us", "µs":
+		return time.Microsecond
+	case "ms":
+		return time.Millisecond
+	case "min":
+		return time.Minute
+	case "h":
+		return time.Hour
+	default:
+		return time.Second
+	}
+}
+
 // =============================================================================
 // METRICS COLLECTOR - Factory and Registry
 // =============================================================================
@@ -937,13 +1348,33 @@ type metricsCollector struct {
 	summaries        map[string]*summaryImpl
 	timers           map[string]*timerImpl
 	customCollectors map[string]CustomCollector
-	cardinality      *LabelCardinality // Tracks label cardinality to prevent metric explosion
+	disabled         map[string]struct{} // Names of collectors disabled at runtime
+	cardinality      *LabelCardinality   // Tracks label cardinality to prevent metric explosion
+	exporters        map[string]Exporter // Exporters configured with WithExporter
+	healthManager    HealthManager       // Health manager checked by GatherAll
 	startTime        time.Time
 	started          atomic.Bool
+	closed           atomic.Bool
 	logger           log.Logger
 	config           *MetricsConfig
+
+	deltaMu        sync.Mutex               // Serializes ExportDelta calls
+	deltaBaselines map[string]deltaBaseline // Totals reported by the previous ExportDelta
+
+	exportMu     sync.Mutex                // Guards exportStats and exportCancel
+	exportStats  map[string]*ExporterStats // Export statistics per exporter name
+	exportCancel context.CancelFunc        // Stops the exporter scheduler, nil when not running
+	exportWG     sync.WaitGroup            // Scheduler loops and in-flight exports
+
+	// Errors surfaced through Stats, guarded by mu
+	recentErrors  []string
+	errorCount    int64
+	lastErrorTime time.Time
 }
 
+// maxRecentErrors bounds the errors kept for CollectorStats.Errors.
+const maxRecentErrors = 10
+
 // NewMetricsCollector creates a new metrics collector.
 func NewMetricsCollector(name string, opts ...MetricOption) Metrics {
 	options := &MetricOptions{}
@@ -965,7 +1396,11 @@ func NewMetricsCollector(name string, opts ...MetricOption) Metrics {
 		summaries:        make(map[string]*summaryImpl),
 		timers:           make(map[string]*timerImpl),
 		customCollectors: make(map[string]CustomCollector),
+		disabled:         make(map[string]struct{}),
 		cardinality:      NewLabelCardinality(maxCardinality),
+		exporters:        maps.Clone(options.Exporters),
+		exportStats:      make(map[string]*ExporterStats),
+		healthManager:    options.HealthManager,
 		startTime:        time.Now(),
 		logger:           options.Logger,
 		config:           options.Config,
@@ -979,6 +1414,10 @@ func (mc *metricsCollector) Name() string {
 }
 
 func (mc *metricsCollector) Start(ctx context.Context) error {
+	if mc.closed.Load() {
+		return ErrMetricsClosed
+	}
+
 	mc.started.Store(true)
 
 	return nil
@@ -987,10 +1426,14 @@ func (mc *metricsCollector) Start(ctx context.Context) error {
 func (mc *metricsCollector) Stop(ctx context.Context) error {
 	mc.started.Store(false)
 
-	return nil
+	return mc.stopExporters(ctx)
 }
 
 func (mc *metricsCollector) Health(ctx context.Context) error {
+	if mc.closed.Load() {
+		return ErrMetricsClosed
+	}
+
 	if !mc.started.Load() {
 		return ErrNotStarted
 	}
@@ -1007,6 +1450,11 @@ func (mc *metricsCollector) extractLabels(opts []MetricOption) map[string]string
 
 	allLabels := make(map[string]string)
 
+	// Include default tags from config; labels of the metric override them
+	if mc.config != nil && mc.config.Collection.DefaultTags != nil {
+		maps.Copy(allLabels, mc.config.Collection.DefaultTags)
+	}
+
 	// Merge all label sources
 	if options.Labels != nil {
 		maps.Copy(allLabels, options.Labels)
@@ -1016,11 +1464,6 @@ func (mc *metricsCollector) extractLabels(opts []MetricOption) map[string]string
 		maps.Copy(allLabels, options.ConstLabels)
 	}
 
-	// Include default tags from config
-	if mc.config != nil && mc.config.Collection.DefaultTags != nil {
-		maps.Copy(allLabels, mc.config.Collection.DefaultTags)
-	}
-
 	return allLabels
 }
 
@@ -1028,8 +1471,12 @@ func (mc *metricsCollector) extractLabels(opts []MetricOption) map[string]string
 // would exceed cardinality limits, and records it if allowed.
 // Returns an error if the limit would be exceeded.
 func (mc *metricsCollector) checkAndRecordCardinality(metricName string, opts []MetricOption) error {
-	labels := mc.extractLabels(opts)
+	return mc.recordCardinality(metricName, mc.extractLabels(opts))
+}
 
+// recordCardinality checks and records a label combination of metricName
+// against the cardinality limit. Must be called with mc.mu held.
+func (mc *metricsCollector) recordCardinality(metricName string, labels map[string]string) error {
 	// Check if this combination would exceed limits
 	if !mc.cardinality.Check(metricName, labels) {
 		if mc.logger != nil {
@@ -1056,15 +1503,31 @@ func (mc *metricsCollector) checkAndRecordCardinality(metricName string, opts []
 	return nil
 }
 
-// mergeDefaultOptions merges default tags from config with metric-specific options.
-// Metric-specific options take precedence over defaults.
-func (mc *metricsCollector) mergeDefaultOptions(opts []MetricOption) []MetricOption {
-	if mc.config == nil || len(mc.config.Collection.DefaultTags) == 0 {
+// mergeDefaultOptions merges default tags from config, and the configured
+// default buckets for histograms and timers, with metric-specific options.
+// Metric-specific options take precedence over defaults. The default tags
+// are applied first, so WithConstLabel adds to them and overrides them by
+// key, while WithConstLabels replaces them.
+func (mc *metricsCollector) mergeDefaultOptions(metricType MetricType, opts []MetricOption) []MetricOption {
+	if mc.config == nil {
+		return opts
+	}
+
+	var defaultBuckets []float64
+
+	switch metricType {
+	case MetricTypeHistogram:
+		defaultBuckets = mc.config.Buckets.Histogram
+	case MetricTypeTimer:
+		defaultBuckets = mc.config.Buckets.Timer
+	}
+
+	if len(mc.config.Collection.DefaultTags) == 0 && mc.config.Collection.Namespace == "" && len(defaultBuckets) == 0 {
 		return opts
 	}
 
 	// Create merged options slice with default tags first
-	mergedOpts := make([]MetricOption, 0, len(opts)+2)
+	mergedOpts := make([]MetricOption, 0, len(opts)+3)
 
 	// Add namespace from config if present
 	if mc.config.Collection.Namespace != "" {
@@ -1079,24 +1542,291 @@ func (mc *metricsCollector) mergeDefaultOptions(opts []MetricOption) []MetricOpt
 		mergedOpts = append(mergedOpts, WithConstLabels(defaultTagsCopy))
 	}
 
+	// Bucket options of the metric, including WithDurationBuckets for timers,
+	// override the configured ones
+	if len(defaultBuckets) > 0 {
+		mergedOpts = append(mergedOpts, WithBuckets(slices.Clone(defaultBuckets)...))
+	}
+
 	// Append metric-specific options (these will override defaults if they conflict)
 	mergedOpts = append(mergedOpts, opts...)
 
 	return mergedOpts
 }
 
+// metricKey returns the fully qualified name of a metric created with opts,
+// which is the key it is registered under.
+func metricKey(name string, opts []MetricOption) string {
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return qualifiedName(options.Namespace, options.Subsystem, name)
+}
+
+// warnOnNameConflict logs when a metric of another type is already registered
+// under key. Both are kept, but exports will contain two families with the
+// same name. Must be called with mc.mu held.
+func (mc *metricsCollector) warnOnNameConflict(key string, metricType MetricType) {
+	if mc.logger == nil {
+		return
+	}
+
+	existing := map[MetricType]bool{
+		MetricTypeCounter:   mc.counters[key] != nil,
+		MetricTypeGauge:     mc.gauges[key] != nil,
+		MetricTypeHistogram: mc.histograms[key] != nil,
+		MetricTypeSummary:   mc.summaries[key] != nil,
+		MetricTypeTimer:     mc.timers[key] != nil,
+	}
+
+	for other, exists := range existing {
+		if exists && other != metricType {
+			mc.logger.Warn("metric name already registered with a different type",
+				log.String("metric", key),
+				log.String("type", string(metricType)),
+				log.String("existing_type", string(other)))
+		}
+	}
+}
+
+// validateMetric checks the name and label keys of a metric about to be
+// created, recording the first problem found as a collector error. Metrics
+// that fail validation are not registered. Must be called with mc.mu held.
+func (mc *metricsCollector) validateMetric(name string, opts []MetricOption) error {
+	if err := checkMetric(name, opts); err != nil {
+		mc.recordError(err, name)
+
+		return err
+	}
+
+	return nil
+}
+
+// warnOnPercentileCorrection logs when the percentiles in opts contain
+// duplicates or values outside (0, 1) that the summary will drop, or nothing
+// usable so the defaults are tracked instead.
+func (mc *metricsCollector) warnOnPercentileCorrection(name string, opts []MetricOption) {
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	mc.warnOnCorrection(name, "percentiles", "out of range", options.Percentiles, cleanPercentiles)
+}
+
+// warnOnBucketCorrection logs when the explicit bucket boundaries in opts
+// contain duplicates or non-finite values that the histogram will drop, or
+// nothing usable so the defaults are used instead.
+func (mc *metricsCollector) warnOnBucketCorrection(name string, opts []MetricOption) {
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.NativeBuckets || len(options.DurationBuckets) > 0 {
+		return
+	}
+
+	mc.warnOnCorrection(name, "bucket boundaries", "non-finite", options.Buckets, cleanBuckets)
+}
+
+// warnOnCorrection logs when clean drops duplicate or invalid entries from the
+// requested values of metric name, or all of them. The log messages describe
+// the values as label and the invalid ones as invalid.
+func (mc *metricsCollector) warnOnCorrection(name, label, invalid string, values []float64, clean func([]float64) ([]float64, bool)) {
+	if mc.logger == nil || len(values) == 0 {
+		return
+	}
+
+	cleaned, corrected := clean(values)
+	if !corrected {
+		return
+	}
+
+	if len(cleaned) == 0 {
+		mc.logger.Warn("no valid "+label+", using defaults",
+			log.String("metric", name), log.String("requested", fmt.Sprint(values)))
+
+		return
+	}
+
+	mc.logger.Warn("dropped duplicate or "+invalid+" "+label,
+		log.String("metric", name), log.String("requested", fmt.Sprint(values)),
+		log.String("corrected", fmt.Sprint(cleaned)))
+}
+
+// checkBucketConflict records ErrBucketConflict when opts explicitly request
+// buckets that differ from those of the registered histogram h. Must be
+// called with mc.mu held.
+func (mc *metricsCollector) checkBucketConflict(key string, h *histogramImpl, opts []MetricOption) {
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if bucketsConflict(h, options, DefaultHistogramBuckets) {
+		mc.recordBucketConflict(MetricTypeHistogram, key)
+	}
+}
+
+// checkTimerBucketConflict is checkBucketConflict for the registered timer t,
+// converting requested duration buckets to the unit of t. Must be called with
+// mc.mu held.
+func (mc *metricsCollector) checkTimerBucketConflict(key string, t *timerImpl, opts []MetricOption) {
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if len(options.DurationBuckets) > 0 {
+		options.Buckets = make([]float64, len(options.DurationBuckets))
+		for i, d := range options.DurationBuckets {
+			options.Buckets[i] = float64(d) / float64(t.unit)
+		}
+	}
+
+	if bucketsConflict(t.histogram, options, DefaultDurationBuckets) {
+		mc.recordBucketConflict(MetricTypeTimer, key)
+	}
+}
+
+// bucketsConflict reports whether options explicitly request buckets that
+// differ from those of h, with defaults standing in for requested buckets
+// that are all invalid.
+func bucketsConflict(h *histogramImpl, options *MetricOptions, defaults []float64) bool {
+	if options.NativeBuckets {
+		return h.native == nil
+	}
+
+	if len(options.Buckets) == 0 {
+		return false
+	}
+
+	requested, _ := cleanBuckets(options.Buckets)
+	if len(requested) == 0 {
+		requested = defaults
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.native != nil || !slices.Equal(requested, h.buckets)
+}
+
+// recordBucketConflict logs that the registered metric was handed out in
+// place of one with the requested buckets and keeps ErrBucketConflict for
+// Stats. Must be called with mc.mu held for writing.
+func (mc *metricsCollector) recordBucketConflict(metricType MetricType, metric string) {
+	if mc.logger != nil {
+		mc.logger.Warn("returning registered metric with different buckets than requested",
+			log.String("metric", metric), log.String("type", string(metricType)))
+	}
+
+	mc.trackError(ErrBucketConflict)
+}
+
+// checkMetric reports whether name is empty or any const label or label key
+// set by opts is invalid.
+func checkMetric(name string, opts []MetricOption) error {
+	if name == "" {
+		return ErrEmptyMetricName
+	}
+
+	options := &MetricOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	for _, labels := range []map[string]string{options.ConstLabels, options.Labels} {
+		if err := validateLabelKeys(labels); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateLabelKeys returns an error for the first label key, in sorted order,
+// that does not match [a-zA-Z_][a-zA-Z0-9_]*.
+func validateLabelKeys(labels map[string]string) error {
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if !validLabelKey(key) {
+			return fmt.Errorf("%w: %q", ErrInvalidLabelKey, key)
+		}
+	}
+
+	return nil
+}
+
+// validLabelKey reports whether key matches [a-zA-Z_][a-zA-Z0-9_]*.
+func validLabelKey(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for i, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordError logs err for metric and keeps it for Stats. Must be called
+// with mc.mu held for writing.
+func (mc *metricsCollector) recordError(err error, metric string) {
+	if mc.logger != nil {
+		mc.logger.Warn("metric rejected", log.String("metric", metric), log.Error(err))
+	}
+
+	mc.trackError(err)
+}
+
+// trackError counts err and keeps it for Stats. Must be called with mc.mu
+// held for writing.
+func (mc *metricsCollector) trackError(err error) {
+	mc.errorCount++
+	mc.lastErrorTime = time.Now()
+
+	mc.recentErrors = append(mc.recentErrors, err.Error())
+	if len(mc.recentErrors) > maxRecentErrors {
+		mc.recentErrors = slices.Delete(mc.recentErrors, 0, len(mc.recentErrors)-maxRecentErrors)
+	}
+}
+
 // MetricFactory interface implementation
 
 func (mc *metricsCollector) Counter(name string, opts ...MetricOption) Counter {
+	if mc.closed.Load() {
+		return noopCounterInstance
+	}
+
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
-	if counter, exists := mc.counters[name]; exists {
+	// Merge default tags from config with metric-specific options
+	mergedOpts := mc.mergeDefaultOptions(MetricTypeCounter, opts)
+	key := metricKey(name, mergedOpts)
+
+	if counter, exists := mc.counters[key]; exists {
 		return counter
 	}
 
+	mc.warnOnNameConflict(key, MetricTypeCounter)
+
+	if err := mc.validateMetric(name, mergedOpts); err != nil {
+		// Hand out a working metric, but keep it out of exports
+		return NewCounter(name, mergedOpts...)
+	}
+
 	// Check cardinality limits before creating metric
-	if err := mc.checkAndRecordCardinality(name, opts); err != nil {
+	if err := mc.checkAndRecordCardinality(key, opts); err != nil {
 		// Return existing metric without labels or create a no-op version
 		// This prevents metric explosion while allowing the system to continue
 		if mc.logger != nil {
@@ -1104,25 +1834,29 @@ func (mc *metricsCollector) Counter(name string, opts ...MetricOption) Counter {
 				log.String("metric", name))
 		}
 		// Return a basic counter without the problematic labels
-		if existing, ok := mc.counters[name]; ok {
+		if existing, ok := mc.counters[key]; ok {
 			return existing
 		}
 		// Create a basic counter without labels
 		counter := NewCounter(name)
-		mc.counters[name] = counter
+		counter.owner = mc
+		mc.counters[key] = counter
 
 		return counter
 	}
 
-	// Merge default tags from config with metric-specific options
-	mergedOpts := mc.mergeDefaultOptions(opts)
 	counter := NewCounter(name, mergedOpts...)
-	mc.counters[name] = counter
+	counter.owner = mc
+	mc.counters[key] = counter
 
 	return counter
 }
 
 func (mc *metricsCollector) Gauge(name string, opts ...MetricOption) Gauge {
+	if mc.closed.Load() {
+		return noopGaugeInstance
+	}
+
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
@@ -1130,36 +1864,51 @@ func (mc *metricsCollector) Gauge(name string, opts ...MetricOption) Gauge {
 		mc.logger.Debug("creating gauge", log.String("name", name), log.Any("opts", opts))
 	}
 
-	if gauge, exists := mc.gauges[name]; exists {
+	// Merge default tags from config with metric-specific options
+	mergedOpts := mc.mergeDefaultOptions(MetricTypeGauge, opts)
+	key := metricKey(name, mergedOpts)
+
+	if gauge, exists := mc.gauges[key]; exists {
 		return gauge
 	}
 
+	mc.warnOnNameConflict(key, MetricTypeGauge)
+
+	if err := mc.validateMetric(name, mergedOpts); err != nil {
+		// Hand out a working metric, but keep it out of exports
+		return NewGauge(name, mergedOpts...)
+	}
+
 	// Check cardinality limits before creating metric
-	if err := mc.checkAndRecordCardinality(name, opts); err != nil {
+	if err := mc.checkAndRecordCardinality(key, opts); err != nil {
 		if mc.logger != nil {
 			mc.logger.Warn("returning existing gauge without new labels due to cardinality limit",
 				log.String("metric", name))
 		}
 
-		if existing, ok := mc.gauges[name]; ok {
+		if existing, ok := mc.gauges[key]; ok {
 			return existing
 		}
 
 		gauge := NewGauge(name)
-		mc.gauges[name] = gauge
+		gauge.owner = mc
+		mc.gauges[key] = gauge
 
 		return gauge
 	}
 
-	// Merge default tags from config with metric-specific options
-	mergedOpts := mc.mergeDefaultOptions(opts)
 	gauge := NewGauge(name, mergedOpts...)
-	mc.gauges[name] = gauge
+	gauge.owner = mc
+	mc.gauges[key] = gauge
 
 	return gauge
 }
 
 func (mc *metricsCollector) Histogram(name string, opts ...MetricOption) Histogram {
+	if mc.closed.Load() {
+		return noopHistInstance
+	}
+
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
@@ -1167,36 +1916,54 @@ func (mc *metricsCollector) Histogram(name string, opts ...MetricOption) Histogr
 		mc.logger.Debug("creating histogram", log.String("name", name), log.Any("opts", opts))
 	}
 
-	if histogram, exists := mc.histograms[name]; exists {
+	// Merge default tags from config with metric-specific options
+	mergedOpts := mc.mergeDefaultOptions(MetricTypeHistogram, opts)
+	key := metricKey(name, mergedOpts)
+
+	if histogram, exists := mc.histograms[key]; exists {
+		mc.checkBucketConflict(key, histogram, opts)
+
 		return histogram
 	}
 
+	mc.warnOnNameConflict(key, MetricTypeHistogram)
+	mc.warnOnBucketCorrection(name, mergedOpts)
+
+	if err := mc.validateMetric(name, mergedOpts); err != nil {
+		// Hand out a working metric, but keep it out of exports
+		return NewHistogram(name, mergedOpts...)
+	}
+
 	// Check cardinality limits before creating metric
-	if err := mc.checkAndRecordCardinality(name, opts); err != nil {
+	if err := mc.checkAndRecordCardinality(key, opts); err != nil {
 		if mc.logger != nil {
 			mc.logger.Warn("returning existing histogram without new labels due to cardinality limit",
 				log.String("metric", name))
 		}
 
-		if existing, ok := mc.histograms[name]; ok {
+		if existing, ok := mc.histograms[key]; ok {
 			return existing
 		}
 
 		histogram := NewHistogram(name)
-		mc.histograms[name] = histogram
+		histogram.owner = mc
+		mc.histograms[key] = histogram
 
 		return histogram
 	}
 
-	// Merge default tags from config with metric-specific options
-	mergedOpts := mc.mergeDefaultOptions(opts)
 	histogram := NewHistogram(name, mergedOpts...)
-	mc.histograms[name] = histogram
+	histogram.owner = mc
+	mc.histograms[key] = histogram
 
 	return histogram
 }
 
 func (mc *metricsCollector) Summary(name string, opts ...MetricOption) Summary {
+	if mc.closed.Load() {
+		return noopSummaryInstance
+	}
+
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
@@ -1204,36 +1971,52 @@ func (mc *metricsCollector) Summary(name string, opts ...MetricOption) Summary {
 		mc.logger.Debug("creating summary", log.String("name", name), log.Any("opts", opts))
 	}
 
-	if summary, exists := mc.summaries[name]; exists {
+	// Merge default tags from config with metric-specific options
+	mergedOpts := mc.mergeDefaultOptions(MetricTypeSummary, opts)
+	key := metricKey(name, mergedOpts)
+
+	if summary, exists := mc.summaries[key]; exists {
 		return summary
 	}
 
+	mc.warnOnNameConflict(key, MetricTypeSummary)
+	mc.warnOnPercentileCorrection(name, mergedOpts)
+
+	if err := mc.validateMetric(name, mergedOpts); err != nil {
+		// Hand out a working metric, but keep it out of exports
+		return NewSummary(name, mergedOpts...)
+	}
+
 	// Check cardinality limits before creating metric
-	if err := mc.checkAndRecordCardinality(name, opts); err != nil {
+	if err := mc.checkAndRecordCardinality(key, opts); err != nil {
 		if mc.logger != nil {
 			mc.logger.Warn("returning existing summary without new labels due to cardinality limit",
 				log.String("metric", name))
 		}
 
-		if existing, ok := mc.summaries[name]; ok {
+		if existing, ok := mc.summaries[key]; ok {
 			return existing
 		}
 
 		summary := NewSummary(name)
-		mc.summaries[name] = summary
+		summary.owner = mc
+		mc.summaries[key] = summary
 
 		return summary
 	}
 
-	// Merge default tags from config with metric-specific options
-	mergedOpts := mc.mergeDefaultOptions(opts)
 	summary := NewSummary(name, mergedOpts...)
-	mc.summaries[name] = summary
+	summary.owner = mc
+	mc.summaries[key] = summary
 
 	return summary
 }
 
 func (mc *metricsCollector) Timer(name string, opts ...MetricOption) Timer {
+	if mc.closed.Load() {
+		return noopTimerInstance
+	}
+
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
@@ -1241,31 +2024,45 @@ func (mc *metricsCollector) Timer(name string, opts ...MetricOption) Timer {
 		mc.logger.Debug("creating timer", log.String("name", name), log.Any("opts", opts))
 	}
 
-	if timer, exists := mc.timers[name]; exists {
+	// Merge default tags from config with metric-specific options
+	mergedOpts := mc.mergeDefaultOptions(MetricTypeTimer, opts)
+	key := metricKey(name, mergedOpts)
+
+	if timer, exists := mc.timers[key]; exists {
+		mc.checkTimerBucketConflict(key, timer, opts)
+
 		return timer
 	}
 
+	mc.warnOnNameConflict(key, MetricTypeTimer)
+	mc.warnOnBucketCorrection(name, mergedOpts)
+
+	if err := mc.validateMetric(name, mergedOpts); err != nil {
+		// Hand out a working metric, but keep it out of exports
+		return NewTimer(name, mergedOpts...)
+	}
+
 	// Check cardinality limits before creating metric
-	if err := mc.checkAndRecordCardinality(name, opts); err != nil {
+	if err := mc.checkAndRecordCardinality(key, opts); err != nil {
 		if mc.logger != nil {
 			mc.logger.Warn("returning existing timer without new labels due to cardinality limit",
 				log.String("metric", name))
 		}
 
-		if existing, ok := mc.timers[name]; ok {
+		if existing, ok := mc.timers[key]; ok {
 			return existing
 		}
 
 		timer := NewTimer(name)
-		mc.timers[name] = timer
+		timer.owner = mc
+		mc.timers[key] = timer
 
 		return timer
 	}
 
-	// Merge default tags from config with metric-specific options
-	mergedOpts := mc.mergeDefaultOptions(opts)
 	timer := NewTimer(name, mergedOpts...)
-	mc.timers[name] = timer
+	timer.owner = mc
+	mc.timers[key] = timer
 
 	return timer
 }
@@ -1273,13 +2070,45 @@ func (mc *metricsCollector) Timer(name string, opts ...MetricOption) Timer {
 // MetricExporter interface implementation
 
 func (mc *metricsCollector) Export(format ExportFormat) ([]byte, error) {
-	// Placeholder - would implement Prometheus, JSON, etc. export
-	return []byte("{}"), nil
+	return mc.ExportContext(context.Background(), format)
+}
+
+// ExportContext checks ctx between metric groups, so a cancelled export
+// stops early instead of walking the remaining metrics.
+func (mc *metricsCollector) ExportContext(ctx context.Context, format ExportFormat) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		return mc.exportJSON(ctx)
+	case ExportFormatPrometheus:
+		return mc.exportPrometheus(ctx)
+	default:
+		// Placeholder - would implement Influx, StatsD export
+		return []byte("{}"), nil
+	}
+}
+
+// ExportDelta exports counters and timers as the increase since the previous
+// ExportDelta call, for push backends such as StatsD that sum what they
+// receive. Only ExportFormatJSON is supported.
+func (mc *metricsCollector) ExportDelta(format ExportFormat) ([]byte, error) {
+	if format != ExportFormatJSON {
+		return nil, fmt.Errorf("%w: %s", ErrDeltaExportUnsupported, format)
+	}
+
+	return mc.exportDeltaJSON()
 }
 
 func (mc *metricsCollector) ExportToFile(format ExportFormat, filename string) error {
-	// Placeholder - would write to file
-	return nil
+	data, err := mc.Export(format)
+	if err != nil {
+		return err
+	}
+
+	return writeExportFile(data, filename)
 }
 
 // CollectorRegistry interface implementation
@@ -1315,6 +2144,7 @@ func (mc *metricsCollector) UnregisterCollector(name string) error {
 	}
 
 	delete(mc.customCollectors, name)
+	delete(mc.disabled, name)
 
 	return nil
 }
@@ -1331,37 +2161,136 @@ func (mc *metricsCollector) ListCollectors() []CustomCollector {
 	return collectors
 }
 
+// EnableCollector re-enables a collector previously disabled with
+// DisableCollector. Enabling a collector that is already enabled is a no-op.
+func (mc *metricsCollector) EnableCollector(name string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, exists := mc.customCollectors[name]; !exists {
+		return ErrCollectorNotFound
+	}
+
+	if mc.logger != nil {
+		mc.logger.Debug("enabling collector", log.String("name", name))
+	}
+
+	delete(mc.disabled, name)
+
+	return nil
+}
+
+// DisableCollector keeps a collector registered but skips it during
+// collection until it is re-enabled.
+func (mc *metricsCollector) DisableCollector(name string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, exists := mc.customCollectors[name]; !exists {
+		return ErrCollectorNotFound
+	}
+
+	if mc.logger != nil {
+		mc.logger.Debug("disabling collector", log.String("name", name))
+	}
+
+	mc.disabled[name] = struct{}{}
+
+	return nil
+}
+
+// ListActiveCollectors returns the registered collectors that take part in
+// collection: those not disabled at runtime and, for a ToggleableCollector,
+// reporting IsEnabled.
+func (mc *metricsCollector) ListActiveCollectors() []CustomCollector {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	collectors := make([]CustomCollector, 0, len(mc.customCollectors))
+	for name, collector := range mc.customCollectors {
+		if mc.collectorActive(name, collector) {
+			collectors = append(collectors, collector)
+		}
+	}
+
+	return collectors
+}
+
+// collectorActive reports whether the named collector should be collected.
+// Callers must hold mc.mu.
+func (mc *metricsCollector) collectorActive(name string, collector CustomCollector) bool {
+	if _, off := mc.disabled[name]; off {
+		return false
+	}
+
+	if toggleable, ok := collector.(ToggleableCollector); ok {
+		return toggleable.IsEnabled()
+	}
+
+	return true
+}
+
+// namedCollector is a custom collector together with its registered name.
+type namedCollector struct {
+	name      string
+	collector CustomCollector
+}
+
+// activeCollectors returns the active custom collectors sorted by name.
+// Exports call Collect on the result after releasing mu, because a collector
+// that calls back into mc, e.g. through a factory method, would otherwise
+// deadlock.
+func (mc *metricsCollector) activeCollectors() []namedCollector {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	collectors := make([]namedCollector, 0, len(mc.customCollectors))
+	for _, name := range slices.Sorted(maps.Keys(mc.customCollectors)) {
+		collector := mc.customCollectors[name]
+		if mc.collectorActive(name, collector) {
+			collectors = append(collectors, namedCollector{name: name, collector: collector})
+		}
+	}
+
+	return collectors
+}
+
 // MetricRepository interface implementation
 
+// ListMetrics returns all metrics keyed by their fully qualified name, the same
+// name reported by Describe and used in exports. Label variants created with
+// WithLabels are keyed by the name followed by their labels, e.g.
+// requests_total{method=GET}.
 func (mc *metricsCollector) ListMetrics() map[string]any {
 	mc.mu.RLock()
 	defer mc.mu.RUnlock()
 
 	metrics := make(map[string]any)
 
-	for name, counter := range mc.counters {
-		metrics[name] = counter
+	for key, counter := range mc.counters {
+		metrics[key] = counter
 	}
 
-	for name, gauge := range mc.gauges {
-		metrics[name] = gauge
+	for key, gauge := range mc.gauges {
+		metrics[key] = gauge
 	}
 
-	for name, histogram := range mc.histograms {
-		metrics[name] = histogram
+	for key, histogram := range mc.histograms {
+		metrics[key] = histogram
 	}
 
-	for name, summary := range mc.summaries {
-		metrics[name] = summary
+	for key, summary := range mc.summaries {
+		metrics[key] = summary
 	}
 
-	for name, timer := range mc.timers {
-		metrics[name] = timer
+	for key, timer := range mc.timers {
+		metrics[key] = timer
 	}
 
 	return metrics
 }
 
+// ListMetricsByType returns the metrics of one type keyed like ListMetrics.
 func (mc *metricsCollector) ListMetricsByType(metricType MetricType) map[string]any {
 	mc.mu.RLock()
 	defer mc.mu.RUnlock()
@@ -1370,24 +2299,24 @@ func (mc *metricsCollector) ListMetricsByType(metricType MetricType) map[string]
 
 	switch metricType {
 	case MetricTypeCounter:
-		for name, counter := range mc.counters {
-			metrics[name] = counter
+		for key, counter := range mc.counters {
+			metrics[key] = counter
 		}
 	case MetricTypeGauge:
-		for name, gauge := range mc.gauges {
-			metrics[name] = gauge
+		for key, gauge := range mc.gauges {
+			metrics[key] = gauge
 		}
 	case MetricTypeHistogram:
-		for name, histogram := range mc.histograms {
-			metrics[name] = histogram
+		for key, histogram := range mc.histograms {
+			metrics[key] = histogram
 		}
 	case MetricTypeSummary:
-		for name, summary := range mc.summaries {
-			metrics[name] = summary
+		for key, summary := range mc.summaries {
+			metrics[key] = summary
 		}
 	case MetricTypeTimer:
-		for name, timer := range mc.timers {
-			metrics[name] = timer
+		for key, timer := range mc.timers {
+			metrics[key] = timer
 		}
 	}
 
@@ -1399,6 +2328,38 @@ func (mc *metricsCollector) ListMetricsByTag(tagKey, tagValue string) map[string
 	return mc.ListMetrics()
 }
 
+func (mc *metricsCollector) MetricNames() []string {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	names := make([]string, 0, len(mc.counters)+len(mc.gauges)+len(mc.histograms)+
+		len(mc.summaries)+len(mc.timers))
+
+	for name := range mc.counters {
+		names = append(names, name)
+	}
+
+	for name := range mc.gauges {
+		names = append(names, name)
+	}
+
+	for name := range mc.histograms {
+		names = append(names, name)
+	}
+
+	for name := range mc.summaries {
+		names = append(names, name)
+	}
+
+	for name := range mc.timers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return slices.Compact(names)
+}
+
 func (mc *metricsCollector) Stats() CollectorStats {
 	mc.mu.RLock()
 	defer mc.mu.RUnlock()
@@ -1415,10 +2376,27 @@ func (mc *metricsCollector) Stats() CollectorStats {
 
 	// Get cardinality stats
 	currentCardinality := mc.cardinality.GetCardinality()
+	maxCardinality := mc.cardinality.MaxCardinality()
 
-	maxCardinality := MaxLabelCardinality
-	if mc.config != nil && mc.config.Limits.MaxMetrics > 0 {
-		maxCardinality = mc.config.Limits.MaxMetrics
+	activeCollectors := 0
+	for name, collector := range mc.customCollectors {
+		if mc.collectorActive(name, collector) {
+			activeCollectors++
+		}
+	}
+
+	dropped := uint64(0)
+	for _, histogram := range mc.histograms {
+		dropped += histogram.dropped.Load()
+	}
+
+	for _, timer := range mc.timers {
+		dropped += timer.histogram.dropped.Load()
+	}
+
+	var lastError string
+	if len(mc.recentErrors) > 0 {
+		lastError = mc.recentErrors[len(mc.recentErrors)-1]
 	}
 
 	return CollectorStats{
@@ -1430,14 +2408,42 @@ func (mc *metricsCollector) Stats() CollectorStats {
 		ActiveMetrics:          totalMetrics,
 		MetricsByType:          metricsByType,
 		CustomCollectors:       len(mc.customCollectors),
-		ActiveCustomCollectors: len(mc.customCollectors),
+		ActiveCustomCollectors: activeCollectors,
+		DroppedObservations:    int64(dropped), //nolint:gosec // count of observations fits in int64
 		LabelCardinality:       currentCardinality,
 		MaxLabelCardinality:    maxCardinality,
+		Errors:                 slices.Clone(mc.recentErrors),
+		ErrorCount:             mc.errorCount,
+		LastError:              lastError,
+		LastErrorTime:          mc.lastErrorTime,
+		ExporterStats:          mc.exporterStats(),
 		HealthStatus:           "healthy",
 		Degraded:               false,
 	}
 }
 
+// GatherAll returns Stats and, if a health manager is configured, the report
+// of a health check run right after. The stats' LastCollectionTime is the
+// time of the gather, and their health status reflects the report.
+func (mc *metricsCollector) GatherAll(ctx context.Context) (CollectorStats, *HealthReport) {
+	gatheredAt := time.Now()
+
+	stats := mc.Stats()
+	stats.LastCollectionTime = gatheredAt
+
+	if mc.healthManager == nil {
+		return stats, nil
+	}
+
+	report := mc.healthManager.Check(ctx)
+	if report != nil {
+		stats.HealthStatus = string(report.Overall)
+		stats.Degraded = report.Overall != HealthStatusHealthy
+	}
+
+	return stats, report
+}
+
 // MetricManager interface implementation
 
 func (mc *metricsCollector) Reset() error {
@@ -1481,6 +2487,7 @@ func (mc *metricsCollector) Reset() error {
 	return nil
 }
 
+// ResetMetric resets the metric registered under the fully qualified name.
 func (mc *metricsCollector) ResetMetric(name string) error {
 	mc.mu.RLock()
 	defer mc.mu.RUnlock()
@@ -1512,11 +2519,95 @@ func (mc *metricsCollector) ResetMetric(name string) error {
 	return ErrMetricNotFound
 }
 
+// Reload replaces the collector configuration at runtime. Metrics created
+// afterwards use the new namespace and default tags, and the new
+// Limits.MaxMetrics applies to label combinations recorded from now on.
+// Existing metrics keep the name and labels they were created with.
 func (mc *metricsCollector) Reload(config *MetricsConfig) error {
-	// Placeholder - would reload configuration
+	if config == nil {
+		return ErrConfigNil
+	}
+
+	cfg := *config
+	cfg.Collection.DefaultTags = maps.Clone(config.Collection.DefaultTags)
+	cfg.Buckets.Histogram = slices.Clone(config.Buckets.Histogram)
+	cfg.Buckets.Timer = slices.Clone(config.Buckets.Timer)
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.config = &cfg
+	mc.cardinality.SetMaxCardinality(cfg.Limits.MaxMetrics)
+
+	if mc.logger != nil {
+		mc.logger.Debug("reloaded metrics configuration",
+			log.String("namespace", cfg.Collection.Namespace),
+			log.Int("max_metrics", mc.cardinality.MaxCardinality()))
+	}
+
 	return nil
 }
 
+// Close stops the exporter scheduler and every registered ClosableCollector,
+// runs each configured exporter one final time and marks the collector
+// closed. Afterwards the factories return no-op metrics and Start and Health
+// return ErrMetricsClosed; metrics created before Close keep their values.
+//
+// Collectors are closed before the final export so it includes their last
+// collection. Waiting for in-flight scheduled exports is bounded by ctx. If
+// ctx ends first the remaining work is skipped and its error returned. Only
+// the first call does anything; later calls return nil.
+func (mc *metricsCollector) Close(ctx context.Context) error {
+	if mc.closed.Swap(true) {
+		return nil
+	}
+
+	mc.started.Store(false)
+
+	if err := mc.stopExporters(ctx); err != nil {
+		return err
+	}
+
+	mc.mu.RLock()
+	collectors := slices.Collect(maps.Values(mc.customCollectors))
+	mc.mu.RUnlock()
+
+	var errs []error
+
+	for _, collector := range collectors {
+		closable, ok := collector.(ClosableCollector)
+		if !ok {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		if err := closable.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("close collector %s: %w", closable.Name(), err))
+		}
+	}
+
+	metrics := mc.ListMetrics()
+
+	for _, name := range slices.Sorted(maps.Keys(mc.exporters)) {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		if err := mc.runExport(ctx, name, metrics); err != nil {
+			errs = append(errs, fmt.Errorf("final export %s: %w", name, err))
+		}
+	}
+
+	if mc.logger != nil {
+		mc.logger.Debug("metrics collector closed", log.String("name", mc.name))
+	}
+
+	return errors.Join(errs...)
+}
+
 // =============================================================================
 // ERRORS
 // =============================================================================
@@ -1527,6 +2618,20 @@ var (
 	ErrCollectorNotFound          = &MetricError{Message: "collector not found"}
 	ErrMetricNotFound             = &MetricError{Message: "metric not found"}
 	ErrCardinalityLimitExceeded   = &MetricError{Message: "label cardinality limit exceeded"}
+	ErrUnsupportedSchemaVersion   = &MetricError{Message: "unsupported export schema version"}
+	ErrDeltaExportUnsupported     = &MetricError{Message: "delta export not supported for format"}
+	ErrConfigNil                  = &MetricError{Message: "metrics config is nil"}
+	ErrEmptyMetricName            = &MetricError{Message: "metric name is empty"}
+	ErrMetricsClosed              = &MetricError{Message: "metrics collector closed"}
+	ErrInvalidLabelKey            = &MetricError{Message: "invalid label key"}
+	ErrRemoteWriteFailed          = &MetricError{Message: "remote write rejected"}
+	ErrOTLPExportFailed           = &MetricError{Message: "otlp export rejected"}
+	ErrExportersStarted           = &MetricError{Message: "exporters already started"}
+	ErrExporterNotFound           = &MetricError{Message: "exporter not registered"}
+	ErrAggregateUnsupported       = &MetricError{Message: "metric type cannot be aggregated"}
+	ErrReadOnlyHistogram          = &MetricError{Message: "histogram is read-only"}
+	ErrBucketConflict             = &MetricError{Message: "histogram already registered with different buckets"}
+	ErrMetricPanic                = &MetricError{Message: "metric panicked during export"}
 )
 
 // MetricError represents a metrics-related error.
@@ -1537,6 +2642,3 @@ type MetricError struct {
 func (e *MetricError) Error() string {
 	return e.Message
 }
-
-// Float64bits and Float64frombits helpers for older Go versions compatibility.
-// Note: math.Float64bits and math.Float64frombits are used for atomic float operations.
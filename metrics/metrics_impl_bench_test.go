@@ -18,6 +18,16 @@ func BenchmarkCounter_Inc(b *testing.B) {
 	}
 }
 
+func BenchmarkNoopCounter_Inc(b *testing.B) {
+	m := NewNoopMetrics()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		m.Counter("bench_counter").Inc()
+	}
+}
+
 func BenchmarkCounter_Add(b *testing.B) {
 	counter := NewCounter("bench_counter")
 
@@ -457,3 +467,31 @@ func benchmarkConcurrentCounter(b *testing.B, counter *counterImpl, numWorkers i
 
 	wg.Wait()
 }
+
+// =============================================================================
+// REGISTRY BENCHMARKS
+// =============================================================================
+
+func BenchmarkCollector_CounterLookup_Parallel(b *testing.B) {
+	collector := NewMetricsCollector("bench_collector")
+	collector.Counter("bench_counter")
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			collector.Counter("bench_counter").Inc()
+		}
+	})
+}
+
+func BenchmarkRegistry_CounterLookup_Parallel(b *testing.B) {
+	registry := NewRegistry(NewMetricsCollector("bench_collector"))
+	registry.Counter("bench_counter")
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			registry.Counter("bench_counter").Inc()
+		}
+	})
+}
@@ -2,14 +2,19 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/xraph/go-utils/log"
 )
 
 // =============================================================================
@@ -104,6 +109,79 @@ func TestCounter_Describe(t *testing.T) {
 	assert.Contains(t, metadata.Name, "described_counter")
 }
 
+func TestMetricCore_FullName(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []MetricOption
+		expected string
+	}{
+		{"bare", nil, "connections_open"},
+		{"namespace", []MetricOption{WithNamespace("db")}, "db_connections_open"},
+		{"subsystem", []MetricOption{WithSubsystem("postgres")}, "postgres_connections_open"},
+		{"namespace and subsystem", []MetricOption{WithNamespace("db"), WithSubsystem("postgres")}, "db_postgres_connections_open"},
+		{"empty subsystem", []MetricOption{WithNamespace("db"), WithSubsystem("")}, "db_connections_open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := NewCounter("connections_open", tt.opts...)
+			assert.Equal(t, tt.expected, counter.fullName())
+			assert.Equal(t, tt.expected, counter.Describe().Name)
+		})
+	}
+}
+
+func TestMetricsCollector_FullNameConsistency(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	counter := collector.Counter("connections_open", WithNamespace("db"), WithSubsystem("postgres"))
+	counter.Add(3)
+
+	const fullName = "db_postgres_connections_open"
+
+	assert.Equal(t, fullName, counter.Describe().Name)
+
+	listed := collector.ListMetrics()
+	assert.Contains(t, listed, fullName)
+	assert.NotContains(t, listed, "connections_open")
+	assert.Contains(t, collector.ListMetricsByType(MetricTypeCounter), fullName)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# TYPE "+fullName+" counter\n")
+	assert.Contains(t, string(data), fullName+" 3\n")
+}
+
+func TestMetricsCollector_SameNameDifferentNamespaces(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	db := collector.Counter("connections", WithNamespace("db"))
+	cache := collector.Counter("connections", WithNamespace("cache"))
+
+	db.Add(2)
+	cache.Add(5)
+
+	assert.NotSame(t, db, cache)
+	assert.Same(t, db, collector.Counter("connections", WithNamespace("db")))
+
+	listed := collector.ListMetrics()
+	require.Len(t, listed, 2)
+	assert.InDelta(t, 2.0, listed["db_connections"].(Counter).Value(), 0)
+	assert.InDelta(t, 5.0, listed["cache_connections"].(Counter).Value(), 0)
+
+	assert.Equal(t, []string{"cache_connections", "db_connections"}, collector.MetricNames())
+}
+
+func TestMetricsCollector_NameConflictAcrossTypes(t *testing.T) {
+	logger := log.NewTestLogger()
+	collector := NewMetricsCollector("test", WithLogger(logger))
+
+	collector.Counter("jobs")
+	collector.Gauge("jobs")
+
+	assert.True(t, logger.(*log.TestLogger).AssertHasLog("WARN", "metric name already registered with a different type"))
+}
+
 func TestCounter_Reset(t *testing.T) {
 	counter := NewCounter("reset_counter")
 	counter.Add(100)
@@ -161,6 +239,44 @@ func TestGauge_SetToCurrentTime(t *testing.T) {
 	assert.True(t, value <= float64(after))
 }
 
+func TestGauge_SetTime(t *testing.T) {
+	gauge := NewGauge("last_event_time")
+
+	// A float64 holds current Unix nanoseconds to within 256ns
+	const precision = 256 * time.Nanosecond
+
+	at := time.Date(2026, time.March, 14, 15, 9, 26, 535897932, time.UTC)
+	gauge.SetTime(at)
+
+	assert.InDelta(t, float64(at.UnixNano()), gauge.Value(), float64(precision))
+	assert.WithinDuration(t, at, gauge.TimeValue(), precision)
+
+	// Sub-microsecond differences survive
+	later := at.Add(750 * time.Nanosecond)
+	gauge.SetTime(later)
+
+	assert.Greater(t, gauge.TimeValue().Sub(at), 500*time.Nanosecond)
+	assert.WithinDuration(t, later, gauge.TimeValue(), precision)
+}
+
+func TestGauge_SetToCurrentTimeNanos(t *testing.T) {
+	gauge := NewGauge("time_gauge_nanos")
+
+	before := time.Now()
+
+	gauge.SetToCurrentTimeNanos()
+
+	after := time.Now()
+
+	value := gauge.TimeValue()
+	assert.False(t, value.Before(before.Add(-256*time.Nanosecond)))
+	assert.False(t, value.After(after.Add(256*time.Nanosecond)))
+
+	// The seconds variant is unchanged
+	gauge.SetToCurrentTime()
+	assert.InDelta(t, float64(time.Now().Unix()), gauge.Value(), 1)
+}
+
 func TestGauge_ConcurrentModifications(t *testing.T) {
 	gauge := NewGauge("concurrent_gauge")
 	numGoroutines := 50
@@ -188,6 +304,66 @@ func TestGauge_ConcurrentModifications(t *testing.T) {
 	assert.Equal(t, 0.0, gauge.Value())
 }
 
+func TestGauge_History(t *testing.T) {
+	gauge := NewGauge("queue_depth", WithHistory(3))
+	assert.Empty(t, gauge.History())
+
+	gauge.Set(1)
+	gauge.Set(2)
+	assert.Equal(t, []float64{1, 2}, historyValues(gauge.History()))
+
+	// The ring wraps at 3, dropping the oldest values
+	gauge.Set(3)
+	gauge.Inc()
+	gauge.Add(10)
+	assert.Equal(t, []float64{3, 4, 14}, historyValues(gauge.History()))
+
+	for range 5 {
+		gauge.Dec()
+	}
+
+	history := gauge.History()
+	assert.Equal(t, []float64{11, 10, 9}, historyValues(history))
+
+	for i := 1; i < len(history); i++ {
+		assert.False(t, history[i].Timestamp.Before(history[i-1].Timestamp))
+	}
+
+	// The returned slice is a copy
+	history[0].Value = 100
+	assert.Equal(t, []float64{11, 10, 9}, historyValues(gauge.History()))
+
+	require.NoError(t, gauge.Reset())
+	assert.Empty(t, gauge.History())
+
+	gauge.Set(5)
+	assert.Equal(t, []float64{5}, historyValues(gauge.History()))
+}
+
+func TestGauge_HistoryOptIn(t *testing.T) {
+	gauge := NewGauge("queue_depth")
+	gauge.Set(1)
+	assert.Nil(t, gauge.History())
+
+	// Label variants inherit the history size
+	collector := NewMetricsCollector("test")
+	variant := collector.Gauge("queue_depth", WithHistory(2)).WithLabels(map[string]string{"queue": "emails"})
+
+	variant.Set(1)
+	variant.Set(2)
+	variant.Set(3)
+	assert.Equal(t, []float64{2, 3}, historyValues(variant.History()))
+}
+
+func historyValues(points []GaugePoint) []float64 {
+	values := make([]float64, len(points))
+	for i, point := range points {
+		values[i] = point.Value
+	}
+
+	return values
+}
+
 // =============================================================================
 // HISTOGRAM TESTS
 // =============================================================================
@@ -207,6 +383,122 @@ func TestHistogram_BasicObservations(t *testing.T) {
 	assert.Equal(t, 27.5, histogram.Mean())
 }
 
+func TestHistogram_BucketCleaning(t *testing.T) {
+	t.Run("duplicates", func(t *testing.T) {
+		histogram := NewHistogram("dup_hist", WithBuckets(10, 1, 10, 5, 1))
+		histogram.Observe(3)
+		histogram.Observe(10)
+
+		assert.Equal(t, map[float64]uint64{1: 0, 5: 1, 10: 1}, histogram.Buckets())
+	})
+
+	t.Run("non-finite", func(t *testing.T) {
+		histogram := NewHistogram("nan_hist", WithBuckets(10, 10, math.NaN(), math.Inf(1), math.Inf(-1)))
+		histogram.Observe(5)
+		histogram.Observe(20)
+
+		assert.Equal(t, map[float64]uint64{10: 1}, histogram.Buckets())
+		assert.Equal(t, uint64(2), histogram.Count())
+		assert.InDelta(t, 10, histogram.Percentile(0.5), 1e-9)
+	})
+
+	t.Run("empty after cleaning", func(t *testing.T) {
+		histogram := NewHistogram("garbage_hist", WithBuckets(math.NaN(), math.Inf(1)))
+		assert.Len(t, histogram.Buckets(), len(DefaultHistogramBuckets))
+
+		timer := NewTimer("garbage_timer", WithBuckets(math.NaN()))
+		assert.Len(t, timer.Buckets(), len(DefaultDurationBuckets))
+		assert.Contains(t, timer.Buckets(), 10*time.Second)
+	})
+
+	t.Run("collector warns", func(t *testing.T) {
+		logger := log.NewTestLogger()
+		collector := NewMetricsCollector("test", WithLogger(logger))
+		testLogger := logger.(*log.TestLogger)
+
+		collector.Histogram("valid_hist", WithBuckets(1, 2))
+		assert.Equal(t, 0, testLogger.CountLogs("WARN"))
+
+		collector.Histogram("dup_hist", WithBuckets(1, 1, 2))
+		assert.True(t, testLogger.AssertHasLog("WARN", "dropped duplicate or non-finite bucket boundaries"))
+
+		collector.Timer("garbage_timer", WithBuckets(math.NaN()))
+		assert.True(t, testLogger.AssertHasLog("WARN", "no valid bucket boundaries, using defaults"))
+	})
+}
+
+func TestMetricsCollector_HistogramBucketConflict(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	first := []float64{1, 2, 5}
+	second := []float64{10, 20, 50}
+
+	const callers = 10
+
+	histograms := make([]Histogram, 2*callers)
+
+	var wg sync.WaitGroup
+
+	for i := range histograms {
+		buckets := first
+		if i%2 == 1 {
+			buckets = second
+		}
+
+		wg.Go(func() {
+			histograms[i] = collector.Histogram("latency", WithBuckets(buckets...))
+		})
+	}
+
+	wg.Wait()
+
+	// Every caller gets the registered histogram; the callers that lost the
+	// race with other buckets are recorded as errors
+	for _, h := range histograms {
+		assert.Same(t, histograms[0], h)
+	}
+
+	registered := slices.Sorted(maps.Keys(histograms[0].Buckets()))
+	assert.True(t, slices.Equal(first, registered) || slices.Equal(second, registered), registered)
+
+	stats := collector.Stats()
+	assert.Equal(t, int64(callers), stats.ErrorCount)
+	assert.Equal(t, ErrBucketConflict.Error(), stats.LastError)
+
+	// Matching buckets in another order, or no bucket options, do not conflict
+	reversed := slices.Clone(registered)
+	slices.Reverse(reversed)
+	collector.Histogram("latency", WithBuckets(reversed...))
+	collector.Histogram("latency")
+	assert.Equal(t, int64(callers), collector.Stats().ErrorCount)
+
+	collector.Histogram("latency", WithNativeBuckets(3))
+	assert.Equal(t, int64(callers+1), collector.Stats().ErrorCount)
+}
+
+func TestMetricsCollector_TimerBucketConflict(t *testing.T) {
+	logger := log.NewTestLogger()
+	collector := NewMetricsCollector("test", WithLogger(logger))
+
+	timer := collector.Timer("request", WithDurationBuckets(time.Millisecond, 10*time.Millisecond))
+
+	// The same boundaries, given as plain buckets or not at all, do not conflict
+	assert.Same(t, timer, collector.Timer("request", WithBuckets(10, 1)))
+	assert.Same(t, timer, collector.Timer("request"))
+	assert.Equal(t, int64(0), collector.Stats().ErrorCount)
+
+	// Other buckets still get the registered timer, but are recorded
+	assert.Same(t, timer, collector.Timer("request", WithDurationBuckets(time.Second)))
+
+	stats := collector.Stats()
+	assert.Equal(t, int64(1), stats.ErrorCount)
+	assert.Equal(t, ErrBucketConflict.Error(), stats.LastError)
+
+	testLogger := logger.(*log.TestLogger)
+	assert.True(t, testLogger.AssertHasLog("WARN", "returning registered metric with different buckets than requested"))
+	assert.False(t, testLogger.AssertHasLog("WARN", "metric rejected"))
+}
+
 func TestHistogram_MinMax(t *testing.T) {
 	histogram := NewHistogram("minmax_histogram")
 
@@ -328,6 +620,37 @@ func TestHistogram_Reset(t *testing.T) {
 	assert.Equal(t, 0.0, histogram.Sum())
 }
 
+func TestHistogram_ObserveDuration(t *testing.T) {
+	seconds := NewHistogram("duration_seconds")
+	millis := NewHistogram("duration_ms", WithUnit("ms"))
+
+	start := time.Now().Add(-2 * time.Second)
+	seconds.ObserveDuration(start)
+	millis.ObserveDuration(start)
+
+	elapsed := time.Since(start)
+
+	assert.Equal(t, uint64(1), seconds.Count())
+	assert.InDelta(t, elapsed.Seconds(), seconds.Sum(), 0.1)
+	assert.GreaterOrEqual(t, seconds.Sum(), 2.0)
+	assert.InDelta(t, float64(elapsed.Milliseconds()), millis.Sum(), 100)
+	assert.GreaterOrEqual(t, millis.Sum(), 2000.0)
+}
+
+func TestHistogram_ObserveDuration_FromCollector(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	histogram, ok := collector.Histogram("request_seconds").(TimedHistogram)
+	require.True(t, ok)
+
+	func() {
+		defer histogram.ObserveDuration(time.Now().Add(-500 * time.Millisecond))
+	}()
+
+	assert.Equal(t, uint64(1), histogram.Count())
+	assert.InDelta(t, 0.5, histogram.Sum(), 0.1)
+}
+
 func TestHistogram_Buckets(t *testing.T) {
 	// Create histogram with custom buckets
 	histogram := NewHistogram("bucket_histogram",
@@ -393,6 +716,165 @@ func TestHistogram_Buckets_Empty(t *testing.T) {
 	}
 }
 
+func TestHistogram_RejectsNonFinite(t *testing.T) {
+	histogram := NewHistogram("non_finite_histogram", WithBuckets(0, 10))
+
+	histogram.Observe(5)
+	histogram.Observe(-3)
+	histogram.Observe(math.NaN())
+	histogram.Observe(math.Inf(1))
+	histogram.ObserveWithExemplar(math.Inf(-1), Exemplar{TraceID: "trace"})
+
+	assert.Equal(t, uint64(2), histogram.Count())
+	assert.InDelta(t, 2.0, histogram.Sum(), 0)
+	assert.InDelta(t, -3.0, histogram.Min(), 0)
+	assert.InDelta(t, 5.0, histogram.Max(), 0)
+	assert.False(t, math.IsNaN(histogram.Mean()))
+	assert.Empty(t, histogram.Exemplars())
+	assert.Equal(t, uint64(3), histogram.dropped.Load())
+}
+
+func TestHistogram_SampleRate(t *testing.T) {
+	const observations = 1_000_000
+
+	tests := []struct {
+		name string
+		rate float64
+	}{
+		{name: "integer weight", rate: 0.1},
+		{name: "fractional weight", rate: 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			histogram := NewHistogram("sampled_hist", WithSampleRate(tt.rate), WithBuckets(0.5, 1.5))
+
+			for range observations {
+				histogram.Observe(1)
+			}
+
+			// The sampled count has a standard deviation well under 1% of
+			// the total, so 2% leaves a wide margin
+			count := float64(histogram.Count())
+			assert.InEpsilon(t, observations, count, 0.02)
+			assert.InDelta(t, count, histogram.Sum(), 1e-6)
+			assert.InDelta(t, 1.0, histogram.Mean(), 1e-9)
+
+			// Bucket counts are upsampled like Count
+			assert.Equal(t, map[float64]uint64{0.5: 0, 1.5: histogram.Count()}, histogram.Buckets())
+		})
+	}
+}
+
+func TestHistogram_SampleRateNative(t *testing.T) {
+	histogram := NewHistogram("sampled_native", WithSampleRate(0.5), WithNativeBuckets(3))
+
+	for range 10_000 {
+		histogram.Observe(2)
+	}
+
+	var bucketTotal uint64
+	for _, n := range histogram.Buckets() {
+		bucketTotal += n
+	}
+
+	assert.Equal(t, histogram.Count(), bucketTotal)
+	assert.InEpsilon(t, 10_000, float64(histogram.Count()), 0.1)
+}
+
+func TestHistogram_SampleRateInvalid(t *testing.T) {
+	for _, rate := range []float64{0, -0.5, 1, 1.5, math.NaN()} {
+		histogram := NewHistogram("unsampled_hist", WithSampleRate(rate))
+
+		for range 100 {
+			histogram.Observe(1)
+		}
+
+		assert.Equal(t, uint64(100), histogram.Count(), "rate %v", rate)
+	}
+}
+
+func TestTimer_SampleRate(t *testing.T) {
+	timer := NewTimer("sampled_timer", WithSampleRate(0.1))
+
+	for range 200_000 {
+		timer.Record(time.Millisecond)
+	}
+
+	assert.InEpsilon(t, 200_000, float64(timer.Count()), 0.05)
+	assert.InDelta(t, time.Millisecond, timer.Mean(), float64(time.Microsecond))
+}
+
+func TestHistogram_NegativeOnly(t *testing.T) {
+	histogram := NewHistogram("negative_histogram")
+	assert.InDelta(t, 0.0, histogram.Max(), 0)
+
+	histogram.Observe(-5)
+	histogram.Observe(-2)
+
+	assert.InDelta(t, -5.0, histogram.Min(), 0)
+	assert.InDelta(t, -2.0, histogram.Max(), 0)
+
+	require.NoError(t, histogram.Reset())
+	assert.InDelta(t, 0.0, histogram.Max(), 0)
+}
+
+func TestMetricsCollector_StatsDroppedObservations(t *testing.T) {
+	collector := NewMetricsCollector("dropped_collector")
+
+	collector.Histogram("sizes").Observe(math.NaN())
+	collector.Histogram("sizes").Observe(1)
+	collector.Timer("latency", WithTimerUnit(time.Nanosecond)).Record(time.Millisecond)
+
+	assert.Equal(t, int64(1), collector.Stats().DroppedObservations)
+}
+
+func TestHistogram_QuantileInterpolation(t *testing.T) {
+	buckets := WithBuckets(0, 30, 60, 90, 120)
+
+	snapped := NewHistogram("snapped_histogram", buckets)
+	interpolated := NewHistogram("interpolated_histogram", buckets, WithQuantileInterpolation(true))
+
+	// Uniform sample 0-99
+	for i := range 100 {
+		snapped.Observe(float64(i))
+		interpolated.Observe(float64(i))
+	}
+
+	snappedP50 := snapped.Quantile(0.5)
+	interpolatedP50 := interpolated.Quantile(0.5)
+
+	assert.InDelta(t, 60.0, snappedP50, 0)
+	assert.InDelta(t, 49.0, interpolatedP50, 0.001)
+	assert.Less(t, math.Abs(interpolatedP50-50), math.Abs(snappedP50-50))
+
+	// Estimates never leave the observed range
+	assert.InDelta(t, 0.0, interpolated.Quantile(0), 0)
+	assert.LessOrEqual(t, interpolated.Quantile(0.99), 99.0)
+	assert.InDelta(t, 99.0, interpolated.Quantile(1), 0)
+}
+
+func TestHistogram_QuantileInterpolationOverflow(t *testing.T) {
+	histogram := NewHistogram("overflow_histogram", WithBuckets(10), WithQuantileInterpolation(true))
+
+	histogram.Observe(5)
+	histogram.Observe(500)
+
+	// The upper half lies in the +Inf bucket and resolves to the maximum
+	assert.InDelta(t, 500.0, histogram.Quantile(0.99), 0)
+	assert.InDelta(t, 7.5, histogram.Quantile(0.25), 0.001)
+}
+
+func TestTimer_QuantileInterpolation(t *testing.T) {
+	timer := NewTimer("interpolated_timer", WithBuckets(0, 100), WithQuantileInterpolation(true))
+
+	for i := range 100 {
+		timer.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	assert.InDelta(t, float64(50*time.Millisecond), float64(timer.Percentile(0.5)), float64(2*time.Millisecond))
+}
+
 // =============================================================================
 // SUMMARY TESTS
 // =============================================================================
@@ -430,6 +912,56 @@ func TestSummary_Quantiles(t *testing.T) {
 	assert.InDelta(t, 99.0, p99, 5.0)
 }
 
+func TestSummary_Objectives(t *testing.T) {
+	summary := NewSummary("objectives_summary", WithPercentiles(0.5, 0.99))
+	assert.Equal(t, map[float64]float64{0.5: 0.01, 0.99: 0.01}, summary.Objectives())
+
+	// The returned map is a copy
+	summary.Objectives()[0.75] = 0.01
+	assert.Len(t, summary.Objectives(), 2)
+
+	defaults := NewSummary("default_summary").Objectives()
+	assert.Len(t, defaults, len(DefaultPercentiles))
+
+	for _, p := range DefaultPercentiles {
+		assert.Contains(t, defaults, p)
+	}
+}
+
+func TestSummary_InvalidPercentiles(t *testing.T) {
+	t.Run("out of range dropped", func(t *testing.T) {
+		summary := NewSummary("range_summary", WithPercentiles(1.5, 0.9, 0, -0.1, 1, math.NaN(), 0.9))
+		assert.Equal(t, map[float64]float64{0.9: 0.01}, summary.Objectives())
+
+		for i := 1; i <= 100; i++ {
+			summary.Observe(float64(i))
+		}
+
+		assert.InDelta(t, 90.0, summary.Quantile(0.9), 5.0)
+	})
+
+	t.Run("empty after cleaning", func(t *testing.T) {
+		summary := NewSummary("garbage_summary", WithPercentiles(1.5, 2))
+		assert.Len(t, summary.Objectives(), len(DefaultPercentiles))
+	})
+
+	t.Run("collector warns", func(t *testing.T) {
+		logger := log.NewTestLogger()
+		collector := NewMetricsCollector("test", WithLogger(logger))
+		testLogger := logger.(*log.TestLogger)
+
+		collector.Summary("valid_summary", WithPercentiles(0.5, 0.9))
+		assert.Equal(t, 0, testLogger.CountLogs("WARN"))
+
+		summary := collector.Summary("range_summary", WithPercentiles(0.5, 1.5))
+		assert.True(t, testLogger.AssertHasLog("WARN", "dropped duplicate or out of range percentiles"))
+		assert.Equal(t, map[float64]float64{0.5: 0.01}, summary.Objectives())
+
+		collector.Summary("garbage_summary", WithPercentiles(1.5))
+		assert.True(t, testLogger.AssertHasLog("WARN", "no valid percentiles, using defaults"))
+	})
+}
+
 func TestSummary_MinMax(t *testing.T) {
 	summary := NewSummary("minmax_summary")
 
@@ -480,6 +1012,54 @@ func TestSummary_ConcurrentObservations(t *testing.T) {
 	assert.Equal(t, expected, summary.Count())
 }
 
+func TestSummary_ReservoirSampling(t *testing.T) {
+	const (
+		size         = 1000
+		observations = 1_000_000
+	)
+
+	summary := NewSummary("reservoir_summary", WithReservoirSampling(size), WithPercentiles(0.5, 0.9, 0.99))
+
+	for i := range observations {
+		summary.Observe(float64(i))
+	}
+
+	// The sample stays bounded while Count and Sum cover every observation
+	assert.Len(t, summary.values, size)
+	assert.LessOrEqual(t, cap(summary.values), 2*size)
+	assert.Equal(t, uint64(observations), summary.Count())
+	assert.InDelta(t, float64(observations-1)/2, summary.Mean(), 1e-6)
+
+	// Quantiles come from the stream, not the sample
+	assert.InDelta(t, 0.5*observations, summary.Quantile(0.5), 0.01*observations)
+	assert.InDelta(t, 0.9*observations, summary.Quantile(0.9), 0.01*observations)
+	assert.InDelta(t, 0.99*observations, summary.Quantile(0.99), 0.01*observations)
+
+	// Min, Max and StdDev are sample estimates
+	assert.GreaterOrEqual(t, summary.Min(), 0.0)
+	assert.Less(t, summary.Min(), 0.05*observations)
+	assert.Greater(t, summary.Max(), 0.95*observations)
+	assert.InDelta(t, observations/math.Sqrt(12), summary.StdDev(), 0.05*observations)
+}
+
+func TestSummary_ReservoirSamplingUniform(t *testing.T) {
+	summary := NewSummary("uniform_reservoir_summary", WithReservoirSampling(500))
+
+	for i := range 100_000 {
+		summary.Observe(float64(i))
+	}
+
+	// A most-recent window would only hold values >= 99500
+	early := 0
+	for _, v := range summary.values {
+		if v < 50_000 {
+			early++
+		}
+	}
+
+	assert.InDelta(t, 250, early, 75)
+}
+
 // =============================================================================
 // TIMER TESTS
 // =============================================================================
@@ -500,6 +1080,23 @@ func TestTimer_BasicRecording(t *testing.T) {
 	assert.InDelta(t, 200*time.Millisecond, mean, float64(10*time.Millisecond))
 }
 
+func TestTimer_ObserveDuration(t *testing.T) {
+	timer := NewTimer("observe_timer")
+
+	start := time.Now().Add(-150 * time.Millisecond)
+	timer.ObserveDuration(start)
+
+	elapsed := time.Since(start)
+
+	assert.Equal(t, uint64(1), timer.Count())
+	assert.GreaterOrEqual(t, timer.Sum(), 150*time.Millisecond)
+	assert.LessOrEqual(t, timer.Sum(), elapsed)
+
+	var observer DurationObserver = timer
+	observer.ObserveDuration(time.Now())
+	assert.Equal(t, uint64(2), timer.Count())
+}
+
 func TestTimer_Value(t *testing.T) {
 	timer := NewTimer("test_timer")
 
@@ -577,6 +1174,203 @@ func TestTimer_Exemplars(t *testing.T) {
 	assert.Equal(t, "timer_trace", exemplars[0].TraceID)
 }
 
+func TestTimer_Buckets(t *testing.T) {
+	timer := NewTimer("bucket_timer", WithBuckets(10, 50, 100))
+
+	timer.Record(5 * time.Millisecond)
+	timer.Record(20 * time.Millisecond)
+	timer.Record(30 * time.Millisecond)
+	timer.Record(80 * time.Millisecond)
+	timer.Record(time.Second) // +Inf
+
+	buckets := timer.Buckets()
+	require.Len(t, buckets, 3)
+	assert.Equal(t, uint64(1), buckets[10*time.Millisecond])
+	assert.Equal(t, uint64(2), buckets[50*time.Millisecond])
+	assert.Equal(t, uint64(1), buckets[100*time.Millisecond])
+
+	cumulative := timer.CumulativeBuckets()
+	require.Len(t, cumulative, 3)
+	assert.Equal(t, uint64(1), cumulative[10*time.Millisecond])
+	assert.Equal(t, uint64(3), cumulative[50*time.Millisecond])
+	assert.Equal(t, uint64(4), cumulative[100*time.Millisecond])
+}
+
+func TestTimer_Histogram(t *testing.T) {
+	timer := NewTimer("histogram_timer", WithBuckets(10, 50, 100))
+
+	timer.Record(5 * time.Millisecond)
+	timer.Record(20 * time.Millisecond)
+	timer.Record(30 * time.Millisecond)
+	timer.Record(80 * time.Millisecond)
+
+	histogram := timer.Histogram()
+	assert.Equal(t, map[float64]uint64{10: 1, 50: 2, 100: 1}, histogram.Buckets())
+	assert.Equal(t, uint64(4), histogram.Count())
+	assert.InDelta(t, 135, histogram.Sum(), 1e-9)
+
+	// The view is live but read-only
+	timer.Record(90 * time.Millisecond)
+	assert.Equal(t, uint64(2), histogram.Buckets()[100])
+
+	histogram.Observe(1)
+	histogram.ObserveWithExemplar(1, Exemplar{})
+	require.ErrorIs(t, histogram.Reset(), ErrReadOnlyHistogram)
+	assert.Equal(t, uint64(5), timer.Count())
+
+	mock := NewMockTimer()
+	mock.Record(250 * time.Millisecond)
+	mock.Record(750 * time.Millisecond)
+
+	mockHistogram := mock.Histogram()
+	assert.Equal(t, uint64(2), mockHistogram.Count())
+	assert.InDelta(t, 1000, mockHistogram.Sum(), 1e-9)
+}
+
+func TestTimer_HistogramWithLabels(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	timer := collector.Timer("request_duration", WithBuckets(10, 100))
+	timer.WithLabels(map[string]string{"route": "/a"}).Record(50 * time.Millisecond)
+
+	variant := timer.Histogram().WithLabels(map[string]string{"route": "/a"})
+	assert.Equal(t, uint64(1), variant.Buckets()[100])
+	assert.Equal(t, uint64(0), timer.Histogram().Count())
+}
+
+func TestTimer_Buckets_Empty(t *testing.T) {
+	timer := NewTimer("empty_bucket_timer", WithDefaultTimerBuckets())
+
+	buckets := timer.Buckets()
+	assert.Len(t, buckets, len(DefaultDurationBuckets))
+	assert.Contains(t, buckets, time.Millisecond)
+	assert.Contains(t, buckets, 10*time.Second)
+
+	for _, count := range timer.CumulativeBuckets() {
+		assert.Equal(t, uint64(0), count)
+	}
+}
+
+func TestTimer_DefaultBuckets(t *testing.T) {
+	timer := NewTimer("default_bucket_timer")
+
+	buckets := timer.Buckets()
+	assert.Len(t, buckets, len(DefaultDurationBuckets))
+	assert.Contains(t, buckets, time.Millisecond)
+	assert.Equal(t, "ms", timer.Describe().Unit)
+}
+
+func TestTimer_Unit(t *testing.T) {
+	timer := NewTimer("unit_timer", WithTimerUnit(time.Microsecond))
+
+	timer.Record(1500 * time.Microsecond)
+	timer.Record(500 * time.Microsecond)
+
+	assert.Equal(t, "us", timer.Describe().Unit)
+	assert.Equal(t, 2*time.Millisecond, timer.Sum())
+	assert.Equal(t, 500*time.Microsecond, timer.Min())
+	assert.Equal(t, 1500*time.Microsecond, timer.Max())
+
+	// Default buckets are interpreted in the timer's unit
+	buckets := timer.Buckets()
+	assert.Contains(t, buckets, time.Microsecond)
+	assert.Contains(t, buckets, 10*time.Millisecond)
+}
+
+func TestTimer_UnitExplicitUnitWins(t *testing.T) {
+	timer := NewTimer("named_unit_timer", WithTimerUnit(time.Second), WithUnit("seconds"))
+
+	assert.Equal(t, "seconds", timer.Describe().Unit)
+}
+
+func TestTimer_UnitSubMillisecondResolution(t *testing.T) {
+	record := func(timer Timer) {
+		for i := range 100 {
+			timer.Record(time.Duration(i+1) * 5 * time.Microsecond) // 5us-500us
+		}
+	}
+
+	msTimer := NewTimer("ms_timer")
+	usTimer := NewTimer("us_timer", WithTimerUnit(time.Microsecond))
+
+	record(msTimer)
+	record(usTimer)
+
+	// Every observation falls into the first millisecond bucket
+	assert.Equal(t, time.Millisecond, msTimer.Percentile(0.5))
+	assert.Equal(t, time.Millisecond, msTimer.Percentile(0.99))
+
+	// Microsecond buckets separate the median from the tail
+	p50 := usTimer.Percentile(0.5)
+	p99 := usTimer.Percentile(0.99)
+	assert.Equal(t, 250*time.Microsecond, p50)
+	assert.Equal(t, 500*time.Microsecond, p99)
+	assert.Less(t, p50, msTimer.Percentile(0.5))
+}
+
+func TestTimer_UnitWithLabels(t *testing.T) {
+	timer := NewTimer("labeled_unit_timer", WithTimerUnit(time.Microsecond))
+
+	labeled := timer.WithLabels(map[string]string{"route": "/"})
+	labeled.Record(5 * time.Microsecond)
+
+	assert.Equal(t, "us", labeled.Describe().Unit)
+	assert.Equal(t, 5*time.Microsecond, labeled.Sum())
+	assert.Contains(t, labeled.Buckets(), 5*time.Microsecond)
+}
+
+func TestTimer_DurationBuckets(t *testing.T) {
+	durations := []time.Duration{
+		500 * time.Microsecond, 3 * time.Millisecond, 40 * time.Millisecond,
+		200 * time.Millisecond, 2 * time.Second,
+	}
+
+	raw := NewTimer("raw_bucket_timer", WithBuckets(1, 10, 1000))
+	typed := NewTimer("typed_bucket_timer",
+		WithDurationBuckets(time.Second, time.Millisecond, 10*time.Millisecond, time.Millisecond),
+	)
+
+	for _, d := range durations {
+		raw.Record(d)
+		typed.Record(d)
+	}
+
+	assert.Equal(t, raw.Buckets(), typed.Buckets())
+	assert.Equal(t, raw.CumulativeBuckets(), typed.CumulativeBuckets())
+	assert.Len(t, typed.Buckets(), 3)
+}
+
+func TestTimer_DurationBucketsUseTimerUnit(t *testing.T) {
+	// Option order does not matter: boundaries are converted on creation
+	timer := NewTimer("us_bucket_timer",
+		WithDurationBuckets(100*time.Microsecond, time.Millisecond),
+		WithTimerUnit(time.Microsecond),
+	)
+
+	timer.Record(50 * time.Microsecond)
+	timer.Record(700 * time.Microsecond)
+
+	buckets := timer.Buckets()
+	require.Len(t, buckets, 2)
+	assert.Equal(t, uint64(1), buckets[100*time.Microsecond])
+	assert.Equal(t, uint64(1), buckets[time.Millisecond])
+	assert.InDelta(t, 100.0, timer.histogram.buckets[0], 1e-9)
+}
+
+func TestMetricsCollector_SnapshotTimerUnit(t *testing.T) {
+	collector := NewMetricsCollector("timer_unit_collector")
+
+	collector.Timer("latency", WithTimerUnit(time.Microsecond)).Record(1500 * time.Microsecond)
+
+	entry := collector.Snapshot()["latency"]
+	assert.Equal(t, "us", entry.Unit)
+	assert.InDelta(t, 1500, entry.Sum, 1e-9)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "latency_sum 0.0015")
+}
+
 // =============================================================================
 // METRICS COLLECTOR TESTS
 // =============================================================================
@@ -668,6 +1462,21 @@ func TestMetricsCollector_ListMetrics(t *testing.T) {
 	assert.Len(t, metrics, 5)
 }
 
+func TestMetricsCollector_MetricNames(t *testing.T) {
+	collector := NewMetricsCollector("names_collector")
+
+	assert.Empty(t, collector.MetricNames())
+
+	collector.Timer("timer1")
+	collector.Counter("counter1")
+	collector.Gauge("gauge1")
+	collector.Histogram("histogram1")
+	collector.Summary("summary1")
+	collector.Gauge("counter1") // Same name as counter, listed once
+
+	assert.Equal(t, []string{"counter1", "gauge1", "histogram1", "summary1", "timer1"}, collector.MetricNames())
+}
+
 func TestMetricsCollector_ListMetricsByType(t *testing.T) {
 	collector := NewMetricsCollector("type_collector")
 
@@ -697,6 +1506,214 @@ func TestMetricsCollector_Stats(t *testing.T) {
 	assert.Equal(t, 1, stats.MetricsByType[MetricTypeHistogram])
 }
 
+func TestMetricsCollector_GatherAll(t *testing.T) {
+	hm := NewMockHealthManager()
+	hm.CheckFunc = func(ctx context.Context) *HealthReport {
+		report := NewHealthReport()
+		report.Overall = HealthStatusDegraded
+
+		return report
+	}
+
+	collector := NewMetricsCollector("gather_collector", WithHealthManager(hm))
+	collector.Counter("c1").Inc()
+
+	stats, report := collector.GatherAll(t.Context())
+	require.NotNil(t, report)
+
+	assert.Equal(t, 1, hm.CheckCalls)
+	assert.Equal(t, "gather_collector", stats.Name)
+	assert.Equal(t, 1, stats.ActiveMetrics)
+	assert.Equal(t, HealthStatusDegraded, report.Overall)
+	assert.Equal(t, string(HealthStatusDegraded), stats.HealthStatus)
+	assert.True(t, stats.Degraded)
+	assert.WithinDuration(t, stats.LastCollectionTime, report.Timestamp, time.Second)
+}
+
+func TestMetricsCollector_GatherAllWithoutHealthManager(t *testing.T) {
+	collector := NewMetricsCollector("gather_collector")
+
+	stats, report := collector.GatherAll(t.Context())
+	assert.Nil(t, report)
+	assert.Equal(t, "gather_collector", stats.Name)
+	assert.Equal(t, "healthy", stats.HealthStatus)
+	assert.WithinDuration(t, time.Now(), stats.LastCollectionTime, time.Second)
+}
+
+// toggleCollector is a ToggleableCollector whose enabled state and collect
+// calls are observable from tests.
+type toggleCollector struct {
+	name     string
+	enabled  bool
+	collects int
+}
+
+func (c *toggleCollector) Name() string    { return c.name }
+func (c *toggleCollector) Reset() error    { return nil }
+func (c *toggleCollector) IsEnabled() bool { return c.enabled }
+
+func (c *toggleCollector) Collect() map[string]any {
+	c.collects++
+
+	return map[string]any{"collects": c.collects}
+}
+
+func TestMetricsCollector_DisableCollector(t *testing.T) {
+	collector := NewMetricsCollector("toggle_collector")
+
+	tc := &toggleCollector{name: "toggle", enabled: true}
+	require.NoError(t, collector.RegisterCollector(tc))
+
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"toggle"`)
+	assert.Equal(t, 1, tc.collects)
+
+	require.NoError(t, collector.DisableCollector("toggle"))
+
+	data, err = collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"toggle"`)
+	assert.Equal(t, 1, tc.collects, "disabled collector must not be collected")
+
+	assert.Len(t, collector.ListCollectors(), 1)
+	assert.Empty(t, collector.ListActiveCollectors())
+	assert.Equal(t, 1, collector.Stats().CustomCollectors)
+	assert.Equal(t, 0, collector.Stats().ActiveCustomCollectors)
+
+	require.NoError(t, collector.EnableCollector("toggle"))
+	assert.Len(t, collector.ListActiveCollectors(), 1)
+
+	_, err = collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tc.collects)
+}
+
+func TestMetricsCollector_ToggleableCollectorIsEnabled(t *testing.T) {
+	collector := NewMetricsCollector("toggleable_collector")
+
+	tc := &toggleCollector{name: "toggle", enabled: false}
+	require.NoError(t, collector.RegisterCollector(tc))
+
+	assert.Empty(t, collector.ListActiveCollectors())
+
+	_, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+	assert.Zero(t, tc.collects)
+
+	// Enabling in the registry does not override the collector's own state.
+	require.NoError(t, collector.EnableCollector("toggle"))
+	assert.Empty(t, collector.ListActiveCollectors())
+
+	tc.enabled = true
+	assert.Len(t, collector.ListActiveCollectors(), 1)
+}
+
+func TestMetricsCollector_ToggleUnknownCollector(t *testing.T) {
+	collector := NewMetricsCollector("unknown_toggle_collector")
+
+	assert.ErrorIs(t, collector.EnableCollector("missing"), ErrCollectorNotFound)
+	assert.ErrorIs(t, collector.DisableCollector("missing"), ErrCollectorNotFound)
+
+	// Unregistering forgets the disabled state.
+	tc := &toggleCollector{name: "toggle", enabled: true}
+	require.NoError(t, collector.RegisterCollector(tc))
+	require.NoError(t, collector.DisableCollector("toggle"))
+	require.NoError(t, collector.UnregisterCollector("toggle"))
+	require.NoError(t, collector.RegisterCollector(tc))
+	assert.Len(t, collector.ListActiveCollectors(), 1)
+}
+
+func TestMetricsCollector_WithLabelsSharesFamily(t *testing.T) {
+	collector := NewMetricsCollector("family_collector")
+
+	requests := collector.Counter("requests_total",
+		WithNamespace("api"), WithDescription("Handled requests"), WithLabel("service", "orders"))
+
+	get := requests.WithLabels(map[string]string{"method": "GET"})
+	post := requests.WithLabels(map[string]string{"method": "POST"})
+
+	get.Add(2)
+	post.Inc()
+
+	assert.Same(t, get, requests.WithLabels(map[string]string{"method": "GET"}))
+	assert.NotSame(t, get, post)
+
+	// Variants share the family metadata and keep the parent's labels
+	parentMeta, getMeta := requests.Describe(), get.Describe()
+	assert.Equal(t, map[string]string{"service": "orders", "method": "GET"}, getMeta.Labels)
+
+	getMeta.Labels = parentMeta.Labels
+	assert.Equal(t, parentMeta, getMeta)
+	assert.Equal(t, "Handled requests", post.Describe().Description)
+
+	names := collector.MetricNames()
+	assert.Contains(t, names, "api_requests_total{method=GET,service=orders}")
+	assert.Contains(t, names, "api_requests_total{method=POST,service=orders}")
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	output := string(data)
+	assert.Equal(t, 1, strings.Count(output, "# TYPE api_requests_total counter"))
+	assert.Contains(t, output, `api_requests_total{method="GET",service="orders"} 2`)
+	assert.Contains(t, output, `api_requests_total{method="POST",service="orders"} 1`)
+	assert.Contains(t, output, `api_requests_total{service="orders"} 0`)
+
+	data, err = collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.Len(t, snapshot.Counters, 3)
+	assert.InDelta(t, 2.0, snapshot.Counters["api_requests_total{method=GET,service=orders}"].Value, 0)
+}
+
+func TestMetricsCollector_WithLabelsKeepsConfiguration(t *testing.T) {
+	collector := NewMetricsCollector("variant_config_collector")
+
+	histogram := collector.Histogram("payload_size", WithBuckets(10, 100))
+	variant := histogram.WithLabels(map[string]string{"route": "/"})
+	variant.Observe(50)
+
+	assert.Equal(t, map[float64]uint64{10: 0, 100: 1}, variant.Buckets())
+
+	timer := collector.Timer("latency", WithTimerUnit(time.Microsecond))
+	assert.Equal(t, "us", timer.WithLabels(map[string]string{"route": "/"}).Describe().Unit)
+
+	summary := collector.Summary("size", WithPercentiles(0.5))
+	summary.WithLabels(map[string]string{"route": "/"}).Observe(1)
+
+	assert.Len(t, collector.ListMetricsByType(MetricTypeSummary), 2)
+
+	// Asking for the parent's own labels returns the parent
+	assert.Same(t, histogram, histogram.WithLabels(nil))
+}
+
+func TestMetricsCollector_WithLabelsCardinalityLimit(t *testing.T) {
+	collector := NewMetricsCollector("variant_limit_collector", WithConfig(&MetricsConfig{
+		Limits: MetricsLimits{MaxMetrics: 2},
+	}))
+
+	counter := collector.Counter("events_total")
+	first := counter.WithLabels(map[string]string{"id": "1"})
+	second := counter.WithLabels(map[string]string{"id": "2"})
+
+	assert.NotSame(t, counter, first)
+	assert.Same(t, counter, second, "variants beyond the limit fall back to the parent")
+}
+
+func TestWithLabels_Standalone(t *testing.T) {
+	counter := NewCounter("standalone_total", WithDescription("Standalone"))
+
+	variant := counter.WithLabels(map[string]string{"method": "GET"})
+	variant.Inc()
+
+	assert.NotSame(t, counter, variant)
+	assert.Equal(t, "Standalone", variant.Describe().Description)
+	assert.InDelta(t, 0.0, counter.Value(), 0)
+}
+
 func TestMetricsCollector_Reset(t *testing.T) {
 	collector := NewMetricsCollector("reset_collector")
 
@@ -1016,10 +2033,66 @@ func TestMetricsCollector_DefaultTags_Precedence(t *testing.T) {
 	assert.Equal(t, "i-123", metadata.ConstLabels["instance"], "Metric-specific labels should be present")
 
 	// Default tags that weren't overridden should still be present
-	// Note: WithConstLabels replaces all const labels, so only the explicitly set ones remain
+	// Note: WithConstLabels replaces all const labels, so only the explicitly set ones remain;
+	// WithConstLabel keeps them
 	assert.NotContains(t, metadata.ConstLabels, "service", "Non-overridden defaults are replaced when using WithConstLabels")
 }
 
+func TestMetricsCollector_DefaultTags_WithConstLabel(t *testing.T) {
+	config := &MetricsConfig{
+		Collection: MetricsCollection{
+			DefaultTags: map[string]string{
+				"env":     "production",
+				"service": "api",
+			},
+		},
+	}
+
+	collector := NewMetricsCollector("test_collector", WithConfig(config))
+
+	counter := collector.Counter("requests",
+		WithConstLabel("instance", "i-123"),
+		WithConstLabel("env", "staging"),
+	)
+
+	assert.Equal(t, map[string]string{
+		"env":      "staging",
+		"service":  "api",
+		"instance": "i-123",
+	}, counter.Describe().ConstLabels)
+
+	// Other metrics and the configured defaults are unaffected
+	assert.Equal(t, map[string]string{
+		"env":     "production",
+		"service": "api",
+	}, collector.Gauge("queue_depth").Describe().ConstLabels)
+	assert.Equal(t, map[string]string{
+		"env":     "production",
+		"service": "api",
+	}, config.Collection.DefaultTags)
+
+	// WithConstLabel after WithConstLabels adds to the replaced labels
+	histogram := collector.Histogram("latency",
+		WithConstLabels(map[string]string{"region": "eu"}),
+		WithConstLabel("instance", "i-456"),
+	)
+	assert.Equal(t, map[string]string{
+		"region":   "eu",
+		"instance": "i-456",
+	}, histogram.Describe().ConstLabels)
+}
+
+func TestWithConstLabel_DoesNotMutateLabels(t *testing.T) {
+	labels := map[string]string{"env": "production"}
+
+	options := &MetricOptions{}
+	WithConstLabels(labels)(options)
+	WithConstLabel("instance", "i-123")(options)
+
+	assert.Equal(t, map[string]string{"env": "production", "instance": "i-123"}, options.ConstLabels)
+	assert.Equal(t, map[string]string{"env": "production"}, labels)
+}
+
 func TestMetricsCollector_DefaultTags_NoConfig(t *testing.T) {
 	// Should work fine with nil config
 	collector := NewMetricsCollector("test_collector")
@@ -1127,7 +2200,7 @@ func TestMetricsCollector_MergeDefaultOptions(t *testing.T) {
 	mc := NewMetricsCollector("test", WithConfig(config)).(*metricsCollector)
 
 	// Test with no options
-	merged := mc.mergeDefaultOptions(nil)
+	merged := mc.mergeDefaultOptions(MetricTypeCounter, nil)
 	assert.Len(t, merged, 2, "Should have namespace and default tags options")
 
 	// Test with existing options
@@ -1136,15 +2209,56 @@ func TestMetricsCollector_MergeDefaultOptions(t *testing.T) {
 		WithUnit("requests"),
 	}
 
-	merged = mc.mergeDefaultOptions(opts)
+	merged = mc.mergeDefaultOptions(MetricTypeCounter, opts)
 	assert.Len(t, merged, 4, "Should have namespace, default tags, and 2 custom options")
 
 	// Test with nil config
 	mcNilConfig := NewMetricsCollector("test").(*metricsCollector)
-	merged = mcNilConfig.mergeDefaultOptions(opts)
+	merged = mcNilConfig.mergeDefaultOptions(MetricTypeCounter, opts)
 	assert.Equal(t, opts, merged, "Should return original opts when config is nil")
 }
 
+func TestMetricsCollector_ConfigDefaultBuckets(t *testing.T) {
+	config := &MetricsConfig{
+		Buckets: MetricsBuckets{
+			Histogram: []float64{1, 2, 4},
+			Timer:     []float64{10, 100},
+		},
+	}
+
+	collector := NewMetricsCollector("test", WithConfig(config))
+
+	histogram := collector.Histogram("payload_size")
+	assert.ElementsMatch(t, []float64{1, 2, 4}, slices.Collect(maps.Keys(histogram.Buckets())))
+
+	timer := collector.Timer("request_duration")
+	assert.ElementsMatch(t, []time.Duration{10 * time.Millisecond, 100 * time.Millisecond},
+		slices.Collect(maps.Keys(timer.Buckets())))
+
+	// Explicit bucket options win over the configured defaults
+	explicit := collector.Histogram("explicit_size", WithBuckets(5, 50))
+	assert.ElementsMatch(t, []float64{5, 50}, slices.Collect(maps.Keys(explicit.Buckets())))
+
+	durations := collector.Timer("explicit_duration", WithDurationBuckets(time.Second))
+	assert.ElementsMatch(t, []time.Duration{time.Second}, slices.Collect(maps.Keys(durations.Buckets())))
+
+	// Other metric types are unaffected
+	assert.Len(t, collector.(*metricsCollector).mergeDefaultOptions(MetricTypeCounter, nil), 0)
+}
+
+func TestMetricsCollector_ConfigDefaultBuckets_Reload(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	config := &MetricsConfig{Buckets: MetricsBuckets{Histogram: []float64{1, 2}}}
+	require.NoError(t, collector.Reload(config))
+
+	// Mutating the caller's config after Reload has no effect
+	config.Buckets.Histogram[0] = 100
+
+	histogram := collector.Histogram("payload_size")
+	assert.ElementsMatch(t, []float64{1, 2}, slices.Collect(maps.Keys(histogram.Buckets())))
+}
+
 // =============================================================================
 // LABEL CARDINALITY TESTS
 // =============================================================================
@@ -1364,3 +2478,275 @@ func TestMetricsCollector_Cardinality_MixedLabels(t *testing.T) {
 	stats := collector.Stats()
 	assert.Equal(t, 2, stats.LabelCardinality, "Should track both combinations")
 }
+
+// =============================================================================
+// RELOAD TESTS
+// =============================================================================
+
+func TestMetricsCollector_Reload(t *testing.T) {
+	collector := NewMetricsCollector("test", WithConfig(&MetricsConfig{
+		Collection: MetricsCollection{
+			DefaultTags: map[string]string{"env": "staging"},
+		},
+	}))
+
+	before := collector.Counter("requests")
+
+	config := &MetricsConfig{
+		Collection: MetricsCollection{
+			Namespace:   "app",
+			DefaultTags: map[string]string{"env": "production"},
+		},
+		Limits: MetricsLimits{MaxMetrics: 50},
+	}
+	require.NoError(t, collector.Reload(config))
+
+	// Mutating the caller's config after Reload has no effect.
+	config.Collection.DefaultTags["env"] = "mutated"
+
+	after := collector.Counter("logins")
+
+	assert.Equal(t, "app_logins", after.Describe().Name)
+	assert.Equal(t, "production", after.Describe().ConstLabels["env"])
+
+	// Existing metrics keep their metadata.
+	assert.Equal(t, "requests", before.Describe().Name)
+	assert.Equal(t, "staging", before.Describe().ConstLabels["env"])
+
+	assert.Equal(t, 50, collector.Stats().MaxLabelCardinality)
+}
+
+func TestMetricsCollector_Reload_EnforcesNewLimit(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_1", WithLabel("endpoint", "/a"))
+
+	require.NoError(t, collector.Reload(&MetricsConfig{Limits: MetricsLimits{MaxMetrics: 2}}))
+
+	collector.Counter("requests_2", WithLabel("endpoint", "/b"))
+	limited := collector.Counter("requests_3", WithLabel("endpoint", "/c"))
+
+	assert.Nil(t, limited.(*counterImpl).exportLabels(), "labels beyond the new limit are dropped")
+	assert.Equal(t, 2, collector.Stats().LabelCardinality)
+}
+
+func TestMetricsCollector_Reload_NilConfig(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	assert.ErrorIs(t, collector.Reload(nil), ErrConfigNil)
+}
+
+func TestMetricsCollector_Reload_Concurrent(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	var wg sync.WaitGroup
+
+	for i := range 10 {
+		wg.Go(func() {
+			_ = collector.Reload(&MetricsConfig{
+				Collection: MetricsCollection{DefaultTags: map[string]string{"worker": fmt.Sprint(i)}},
+			})
+		})
+
+		wg.Go(func() {
+			collector.Counter(fmt.Sprintf("requests_%d", i)).Inc()
+			_ = collector.Stats()
+		})
+	}
+
+	wg.Wait()
+
+	assert.Len(t, collector.MetricNames(), 10)
+}
+
+func TestMetricsCollector_RejectsInvalidLabelKey(t *testing.T) {
+	collector := NewMetricsCollector("validation_collector")
+
+	counter := collector.Counter("logins_total", WithLabel("user id", "42"))
+	require.NotNil(t, counter)
+
+	// The rejected metric still works but is not registered
+	counter.Inc()
+	assert.InDelta(t, 1, counter.Value(), 0)
+	assert.Empty(t, collector.MetricNames())
+
+	stats := collector.Stats()
+	assert.Equal(t, int64(1), stats.ErrorCount)
+	assert.Contains(t, stats.LastError, ErrInvalidLabelKey.Error())
+	assert.Contains(t, stats.LastError, `"user id"`)
+	assert.Equal(t, []string{stats.LastError}, stats.Errors)
+	assert.False(t, stats.LastErrorTime.IsZero())
+}
+
+func TestMetricsCollector_AcceptsValidLabelKeys(t *testing.T) {
+	collector := NewMetricsCollector("validation_collector")
+
+	collector.Counter("logins_total", WithLabel("user_id", "42"), WithConstLabels(map[string]string{"_region2": "eu"})).Inc()
+	collector.Gauge("sessions", WithLabel("Client", "web")).Set(3)
+
+	assert.Len(t, collector.MetricNames(), 2)
+	assert.Zero(t, collector.Stats().ErrorCount)
+	assert.Empty(t, collector.Stats().LastError)
+}
+
+func TestMetricsCollector_RejectsInvalidMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		create  func(Metrics)
+		wantErr error
+	}{
+		{name: "empty name", create: func(m Metrics) { m.Gauge("") }, wantErr: ErrEmptyMetricName},
+		{name: "leading digit", create: func(m Metrics) { m.Histogram("size", WithLabel("1st", "a")) }, wantErr: ErrInvalidLabelKey},
+		{name: "dash in const label", create: func(m Metrics) {
+			m.Summary("latency", WithConstLabels(map[string]string{"pod-name": "a"}))
+		}, wantErr: ErrInvalidLabelKey},
+		{name: "empty key", create: func(m Metrics) { m.Timer("query", WithLabel("", "a")) }, wantErr: ErrInvalidLabelKey},
+		{name: "variant label", create: func(m Metrics) {
+			m.Counter("requests_total").WithLabels(map[string]string{"http.method": "GET"})
+		}, wantErr: ErrInvalidLabelKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := NewMetricsCollector("validation_collector")
+			tt.create(collector)
+
+			stats := collector.Stats()
+			assert.Equal(t, int64(1), stats.ErrorCount)
+			assert.Contains(t, stats.LastError, tt.wantErr.Error())
+			assert.LessOrEqual(t, stats.ActiveMetrics, 1)
+		})
+	}
+}
+
+func TestMetricsCollector_RecentErrorsBounded(t *testing.T) {
+	collector := NewMetricsCollector("validation_collector")
+
+	for i := range maxRecentErrors + 5 {
+		collector.Counter(fmt.Sprintf("bad_%d", i), WithLabel("bad key", "x"))
+	}
+
+	stats := collector.Stats()
+	assert.Equal(t, int64(maxRecentErrors+5), stats.ErrorCount)
+	assert.Len(t, stats.Errors, maxRecentErrors)
+	assert.Equal(t, stats.Errors[len(stats.Errors)-1], stats.LastError)
+}
+
+// closeCollector records whether Close was called.
+type closeCollector struct {
+	toggleCollector
+
+	closed int
+	err    error
+}
+
+func (c *closeCollector) Close(ctx context.Context) error {
+	c.closed++
+
+	return c.err
+}
+
+// recordingExporter records every export and fails with err.
+type recordingExporter struct {
+	exports []map[string]any
+	err     error
+}
+
+func (e *recordingExporter) Export(metrics map[string]any) ([]byte, error) {
+	e.exports = append(e.exports, metrics)
+
+	return nil, e.err
+}
+
+func (e *recordingExporter) Format() string       { return "recording" }
+func (e *recordingExporter) Stats() ExporterStats { return ExporterStats{Format: "recording"} }
+
+func TestMetricsCollector_Close(t *testing.T) {
+	exporter := &recordingExporter{}
+	collector := NewMetricsCollector("close_collector", WithExporter("final", exporter))
+	require.NoError(t, collector.Start(context.Background()))
+
+	counter := collector.Counter("jobs_total")
+	counter.Add(3)
+
+	closable := &closeCollector{toggleCollector: toggleCollector{name: "closable"}}
+	require.NoError(t, collector.RegisterCollector(closable))
+	require.NoError(t, collector.RegisterCollector(&toggleCollector{name: "plain"}))
+
+	require.NoError(t, collector.Close(context.Background()))
+
+	assert.Equal(t, 1, closable.closed)
+	require.Len(t, exporter.exports, 1)
+	assert.Contains(t, exporter.exports[0], "jobs_total")
+
+	// The collector is unusable afterwards
+	assert.ErrorIs(t, collector.Health(context.Background()), ErrMetricsClosed)
+	assert.ErrorIs(t, collector.Start(context.Background()), ErrMetricsClosed)
+	assert.False(t, collector.Stats().Started)
+
+	collector.Counter("late_total").Inc()
+	assert.InDelta(t, 0, collector.Counter("late_total").Value(), 0)
+	assert.NotContains(t, collector.MetricNames(), "late_total")
+
+	// Existing metrics keep their values
+	assert.InDelta(t, 3, counter.Value(), 0)
+
+	// Closing again does nothing
+	require.NoError(t, collector.Close(context.Background()))
+	assert.Equal(t, 1, closable.closed)
+	assert.Len(t, exporter.exports, 1)
+}
+
+func TestMetricsCollector_CloseErrors(t *testing.T) {
+	exportErr := errors.New("endpoint unreachable")
+	closeErr := errors.New("still draining")
+
+	collector := NewMetricsCollector("close_collector", WithExporter("remote", &recordingExporter{err: exportErr}))
+	require.NoError(t, collector.RegisterCollector(&closeCollector{
+		toggleCollector: toggleCollector{name: "closable"},
+		err:             closeErr,
+	}))
+
+	err := collector.Close(context.Background())
+	require.ErrorIs(t, err, exportErr)
+	require.ErrorIs(t, err, closeErr)
+	assert.Contains(t, err.Error(), "final export remote")
+}
+
+// contextExporter records the context of every ExportContext call.
+type contextExporter struct {
+	recordingExporter
+
+	ctxs []context.Context
+}
+
+func (e *contextExporter) ExportContext(ctx context.Context, metrics map[string]any) ([]byte, error) {
+	e.ctxs = append(e.ctxs, ctx)
+
+	return e.Export(metrics)
+}
+
+type closeCtxKey struct{}
+
+func TestMetricsCollector_CloseExportContext(t *testing.T) {
+	exporter := &contextExporter{}
+	collector := NewMetricsCollector("close_collector", WithExporter("final", exporter))
+
+	ctx := context.WithValue(context.Background(), closeCtxKey{}, "close")
+	require.NoError(t, collector.Close(ctx))
+
+	// The final export runs through ExportContext with the context of Close
+	require.Len(t, exporter.ctxs, 1)
+	assert.Equal(t, "close", exporter.ctxs[0].Value(closeCtxKey{}))
+	assert.Len(t, exporter.exports, 1)
+}
+
+func TestMetricsCollector_CloseCancelled(t *testing.T) {
+	exporter := &recordingExporter{}
+	collector := NewMetricsCollector("close_collector", WithExporter("final", exporter))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.ErrorIs(t, collector.Close(ctx), context.Canceled)
+	assert.Empty(t, exporter.exports)
+}
@@ -12,12 +12,13 @@ import (
 
 // Ensure all mock implementations satisfy their respective interfaces.
 var (
-	_ Metrics   = (*MockMetrics)(nil)
-	_ Counter   = (*MockCounter)(nil)
-	_ Gauge     = (*MockGauge)(nil)
-	_ Histogram = (*MockHistogram)(nil)
-	_ Summary   = (*MockSummary)(nil)
-	_ Timer     = (*MockTimer)(nil)
+	_ Metrics        = (*MockMetrics)(nil)
+	_ Counter        = (*MockCounter)(nil)
+	_ Gauge          = (*MockGauge)(nil)
+	_ Histogram      = (*MockHistogram)(nil)
+	_ TimedHistogram = (*MockHistogram)(nil)
+	_ Summary        = (*MockSummary)(nil)
+	_ Timer          = (*MockTimer)(nil)
 )
 
 // =============================================================================
@@ -43,39 +44,56 @@ type MockMetrics struct {
 	TimerFunc     func(name string, opts ...MetricOption) Timer
 
 	// MetricExporter interface
-	ExportFunc       func(format ExportFormat) ([]byte, error)
-	ExportToFileFunc func(format ExportFormat, filename string) error
+	ExportFunc         func(format ExportFormat) ([]byte, error)
+	ExportContextFunc  func(ctx context.Context, format ExportFormat) ([]byte, error)
+	ExportToFileFunc   func(format ExportFormat, filename string) error
+	ExportDeltaFunc    func(format ExportFormat) ([]byte, error)
+	StartExportersFunc func(ctx context.Context) error
 
 	// CollectorRegistry interface
-	RegisterCollectorFunc   func(collector CustomCollector) error
-	UnregisterCollectorFunc func(name string) error
-	ListCollectorsFunc      func() []CustomCollector
+	RegisterCollectorFunc    func(collector CustomCollector) error
+	UnregisterCollectorFunc  func(name string) error
+	ListCollectorsFunc       func() []CustomCollector
+	EnableCollectorFunc      func(name string) error
+	DisableCollectorFunc     func(name string) error
+	ListActiveCollectorsFunc func() []CustomCollector
 
 	// MetricRepository interface
-	ListMetricsFunc       func() map[string]any
-	ListMetricsByTypeFunc func(metricType MetricType) map[string]any
-	ListMetricsByTagFunc  func(tagKey, tagValue string) map[string]any
-	StatsFunc             func() CollectorStats
+	ListMetricsFunc        func() map[string]any
+	ListMetricsByTypeFunc  func(metricType MetricType) map[string]any
+	ListMetricsByTagFunc   func(tagKey, tagValue string) map[string]any
+	ListFamiliesFunc       func() []MetricFamily
+	MetricNamesFunc        func() []string
+	SnapshotFunc           func() map[string]MetricSnapshotEntry
+	StatsFunc              func() CollectorStats
+	GatherAllFunc          func(ctx context.Context) (CollectorStats, *HealthReport)
+	AggregateFunc          func(name string) (float64, error)
+	AggregateHistogramFunc func(name string) (HistogramAggregate, error)
 
 	// MetricManager interface
 	ResetFunc       func() error
 	ResetMetricFunc func(name string) error
 	ReloadFunc      func(config *MetricsConfig) error
+	CloseFunc       func(ctx context.Context) error
 
 	// Call tracking
-	NameCalls         int
-	StartCalls        int
-	StopCalls         int
-	HealthCalls       int
-	CounterCalls      int
-	GaugeCalls        int
-	HistogramCalls    int
-	SummaryCalls      int
-	TimerCalls        int
-	ExportCalls       int
-	ExportToFileCalls int
-	ResetCalls        int
-	ReloadCalls       int
+	NameCalls           int
+	StartCalls          int
+	StopCalls           int
+	HealthCalls         int
+	CounterCalls        int
+	GaugeCalls          int
+	HistogramCalls      int
+	SummaryCalls        int
+	TimerCalls          int
+	ExportCalls         int
+	ExportContextCalls  int
+	ExportToFileCalls   int
+	ResetCalls          int
+	ReloadCalls         int
+	CloseCalls          int
+	GatherAllCalls      int
+	StartExportersCalls int
 }
 
 // NewMockMetrics creates a new mock metrics with sensible defaults.
@@ -107,9 +125,22 @@ func NewMockMetrics() *MockMetrics {
 	m.ExportFunc = func(format ExportFormat) ([]byte, error) {
 		return []byte("{}"), nil
 	}
+	m.ExportContextFunc = func(ctx context.Context, format ExportFormat) ([]byte, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return []byte("{}"), nil
+	}
 	m.ExportToFileFunc = func(format ExportFormat, filename string) error {
 		return nil
 	}
+	m.ExportDeltaFunc = func(format ExportFormat) ([]byte, error) {
+		return []byte("{}"), nil
+	}
+	m.StartExportersFunc = func(ctx context.Context) error {
+		return nil
+	}
 
 	m.RegisterCollectorFunc = func(collector CustomCollector) error {
 		return nil
@@ -120,6 +151,15 @@ func NewMockMetrics() *MockMetrics {
 	m.ListCollectorsFunc = func() []CustomCollector {
 		return []CustomCollector{}
 	}
+	m.EnableCollectorFunc = func(name string) error {
+		return nil
+	}
+	m.DisableCollectorFunc = func(name string) error {
+		return nil
+	}
+	m.ListActiveCollectorsFunc = func() []CustomCollector {
+		return []CustomCollector{}
+	}
 
 	m.ListMetricsFunc = func() map[string]any {
 		return make(map[string]any)
@@ -130,6 +170,15 @@ func NewMockMetrics() *MockMetrics {
 	m.ListMetricsByTagFunc = func(tagKey, tagValue string) map[string]any {
 		return make(map[string]any)
 	}
+	m.ListFamiliesFunc = func() []MetricFamily {
+		return []MetricFamily{}
+	}
+	m.MetricNamesFunc = func() []string {
+		return []string{}
+	}
+	m.SnapshotFunc = func() map[string]MetricSnapshotEntry {
+		return make(map[string]MetricSnapshotEntry)
+	}
 	m.StatsFunc = func() CollectorStats {
 		return CollectorStats{
 			Name:    "mock-metrics",
@@ -137,9 +186,20 @@ func NewMockMetrics() *MockMetrics {
 		}
 	}
 
+	m.GatherAllFunc = func(ctx context.Context) (CollectorStats, *HealthReport) {
+		return m.StatsFunc(), nil
+	}
+	m.AggregateFunc = func(name string) (float64, error) {
+		return 0, nil
+	}
+	m.AggregateHistogramFunc = func(name string) (HistogramAggregate, error) {
+		return HistogramAggregate{Name: name}, nil
+	}
+
 	m.ResetFunc = func() error { return nil }
 	m.ResetMetricFunc = func(name string) error { return nil }
 	m.ReloadFunc = func(config *MetricsConfig) error { return nil }
+	m.CloseFunc = func(ctx context.Context) error { return nil }
 
 	return m
 }
@@ -240,6 +300,15 @@ func (m *MockMetrics) Export(format ExportFormat) ([]byte, error) {
 	return m.ExportFunc(format)
 }
 
+func (m *MockMetrics) ExportContext(ctx context.Context, format ExportFormat) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ExportContextCalls++
+
+	return m.ExportContextFunc(ctx, format)
+}
+
 func (m *MockMetrics) ExportToFile(format ExportFormat, filename string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -249,6 +318,22 @@ func (m *MockMetrics) ExportToFile(format ExportFormat, filename string) error {
 	return m.ExportToFileFunc(format, filename)
 }
 
+func (m *MockMetrics) ExportDelta(format ExportFormat) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ExportDeltaFunc(format)
+}
+
+func (m *MockMetrics) StartExporters(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.StartExportersCalls++
+
+	return m.StartExportersFunc(ctx)
+}
+
 // CollectorRegistry interface implementation
 
 func (m *MockMetrics) RegisterCollector(collector CustomCollector) error {
@@ -272,6 +357,27 @@ func (m *MockMetrics) ListCollectors() []CustomCollector {
 	return m.ListCollectorsFunc()
 }
 
+func (m *MockMetrics) EnableCollector(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.EnableCollectorFunc(name)
+}
+
+func (m *MockMetrics) DisableCollector(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.DisableCollectorFunc(name)
+}
+
+func (m *MockMetrics) ListActiveCollectors() []CustomCollector {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ListActiveCollectorsFunc()
+}
+
 // MetricRepository interface implementation
 
 func (m *MockMetrics) ListMetrics() map[string]any {
@@ -288,6 +394,13 @@ func (m *MockMetrics) ListMetricsByType(metricType MetricType) map[string]any {
 	return m.ListMetricsByTypeFunc(metricType)
 }
 
+func (m *MockMetrics) ListFamilies() []MetricFamily {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ListFamiliesFunc()
+}
+
 func (m *MockMetrics) ListMetricsByTag(tagKey, tagValue string) map[string]any {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -295,6 +408,20 @@ func (m *MockMetrics) ListMetricsByTag(tagKey, tagValue string) map[string]any {
 	return m.ListMetricsByTagFunc(tagKey, tagValue)
 }
 
+func (m *MockMetrics) MetricNames() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.MetricNamesFunc()
+}
+
+func (m *MockMetrics) Snapshot() map[string]MetricSnapshotEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.SnapshotFunc()
+}
+
 func (m *MockMetrics) Stats() CollectorStats {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -302,6 +429,29 @@ func (m *MockMetrics) Stats() CollectorStats {
 	return m.StatsFunc()
 }
 
+func (m *MockMetrics) GatherAll(ctx context.Context) (CollectorStats, *HealthReport) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.GatherAllCalls++
+
+	return m.GatherAllFunc(ctx)
+}
+
+func (m *MockMetrics) Aggregate(name string) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.AggregateFunc(name)
+}
+
+func (m *MockMetrics) AggregateHistogram(name string) (HistogramAggregate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.AggregateHistogramFunc(name)
+}
+
 // MetricManager interface implementation
 
 func (m *MockMetrics) Reset() error {
@@ -329,6 +479,15 @@ func (m *MockMetrics) Reload(config *MetricsConfig) error {
 	return m.ReloadFunc(config)
 }
 
+func (m *MockMetrics) Close(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.CloseCalls++
+
+	return m.CloseFunc(ctx)
+}
+
 // =============================================================================
 // MOCK METRIC TYPES
 // =============================================================================
@@ -496,6 +655,25 @@ func (g *MockGauge) SetToCurrentTime() {
 	g.timestamp = now
 }
 
+func (g *MockGauge) SetToCurrentTimeNanos() {
+	g.SetTime(time.Now())
+}
+
+func (g *MockGauge) SetTime(t time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.value = float64(t.UnixNano())
+	g.timestamp = time.Now()
+}
+
+func (g *MockGauge) TimeValue() time.Time {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return time.Unix(0, int64(g.value))
+}
+
 func (g *MockGauge) Value() float64 {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
@@ -510,6 +688,11 @@ func (g *MockGauge) Timestamp() time.Time {
 	return g.timestamp
 }
 
+// History returns nil; MockGauge retains no history.
+func (g *MockGauge) History() []GaugePoint {
+	return nil
+}
+
 func (g *MockGauge) Describe() MetricMetadata {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
@@ -557,6 +740,16 @@ func (h *MockHistogram) Observe(value float64) {
 	h.values = append(h.values, value)
 }
 
+// ObserveDuration observes the time elapsed since start in seconds, or in the
+// unit of the mock's metadata.
+func (h *MockHistogram) ObserveDuration(start time.Time) {
+	h.mu.RLock()
+	unit := unitDuration(h.metadata.Unit)
+	h.mu.RUnlock()
+
+	h.Observe(float64(time.Since(start)) / float64(unit))
+}
+
 func (h *MockHistogram) ObserveWithExemplar(value float64, exemplar Exemplar) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -708,6 +901,10 @@ func (h *MockHistogram) Buckets() map[float64]uint64 {
 	return buckets
 }
 
+func (h *MockHistogram) NativeBuckets() *NativeHistogram {
+	return nil
+}
+
 func (h *MockHistogram) Exemplars() []Exemplar {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -786,6 +983,10 @@ func (t *MockTimer) Time() func() {
 	}
 }
 
+func (t *MockTimer) ObserveDuration(start time.Time) {
+	t.Record(time.Since(start))
+}
+
 func (t *MockTimer) Count() uint64 {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -904,6 +1105,77 @@ func (t *MockTimer) Max() time.Duration {
 	return maxDuration
 }
 
+func (t *MockTimer) Buckets() map[time.Duration]uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	bounds := mockTimerBuckets()
+	buckets := make(map[time.Duration]uint64, len(bounds))
+
+	for _, boundary := range bounds {
+		buckets[boundary] = 0
+	}
+
+	for _, d := range t.durations {
+		for _, boundary := range bounds {
+			if d <= boundary {
+				buckets[boundary]++
+
+				break
+			}
+		}
+	}
+
+	return buckets
+}
+
+func (t *MockTimer) CumulativeBuckets() map[time.Duration]uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	bounds := mockTimerBuckets()
+	buckets := make(map[time.Duration]uint64, len(bounds))
+
+	for _, boundary := range bounds {
+		buckets[boundary] = 0
+
+		for _, d := range t.durations {
+			if d <= boundary {
+				buckets[boundary]++
+			}
+		}
+	}
+
+	return buckets
+}
+
+// Histogram returns a MockHistogram holding a snapshot of the recorded
+// durations in milliseconds.
+func (t *MockTimer) Histogram() Histogram {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	histogram := NewMockHistogram()
+	histogram.metadata.Name = t.metadata.Name
+
+	for _, d := range t.durations {
+		histogram.values = append(histogram.values, float64(d)/float64(time.Millisecond))
+	}
+
+	return histogram
+}
+
+// mockTimerBuckets returns the fixed bucket bounds used by MockTimer.
+func mockTimerBuckets() []time.Duration {
+	return []time.Duration{
+		10 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+	}
+}
+
 func (t *MockTimer) Exemplars() []Exemplar {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -1068,6 +1340,10 @@ func (s *MockSummary) StdDev() float64 {
 	return variance // Simplified: should be sqrt(variance)
 }
 
+func (s *MockSummary) Objectives() map[float64]float64 {
+	return nil
+}
+
 func (s *MockSummary) Describe() MetricMetadata {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -0,0 +1,224 @@
+package metrics
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"sync"
+)
+
+// =============================================================================
+// NATIVE HISTOGRAM BUCKETS
+// =============================================================================
+
+const (
+	// MinNativeSchema is the lowest supported native histogram schema
+	// (growth factor 2^16 per bucket).
+	MinNativeSchema = -4
+
+	// MaxNativeSchema is the highest supported native histogram schema
+	// (growth factor 2^(1/256) per bucket).
+	MaxNativeSchema = 8
+
+	// DefaultNativeZeroThreshold is the width of the zero bucket. Observations
+	// with an absolute value at or below it are counted as zero.
+	DefaultNativeZeroThreshold = 2.938735877055719e-39 // 2^-128
+)
+
+// NativeHistogram is a snapshot of the exponential buckets of a histogram
+// created with WithNativeBuckets.
+//
+// Bucket index i of schema s covers (base^(i-1), base^i] with
+// base = 2^(2^-s); negative buckets mirror positive ones for negative values.
+type NativeHistogram struct {
+	Schema        int            `json:"schema"`
+	ZeroThreshold float64        `json:"zero_threshold"`
+	ZeroCount     uint64         `json:"zero_count"`
+	Positive      map[int]uint64 `json:"positive,omitempty"`
+	Negative      map[int]uint64 `json:"negative,omitempty"`
+}
+
+// UpperBound returns the upper bound of the positive bucket with the given index.
+func (n *NativeHistogram) UpperBound(index int) float64 {
+	return nativeBucketBound(n.Schema, index)
+}
+
+// scale returns a copy of n with every observation multiplied by factor,
+// keeping the schema. Each bucket moves whole to the bucket holding its
+// scaled geometric midpoint, so counts stay exact when factor is a power of
+// the growth factor and are otherwise off by at most one bucket.
+func (n *NativeHistogram) scale(factor float64) *NativeHistogram {
+	scaled := &NativeHistogram{
+		Schema:        n.Schema,
+		ZeroThreshold: n.ZeroThreshold,
+		ZeroCount:     n.ZeroCount,
+		Positive:      make(map[int]uint64, len(n.Positive)),
+		Negative:      make(map[int]uint64, len(n.Negative)),
+	}
+
+	rebucket := func(dst, src map[int]uint64) {
+		for idx, count := range src {
+			mid := math.Sqrt(nativeBucketBound(n.Schema, idx-1)) * math.Sqrt(nativeBucketBound(n.Schema, idx)) * factor
+			if mid <= n.ZeroThreshold {
+				scaled.ZeroCount += count
+
+				continue
+			}
+
+			dst[nativeBucketIndex(n.Schema, mid)] += count
+		}
+	}
+
+	rebucket(scaled.Positive, n.Positive)
+	rebucket(scaled.Negative, n.Negative)
+
+	return scaled
+}
+
+// nativeBuckets stores observations in exponential buckets that are
+// allocated on first use.
+type nativeBuckets struct {
+	mu            sync.Mutex
+	schema        int
+	zeroThreshold float64
+	zeroCount     uint64
+	positive      map[int]uint64
+	negative      map[int]uint64
+}
+
+// nativeSpan is a populated bucket with its value range.
+type nativeSpan struct {
+	lower, upper float64
+	count        uint64
+}
+
+func newNativeBuckets(schema int) *nativeBuckets {
+	return &nativeBuckets{
+		schema:        min(max(schema, MinNativeSchema), MaxNativeSchema),
+		zeroThreshold: DefaultNativeZeroThreshold,
+		positive:      make(map[int]uint64),
+		negative:      make(map[int]uint64),
+	}
+}
+
+func (n *nativeBuckets) observe(value float64, count uint64) {
+	if math.IsNaN(value) {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	switch {
+	case math.Abs(value) <= n.zeroThreshold:
+		n.zeroCount += count
+	case value > 0:
+		n.positive[nativeBucketIndex(n.schema, value)] += count
+	default:
+		n.negative[nativeBucketIndex(n.schema, -value)] += count
+	}
+}
+
+func (n *nativeBuckets) reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.zeroCount = 0
+	n.positive = make(map[int]uint64)
+	n.negative = make(map[int]uint64)
+}
+
+func (n *nativeBuckets) snapshot() *NativeHistogram {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return &NativeHistogram{
+		Schema:        n.schema,
+		ZeroThreshold: n.zeroThreshold,
+		ZeroCount:     n.zeroCount,
+		Positive:      maps.Clone(n.positive),
+		Negative:      maps.Clone(n.negative),
+	}
+}
+
+// spans returns the populated buckets ordered from the lowest to the highest values.
+func (n *nativeBuckets) spans() []nativeSpan {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	spans := make([]nativeSpan, 0, len(n.negative)+len(n.positive)+1)
+
+	for _, idx := range slices.Backward(slices.Sorted(maps.Keys(n.negative))) {
+		spans = append(spans, nativeSpan{
+			lower: -nativeBucketBound(n.schema, idx),
+			upper: -nativeBucketBound(n.schema, idx-1),
+			count: n.negative[idx],
+		})
+	}
+
+	if n.zeroCount > 0 {
+		spans = append(spans, nativeSpan{lower: -n.zeroThreshold, upper: n.zeroThreshold, count: n.zeroCount})
+	}
+
+	for _, idx := range slices.Sorted(maps.Keys(n.positive)) {
+		spans = append(spans, nativeSpan{
+			lower: nativeBucketBound(n.schema, idx-1),
+			upper: nativeBucketBound(n.schema, idx),
+			count: n.positive[idx],
+		})
+	}
+
+	return spans
+}
+
+// quantile estimates the q-quantile by interpolating exponentially within the
+// bucket that contains the target rank.
+func (n *nativeBuckets) quantile(q float64, count uint64) float64 {
+	rank := q * float64(count)
+	cumulative := 0.0
+
+	for _, span := range n.spans() {
+		previous := cumulative
+		cumulative += float64(span.count)
+
+		if cumulative < rank {
+			continue
+		}
+
+		fraction := (rank - previous) / float64(span.count)
+
+		if span.lower > 0 || span.upper < 0 {
+			return span.lower * math.Pow(span.upper/span.lower, fraction)
+		}
+
+		// The zero bucket straddles zero, so interpolate linearly.
+		return span.lower + (span.upper-span.lower)*fraction
+	}
+
+	return 0
+}
+
+// nativeBucketIndex returns the index of the bucket containing the positive value v.
+func nativeBucketIndex(schema int, v float64) int {
+	frac, exp := math.Frexp(v) // v = frac * 2^exp, frac in [0.5, 1)
+
+	if schema > 0 {
+		// log2(v) = (exp-1) + log2(2*frac), with log2(2*frac) in [0, 1).
+		return (exp-1)<<schema + int(math.Ceil(math.Log2(2*frac)*float64(int(1)<<schema)))
+	}
+
+	// ceil(log2(v)) is exp-1 for exact powers of two and exp otherwise.
+	ceilLog := exp
+	if frac == 0.5 {
+		ceilLog = exp - 1
+	}
+
+	width := 1 << -schema
+
+	return int(math.Ceil(float64(ceilLog) / float64(width)))
+}
+
+// nativeBucketBound returns the upper bound of the positive bucket with the given index.
+func nativeBucketBound(schema, index int) float64 {
+	return math.Exp2(float64(index) * math.Ldexp(1, -schema))
+}
@@ -0,0 +1,179 @@
+package metrics
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeBucketIndex(t *testing.T) {
+	tests := []struct {
+		schema int
+		value  float64
+		want   int
+	}{
+		{schema: 0, value: 1, want: 0},
+		{schema: 0, value: 1.5, want: 1},
+		{schema: 0, value: 2, want: 1},
+		{schema: 0, value: 2.1, want: 2},
+		{schema: 0, value: 0.5, want: -1},
+		{schema: 1, value: 2, want: 2},
+		{schema: 1, value: 1.4, want: 1},
+		{schema: 1, value: 1.5, want: 2},
+		{schema: 3, value: 1024, want: 80},
+		{schema: -1, value: 4, want: 1},
+		{schema: -1, value: 5, want: 2},
+		{schema: -2, value: 16, want: 1},
+		{schema: -2, value: 17, want: 2},
+	}
+
+	for _, tt := range tests {
+		got := nativeBucketIndex(tt.schema, tt.value)
+		assert.Equal(t, tt.want, got, "schema=%d value=%v", tt.schema, tt.value)
+
+		// The value must fall inside (bound(idx-1), bound(idx)]
+		assert.LessOrEqual(t, tt.value, nativeBucketBound(tt.schema, got)*(1+1e-12))
+		assert.Greater(t, tt.value, nativeBucketBound(tt.schema, got-1))
+	}
+}
+
+func TestNativeHistogram_Observe(t *testing.T) {
+	h := NewHistogram("native", WithNativeBuckets(0))
+
+	h.Observe(0)
+	h.Observe(1)
+	h.Observe(3)
+	h.Observe(3.5)
+	h.Observe(-3)
+	h.Observe(math.NaN())
+
+	native := h.NativeBuckets()
+	require.NotNil(t, native)
+	assert.Equal(t, 0, native.Schema)
+	assert.Equal(t, uint64(1), native.ZeroCount)
+	assert.Equal(t, map[int]uint64{0: 1, 2: 2}, native.Positive)
+	assert.Equal(t, map[int]uint64{2: 1}, native.Negative)
+	assert.InDelta(t, 4.0, native.UpperBound(2), 1e-12)
+
+	buckets := h.Buckets()
+	assert.Equal(t, uint64(2), buckets[4])
+	assert.Equal(t, uint64(1), buckets[1])
+	assert.Equal(t, uint64(1), buckets[-2])
+}
+
+func TestNativeHistogram_SchemaClamped(t *testing.T) {
+	assert.Equal(t, MaxNativeSchema, NewHistogram("high", WithNativeBuckets(42)).NativeBuckets().Schema)
+	assert.Equal(t, MinNativeSchema, NewHistogram("low", WithNativeBuckets(-42)).NativeBuckets().Schema)
+}
+
+func TestNativeHistogram_ClassicHasNoNativeBuckets(t *testing.T) {
+	h := NewHistogram("classic")
+	h.Observe(1)
+
+	assert.Nil(t, h.NativeBuckets())
+}
+
+func TestNativeHistogram_P99Accuracy(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	classic := NewHistogram("classic", WithDefaultHistogramBuckets())
+	native := NewHistogram("native", WithNativeBuckets(3))
+
+	values := make([]float64, 100000)
+	for i := range values {
+		// Lognormal latencies centred around ~50ms with a long tail
+		values[i] = math.Exp(rng.NormFloat64()*1.2 + math.Log(50))
+		classic.Observe(values[i])
+		native.Observe(values[i])
+	}
+
+	slices.Sort(values)
+	want := values[int(0.99*float64(len(values)))-1]
+
+	classicErr := math.Abs(classic.Percentile(0.99)-want) / want
+	nativeErr := math.Abs(native.Percentile(0.99)-want) / want
+
+	assert.Less(t, nativeErr, classicErr)
+	assert.Less(t, nativeErr, 0.05, "schema 3 buckets are ~9%% wide")
+	assert.LessOrEqual(t, native.Percentile(1), native.Max())
+	assert.GreaterOrEqual(t, native.Percentile(0), native.Min())
+}
+
+func TestNativeHistogram_Reset(t *testing.T) {
+	h := NewHistogram("native", WithNativeBuckets(2))
+	h.Observe(10)
+
+	require.NoError(t, h.Reset())
+
+	native := h.NativeBuckets()
+	assert.Empty(t, native.Positive)
+	assert.Equal(t, uint64(0), h.Count())
+	assert.Equal(t, 0.0, h.Percentile(0.5))
+}
+
+func TestNativeHistogram_WithLabels(t *testing.T) {
+	h := NewHistogram("native", WithNativeBuckets(2))
+
+	labeled := h.WithLabels(map[string]string{"route": "/"})
+	labeled.Observe(10)
+
+	require.NotNil(t, labeled.NativeBuckets())
+	assert.Equal(t, 2, labeled.NativeBuckets().Schema)
+}
+
+func TestNativeHistogram_PrometheusExport(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	h := collector.Histogram("latency", WithNativeBuckets(0))
+	h.Observe(1)
+	h.Observe(3)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	output := string(data)
+	assert.Contains(t, output, "# TYPE latency histogram\n")
+	assert.Contains(t, output, `latency_bucket{le="1"} 1`)
+	assert.Contains(t, output, `latency_bucket{le="4"} 2`)
+	assert.Contains(t, output, `latency_bucket{le="+Inf"} 2`)
+	assert.Contains(t, output, "latency_count 2")
+	assert.Equal(t, 1, strings.Count(output, `le="+Inf"`))
+}
+
+func TestNativeHistogram_TimerBuckets(t *testing.T) {
+	timer := NewTimer("native", WithNativeBuckets(0))
+	timer.Record(3 * time.Millisecond)
+	timer.Record(3 * time.Millisecond)
+	timer.Record(100 * time.Millisecond)
+
+	// Bucket upper bounds in milliseconds: 4 and 128
+	assert.Equal(t, map[time.Duration]uint64{
+		4 * time.Millisecond:   2,
+		128 * time.Millisecond: 1,
+	}, timer.Buckets())
+	assert.Equal(t, map[time.Duration]uint64{
+		4 * time.Millisecond:   2,
+		128 * time.Millisecond: 3,
+	}, timer.CumulativeBuckets())
+}
+
+func TestNativeHistogram_Scale(t *testing.T) {
+	h := NewHistogram("native", WithNativeBuckets(0))
+	for _, v := range []float64{0, 3, 500, 3000, -3000} {
+		h.Observe(v)
+	}
+
+	// Milliseconds to seconds: 500 lands in (0.25, 0.5] and 3000 in (2, 4]
+	scaled := h.NativeBuckets().scale(0.001)
+	assert.Equal(t, uint64(1), scaled.ZeroCount)
+	assert.Equal(t, map[int]uint64{-8: 1, -1: 1, 2: 1}, scaled.Positive)
+	assert.Equal(t, map[int]uint64{2: 1}, scaled.Negative)
+
+	// A power of the growth factor keeps every bucket
+	assert.Equal(t, map[int]uint64{3: 1, 10: 1, 13: 1}, h.NativeBuckets().scale(2).Positive)
+}
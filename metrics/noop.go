@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// NOOP METRICS
+// =============================================================================
+
+// NewNoopMetrics returns a Metrics implementation that discards everything.
+// Inject it where metrics are optional instead of nil-checking at every call
+// site. All factories return shared no-op singletons, so recording a metric
+// does not allocate.
+func NewNoopMetrics() Metrics {
+	return noopMetricsInstance
+}
+
+var (
+	noopMetricsInstance Metrics   = noopMetrics{}
+	noopCounterInstance Counter   = noopCounter{}
+	noopGaugeInstance   Gauge     = noopGauge{}
+	noopHistInstance    Histogram = noopHistogram{}
+	noopSummaryInstance Summary   = noopSummary{}
+	noopTimerInstance   Timer     = noopTimer{}
+
+	noopStop = func() {}
+)
+
+// noopMetrics is a Metrics implementation that does nothing.
+type noopMetrics struct{}
+
+func (noopMetrics) Name() string                    { return "noop" }
+func (noopMetrics) Start(ctx context.Context) error { return nil }
+func (noopMetrics) Stop(ctx context.Context) error  { return nil }
+func (noopMetrics) Health(ctx context.Context) error {
+	return nil
+}
+
+func (noopMetrics) Counter(name string, opts ...MetricOption) Counter     { return noopCounterInstance }
+func (noopMetrics) Gauge(name string, opts ...MetricOption) Gauge         { return noopGaugeInstance }
+func (noopMetrics) Histogram(name string, opts ...MetricOption) Histogram { return noopHistInstance }
+func (noopMetrics) Summary(name string, opts ...MetricOption) Summary     { return noopSummaryInstance }
+func (noopMetrics) Timer(name string, opts ...MetricOption) Timer         { return noopTimerInstance }
+
+func (noopMetrics) Export(format ExportFormat) ([]byte, error) { return nil, nil }
+func (noopMetrics) ExportContext(ctx context.Context, format ExportFormat) ([]byte, error) {
+	return nil, nil
+}
+func (noopMetrics) ExportToFile(format ExportFormat, filename string) error { return nil }
+func (noopMetrics) ExportDelta(format ExportFormat) ([]byte, error)         { return nil, nil }
+func (noopMetrics) StartExporters(ctx context.Context) error                { return nil }
+
+func (noopMetrics) RegisterCollector(collector CustomCollector) error { return nil }
+func (noopMetrics) UnregisterCollector(name string) error             { return nil }
+func (noopMetrics) ListCollectors() []CustomCollector                 { return nil }
+func (noopMetrics) EnableCollector(name string) error                 { return nil }
+func (noopMetrics) DisableCollector(name string) error                { return nil }
+func (noopMetrics) ListActiveCollectors() []CustomCollector           { return nil }
+
+func (noopMetrics) ListMetrics() map[string]any                             { return map[string]any{} }
+func (noopMetrics) ListMetricsByType(metricType MetricType) map[string]any  { return map[string]any{} }
+func (noopMetrics) ListFamilies() []MetricFamily                            { return nil }
+func (noopMetrics) ListMetricsByTag(tagKey, tagValue string) map[string]any { return map[string]any{} }
+func (noopMetrics) MetricNames() []string                                   { return nil }
+func (noopMetrics) Snapshot() map[string]MetricSnapshotEntry                { return map[string]MetricSnapshotEntry{} }
+func (noopMetrics) Stats() CollectorStats                                   { return CollectorStats{Name: "noop"} }
+func (noopMetrics) Aggregate(name string) (float64, error)                  { return 0, nil }
+func (noopMetrics) Reset() error                                            { return nil }
+func (noopMetrics) ResetMetric(name string) error                           { return nil }
+func (noopMetrics) Reload(config *MetricsConfig) error                      { return nil }
+func (noopMetrics) Close(ctx context.Context) error                         { return nil }
+
+func (noopMetrics) GatherAll(ctx context.Context) (CollectorStats, *HealthReport) {
+	return CollectorStats{Name: "noop"}, nil
+}
+
+func (noopMetrics) AggregateHistogram(name string) (HistogramAggregate, error) {
+	return HistogramAggregate{Name: name}, nil
+}
+
+// noopCounter is a Counter that does nothing.
+type noopCounter struct{}
+
+func (noopCounter) Inc()                                             {}
+func (noopCounter) Add(delta float64)                                {}
+func (noopCounter) AddWithExemplar(delta float64, exemplar Exemplar) {}
+func (noopCounter) Value() float64                                   { return 0 }
+func (noopCounter) Timestamp() time.Time                             { return time.Time{} }
+func (noopCounter) Exemplars() []Exemplar                            { return nil }
+func (noopCounter) Describe() MetricMetadata                         { return MetricMetadata{Type: MetricTypeCounter} }
+func (noopCounter) WithLabels(labels map[string]string) Counter      { return noopCounterInstance }
+func (noopCounter) Reset() error                                     { return nil }
+
+// noopGauge is a Gauge that does nothing.
+type noopGauge struct{}
+
+func (noopGauge) Set(value float64)                         {}
+func (noopGauge) Inc()                                      {}
+func (noopGauge) Dec()                                      {}
+func (noopGauge) Add(delta float64)                         {}
+func (noopGauge) Sub(delta float64)                         {}
+func (noopGauge) SetToCurrentTime()                         {}
+func (noopGauge) SetToCurrentTimeNanos()                    {}
+func (noopGauge) SetTime(t time.Time)                       {}
+func (noopGauge) TimeValue() time.Time                      { return time.Unix(0, 0) }
+func (noopGauge) Value() float64                            { return 0 }
+func (noopGauge) Timestamp() time.Time                      { return time.Time{} }
+func (noopGauge) History() []GaugePoint                     { return nil }
+func (noopGauge) Describe() MetricMetadata                  { return MetricMetadata{Type: MetricTypeGauge} }
+func (noopGauge) WithLabels(labels map[string]string) Gauge { return noopGaugeInstance }
+func (noopGauge) Reset() error                              { return nil }
+
+// noopHistogram is a Histogram that does nothing.
+type noopHistogram struct{}
+
+func (noopHistogram) Observe(value float64)                          {}
+func (noopHistogram) ObserveWithExemplar(value float64, ex Exemplar) {}
+func (noopHistogram) ObserveDuration(start time.Time)                {}
+func (noopHistogram) Count() uint64                                  { return 0 }
+func (noopHistogram) Sum() float64                                   { return 0 }
+func (noopHistogram) Mean() float64                                  { return 0 }
+func (noopHistogram) StdDev() float64                                { return 0 }
+func (noopHistogram) Min() float64                                   { return 0 }
+func (noopHistogram) Max() float64                                   { return 0 }
+func (noopHistogram) Percentile(percentile float64) float64          { return 0 }
+func (noopHistogram) Quantile(q float64) float64                     { return 0 }
+func (noopHistogram) Buckets() map[float64]uint64                    { return nil }
+func (noopHistogram) NativeBuckets() *NativeHistogram                { return nil }
+func (noopHistogram) Exemplars() []Exemplar                          { return nil }
+func (noopHistogram) Describe() MetricMetadata                       { return MetricMetadata{Type: MetricTypeHistogram} }
+func (noopHistogram) WithLabels(labels map[string]string) Histogram  { return noopHistInstance }
+func (noopHistogram) Reset() error                                   { return nil }
+
+// noopSummary is a Summary that does nothing.
+type noopSummary struct{}
+
+func (noopSummary) Observe(value float64)                       {}
+func (noopSummary) Count() uint64                               { return 0 }
+func (noopSummary) Sum() float64                                { return 0 }
+func (noopSummary) Mean() float64                               { return 0 }
+func (noopSummary) Quantile(q float64) float64                  { return 0 }
+func (noopSummary) Min() float64                                { return 0 }
+func (noopSummary) Max() float64                                { return 0 }
+func (noopSummary) StdDev() float64                             { return 0 }
+func (noopSummary) Objectives() map[float64]float64             { return nil }
+func (noopSummary) Describe() MetricMetadata                    { return MetricMetadata{Type: MetricTypeSummary} }
+func (noopSummary) WithLabels(labels map[string]string) Summary { return noopSummaryInstance }
+func (noopSummary) Reset() error                                { return nil }
+
+// noopTimer is a Timer that does nothing.
+type noopTimer struct{}
+
+func (noopTimer) Record(duration time.Duration)                          {}
+func (noopTimer) RecordWithExemplar(duration time.Duration, ex Exemplar) {}
+func (noopTimer) Time() func()                                           { return noopStop }
+func (noopTimer) ObserveDuration(start time.Time)                        {}
+func (noopTimer) Count() uint64                                          { return 0 }
+func (noopTimer) Value() time.Duration                                   { return 0 }
+func (noopTimer) Sum() time.Duration                                     { return 0 }
+func (noopTimer) Mean() time.Duration                                    { return 0 }
+func (noopTimer) StdDev() time.Duration                                  { return 0 }
+func (noopTimer) Min() time.Duration                                     { return 0 }
+func (noopTimer) Max() time.Duration                                     { return 0 }
+func (noopTimer) Percentile(percentile float64) time.Duration            { return 0 }
+func (noopTimer) Quantile(q float64) time.Duration                       { return 0 }
+func (noopTimer) Buckets() map[time.Duration]uint64                      { return nil }
+func (noopTimer) CumulativeBuckets() map[time.Duration]uint64            { return nil }
+func (noopTimer) Histogram() Histogram                                   { return noopHistInstance }
+func (noopTimer) Exemplars() []Exemplar                                  { return nil }
+func (noopTimer) Describe() MetricMetadata                               { return MetricMetadata{Type: MetricTypeTimer} }
+func (noopTimer) WithLabels(labels map[string]string) Timer              { return noopTimerInstance }
+func (noopTimer) Reset() error                                           { return nil }
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoopMetrics(t *testing.T) {
+	m := NewNoopMetrics()
+
+	require.NoError(t, m.Start(context.Background()))
+	require.NoError(t, m.Health(context.Background()))
+
+	counter := m.Counter("requests_total", WithLabel("method", "GET"))
+	counter.Inc()
+	counter.Add(5)
+	assert.Zero(t, counter.Value())
+	assert.Equal(t, m.Counter("other"), counter.WithLabels(map[string]string{"a": "b"}))
+
+	m.Gauge("queue_depth").Set(10)
+	assert.Zero(t, m.Gauge("queue_depth").Value())
+
+	m.Histogram("size").Observe(1)
+	m.Summary("latency").Observe(1)
+	assert.Zero(t, m.Histogram("size").Count())
+	assert.Zero(t, m.Summary("latency").Count())
+
+	timer := m.Timer("duration")
+	timer.Record(time.Second)
+	timer.Time()()
+	assert.Zero(t, timer.Count())
+
+	assert.Empty(t, m.ListMetrics())
+	assert.Empty(t, m.MetricNames())
+	assert.Equal(t, "noop", m.Stats().Name)
+}
+
+func TestNoopMetrics_ZeroAllocations(t *testing.T) {
+	m := NewNoopMetrics()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		m.Counter("requests_total").Inc()
+		m.Gauge("queue_depth").Set(1)
+		m.Histogram("size").Observe(1)
+		m.Timer("duration").Time()()
+	})
+
+	assert.Zero(t, allocs)
+}
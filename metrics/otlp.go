@@ -0,0 +1,409 @@
+package metrics
+
+import (
+	"context"
+	"encoding/hex"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// =============================================================================
+// OTLP/HTTP EXPORTER
+// =============================================================================
+
+// OTLPFormat is the Format of the OTLP exporter.
+const OTLPFormat = "otlp"
+
+// otlpScopeName is the instrumentation scope metrics are reported under.
+const otlpScopeName = "github.com/xraph/go-utils/metrics"
+
+// OTLPExporter is an Exporter pushing metrics to an OpenTelemetry collector
+// over OTLP/HTTP. Each export encodes the metrics as a protobuf
+// ExportMetricsServiceRequest and POSTs it to the endpoint.
+//
+// Counters are sent as monotonic cumulative sums, gauges as gauges,
+// histograms as cumulative histograms with explicit bounds, timers as
+// histograms in seconds and summaries as summaries. Const labels and labels
+// become data point attributes, and exemplars become OTLP exemplars. Trace
+// and span IDs that are not hex encoded 16 and 8 byte IDs are sent as the
+// exemplar attributes trace_id and span_id instead. An OTLPExporter is safe
+// for concurrent use.
+type OTLPExporter struct {
+	pushExporter
+
+	resource map[string]string
+}
+
+var _ ContextExporter = (*OTLPExporter)(nil)
+
+// OTLPOption configures an OTLPExporter.
+type OTLPOption func(*OTLPExporter)
+
+// WithOTLPHeader sends a custom header with every request, such as an API
+// key.
+func WithOTLPHeader(key, value string) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.headers[key] = value
+	}
+}
+
+// WithOTLPTimeout bounds each Export call. It does not apply to
+// ExportContext, which is bounded by its context. The default is 30 seconds;
+// zero or negative disables the timeout.
+func WithOTLPTimeout(d time.Duration) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.timeout = d
+	}
+}
+
+// WithOTLPClient sets the HTTP client requests are sent with, e.g. to
+// configure TLS. The default is http.DefaultClient.
+func WithOTLPClient(client *http.Client) OTLPOption {
+	return func(e *OTLPExporter) {
+		if client != nil {
+			e.client = client
+		}
+	}
+}
+
+// WithOTLPResource adds attributes describing the reporting service, such as
+// service.name, to the OTLP resource.
+func WithOTLPResource(attributes map[string]string) OTLPOption {
+	return func(e *OTLPExporter) {
+		maps.Copy(e.resource, attributes)
+	}
+}
+
+// NewOTLPExporter creates an exporter pushing to the OTLP/HTTP metrics
+// endpoint, e.g. "http://otel-collector:4318/v1/metrics".
+func NewOTLPExporter(endpoint string, opts ...OTLPOption) *OTLPExporter {
+	e := &OTLPExporter{
+		pushExporter: newPushExporter(endpoint, OTLPFormat),
+		resource:     make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Export pushes metrics to the endpoint within the configured timeout and
+// returns the encoded request that was written.
+func (e *OTLPExporter) Export(metrics map[string]any) ([]byte, error) {
+	ctx, cancel := e.exportContext()
+	defer cancel()
+
+	return e.ExportContext(ctx, metrics)
+}
+
+// ExportContext is like Export, but bounded by ctx instead of the configured
+// timeout.
+func (e *OTLPExporter) ExportContext(ctx context.Context, metrics map[string]any) ([]byte, error) {
+	payload := encodeOTLPRequest(metrics, e.resource, time.Now())
+
+	err := e.push(ctx, payload, http.Header{"Content-Type": {"application/x-protobuf"}}, ErrOTLPExportFailed)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// Format returns OTLPFormat.
+func (e *OTLPExporter) Format() string {
+	return OTLPFormat
+}
+
+// OTLP enum values.
+const (
+	otlpTemporalityCumulative = 2
+)
+
+// encodeOTLPRequest encodes metrics as an ExportMetricsServiceRequest with a
+// single resource and scope, every data point stamped with now.
+func encodeOTLPRequest(metrics map[string]any, resource map[string]string, now time.Time) []byte {
+	var scope []byte
+
+	scope = appendOTLPMessage(scope, 1, func(b []byte) []byte { // InstrumentationScope
+		return appendOTLPString(b, 1, otlpScopeName)
+	})
+
+	for _, family := range bridgeFamilies(metrics) {
+		scope = appendOTLPMessage(scope, 2, func(b []byte) []byte { // Metric
+			return appendOTLPMetric(b, family, now)
+		})
+	}
+
+	var resourceMetrics []byte
+
+	resourceMetrics = appendOTLPMessage(resourceMetrics, 1, func(b []byte) []byte { // Resource
+		return appendOTLPAttributes(b, 1, resource)
+	})
+	resourceMetrics = protowire.AppendTag(resourceMetrics, 2, protowire.BytesType) // ScopeMetrics
+	resourceMetrics = protowire.AppendBytes(resourceMetrics, scope)
+
+	request := protowire.AppendTag(nil, 1, protowire.BytesType) // ResourceMetrics
+
+	return protowire.AppendBytes(request, resourceMetrics)
+}
+
+// appendOTLPMetric appends the fields of the Metric message of a family.
+func appendOTLPMetric(b []byte, family *bridgeFamily, now time.Time) []byte {
+	b = appendOTLPString(b, 1, family.name)
+	if family.help != "" {
+		b = appendOTLPString(b, 2, family.help)
+	}
+
+	unit := family.unit
+
+	switch family.series[0].metric.(type) {
+	case Counter:
+		return appendOTLPMessage(appendOTLPUnit(b, unit), 7, func(b []byte) []byte { // Sum
+			for _, s := range family.series {
+				c := s.metric.(Counter) //nolint:forcetypeassert // families hold a single type
+				b = appendOTLPMessage(b, 1, func(b []byte) []byte {
+					return appendOTLPNumberPoint(b, s, c.Value(), c.Exemplars(), now)
+				})
+			}
+
+			b = protowire.AppendTag(b, 2, protowire.VarintType)
+			b = protowire.AppendVarint(b, otlpTemporalityCumulative)
+			b = protowire.AppendTag(b, 3, protowire.VarintType)
+
+			return protowire.AppendVarint(b, 1) // is_monotonic
+		})
+	case Gauge:
+		return appendOTLPMessage(appendOTLPUnit(b, unit), 5, func(b []byte) []byte { // Gauge
+			for _, s := range family.series {
+				g := s.metric.(Gauge) //nolint:forcetypeassert // families hold a single type
+				b = appendOTLPMessage(b, 1, func(b []byte) []byte {
+					return appendOTLPNumberPoint(b, s, g.Value(), nil, now)
+				})
+			}
+
+			return b
+		})
+	case Summary:
+		return appendOTLPMessage(appendOTLPUnit(b, unit), 11, func(b []byte) []byte { // Summary
+			for _, s := range family.series {
+				b = appendOTLPMessage(b, 1, func(b []byte) []byte {
+					return appendOTLPSummaryPoint(b, s, now)
+				})
+			}
+
+			return b
+		})
+	case Timer:
+		unit = "s"
+	}
+
+	return appendOTLPMessage(appendOTLPUnit(b, unit), 9, func(b []byte) []byte { // Histogram
+		for _, s := range family.series {
+			b = appendOTLPMessage(b, 1, func(b []byte) []byte {
+				return appendOTLPHistogramPoint(b, s, now)
+			})
+		}
+
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+
+		return protowire.AppendVarint(b, otlpTemporalityCumulative)
+	})
+}
+
+// appendOTLPUnit appends the unit field of a Metric, if set.
+func appendOTLPUnit(b []byte, unit string) []byte {
+	if unit == "" {
+		return b
+	}
+
+	return appendOTLPString(b, 3, unit)
+}
+
+// appendOTLPNumberPoint appends the fields of a NumberDataPoint.
+func appendOTLPNumberPoint(b []byte, s bridgeSeries, value float64, exemplars []Exemplar, now time.Time) []byte {
+	b = appendOTLPTimes(b, s, now)
+	b = appendOTLPDouble(b, 4, value) // as_double
+
+	for _, ex := range exemplars {
+		b = appendOTLPMessage(b, 5, func(b []byte) []byte { return appendOTLPExemplar(b, ex, now) })
+	}
+
+	return appendOTLPAttributes(b, 7, s.labels)
+}
+
+// appendOTLPHistogramPoint appends the fields of a HistogramDataPoint for a
+// histogram or, in seconds, a timer.
+func appendOTLPHistogramPoint(b []byte, s bridgeSeries, now time.Time) []byte {
+	var (
+		buckets                 map[float64]uint64
+		count                   uint64
+		sum, minValue, maxValue float64
+		exemplars               []Exemplar
+	)
+
+	switch m := s.metric.(type) {
+	case Histogram:
+		buckets, count, sum, minValue, maxValue = m.Buckets(), m.Count(), m.Sum(), m.Min(), m.Max()
+		exemplars = m.Exemplars()
+	case Timer:
+		buckets = make(map[float64]uint64)
+		for boundary, n := range m.Buckets() {
+			buckets[boundary.Seconds()] = n
+		}
+
+		count, sum, minValue, maxValue = m.Count(), m.Sum().Seconds(), m.Min().Seconds(), m.Max().Seconds()
+		exemplars = m.Exemplars()
+	}
+
+	b = appendOTLPTimes(b, s, now)
+	b = protowire.AppendTag(b, 4, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, count)
+	b = appendOTLPDouble(b, 5, sum)
+
+	// bucket_counts has one more entry than explicit_bounds: the overflow
+	// bucket above the last bound.
+	bounds := slices.Sorted(maps.Keys(buckets))
+
+	var counts, packedBounds []byte
+
+	inBounds := uint64(0)
+	for _, bound := range bounds {
+		counts = protowire.AppendFixed64(counts, buckets[bound])
+		packedBounds = protowire.AppendFixed64(packedBounds, math.Float64bits(bound))
+		inBounds += buckets[bound]
+	}
+
+	counts = protowire.AppendFixed64(counts, count-min(inBounds, count))
+
+	b = protowire.AppendTag(b, 6, protowire.BytesType)
+	b = protowire.AppendBytes(b, counts)
+
+	if len(bounds) > 0 {
+		b = protowire.AppendTag(b, 7, protowire.BytesType)
+		b = protowire.AppendBytes(b, packedBounds)
+	}
+
+	for _, ex := range exemplars {
+		b = appendOTLPMessage(b, 8, func(b []byte) []byte { return appendOTLPExemplar(b, ex, now) })
+	}
+
+	b = appendOTLPAttributes(b, 9, s.labels)
+
+	if count > 0 {
+		b = appendOTLPDouble(b, 11, minValue)
+		b = appendOTLPDouble(b, 12, maxValue)
+	}
+
+	return b
+}
+
+// appendOTLPSummaryPoint appends the fields of a SummaryDataPoint.
+func appendOTLPSummaryPoint(b []byte, s bridgeSeries, now time.Time) []byte {
+	m := s.metric.(Summary) //nolint:forcetypeassert // families hold a single type
+
+	b = appendOTLPTimes(b, s, now)
+	b = protowire.AppendTag(b, 4, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, m.Count())
+	b = appendOTLPDouble(b, 5, m.Sum())
+
+	if o, ok := m.(interface{ objectiveQuantiles() []float64 }); ok {
+		for _, q := range o.objectiveQuantiles() {
+			b = appendOTLPMessage(b, 6, func(b []byte) []byte { // ValueAtQuantile
+				return appendOTLPDouble(appendOTLPDouble(b, 1, q), 2, m.Quantile(q))
+			})
+		}
+	}
+
+	return appendOTLPAttributes(b, 7, s.labels)
+}
+
+// appendOTLPTimes appends start_time_unix_nano, the metric's creation time,
+// and time_unix_nano of a data point.
+func appendOTLPTimes(b []byte, s bridgeSeries, now time.Time) []byte {
+	if !s.created.IsZero() {
+		b = protowire.AppendTag(b, 2, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, uint64(s.created.UnixNano())) //nolint:gosec // times after 1970
+	}
+
+	b = protowire.AppendTag(b, 3, protowire.Fixed64Type)
+
+	return protowire.AppendFixed64(b, uint64(now.UnixNano())) //nolint:gosec // times after 1970
+}
+
+// appendOTLPExemplar appends the fields of an Exemplar.
+func appendOTLPExemplar(b []byte, ex Exemplar, now time.Time) []byte {
+	attributes := maps.Clone(ex.Labels)
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+
+	timestamp := ex.Timestamp
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+
+	b = protowire.AppendTag(b, 2, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, uint64(timestamp.UnixNano())) //nolint:gosec // times after 1970
+	b = appendOTLPDouble(b, 3, ex.Value)
+
+	if id, err := hex.DecodeString(ex.SpanID); err == nil && len(id) == 8 {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, id)
+	} else if ex.SpanID != "" {
+		attributes["span_id"] = ex.SpanID
+	}
+
+	if id, err := hex.DecodeString(ex.TraceID); err == nil && len(id) == 16 {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, id)
+	} else if ex.TraceID != "" {
+		attributes["trace_id"] = ex.TraceID
+	}
+
+	return appendOTLPAttributes(b, 7, attributes)
+}
+
+// appendOTLPAttributes appends attributes as repeated KeyValue fields with
+// string values, sorted by key.
+func appendOTLPAttributes(b []byte, num protowire.Number, attributes map[string]string) []byte {
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		b = appendOTLPMessage(b, num, func(b []byte) []byte { // KeyValue
+			b = appendOTLPString(b, 1, key)
+
+			return appendOTLPMessage(b, 2, func(b []byte) []byte { // AnyValue
+				return appendOTLPString(b, 1, attributes[key])
+			})
+		})
+	}
+
+	return b
+}
+
+// appendOTLPMessage appends an embedded message field whose fields are
+// appended by fn.
+func appendOTLPMessage(b []byte, num protowire.Number, fn func([]byte) []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+
+	return protowire.AppendBytes(b, fn(nil))
+}
+
+// appendOTLPString appends a string field.
+func appendOTLPString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+
+	return protowire.AppendString(b, s)
+}
+
+// appendOTLPDouble appends a double field.
+func appendOTLPDouble(b []byte, num protowire.Number, v float64) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
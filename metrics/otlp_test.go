@@ -0,0 +1,264 @@
+package metrics
+
+import (
+	"encoding/hex"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protoMessage is a decoded protobuf message: the raw values of its fields
+// by number, bytes for length-delimited fields and the integer for others.
+type protoMessage map[protowire.Number][]protoValue
+
+type protoValue struct {
+	bytes []byte
+	num   uint64
+}
+
+func decodeProto(t *testing.T, b []byte) protoMessage {
+	t.Helper()
+
+	msg := make(protoMessage)
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+
+		b = b[n:]
+
+		var v protoValue
+
+		switch typ {
+		case protowire.BytesType:
+			v.bytes, n = protowire.ConsumeBytes(b)
+		case protowire.Fixed64Type:
+			v.num, n = protowire.ConsumeFixed64(b)
+		case protowire.VarintType:
+			v.num, n = protowire.ConsumeVarint(b)
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+
+		require.GreaterOrEqual(t, n, 0)
+
+		b = b[n:]
+		msg[num] = append(msg[num], v)
+	}
+
+	return msg
+}
+
+func (m protoMessage) message(t *testing.T, num protowire.Number) protoMessage {
+	t.Helper()
+	require.NotEmpty(t, m[num], "field %d", num)
+
+	return decodeProto(t, m[num][0].bytes)
+}
+
+func (m protoMessage) messages(t *testing.T, num protowire.Number) []protoMessage {
+	t.Helper()
+
+	msgs := make([]protoMessage, 0, len(m[num]))
+	for _, v := range m[num] {
+		msgs = append(msgs, decodeProto(t, v.bytes))
+	}
+
+	return msgs
+}
+
+func (m protoMessage) str(num protowire.Number) string {
+	if len(m[num]) == 0 {
+		return ""
+	}
+
+	return string(m[num][0].bytes)
+}
+
+func (m protoMessage) double(num protowire.Number) float64 {
+	return math.Float64frombits(m[num][0].num)
+}
+
+// attributes decodes repeated KeyValue fields with string values.
+func (m protoMessage) attributes(t *testing.T, num protowire.Number) map[string]string {
+	t.Helper()
+
+	attrs := make(map[string]string)
+	for _, kv := range m.messages(t, num) {
+		attrs[kv.str(1)] = kv.message(t, 2).str(1)
+	}
+
+	return attrs
+}
+
+// packedFixed64 decodes a packed repeated fixed64 field.
+func (m protoMessage) packedFixed64(t *testing.T, num protowire.Number) []uint64 {
+	t.Helper()
+
+	var values []uint64
+
+	b := m[num][0].bytes
+	for len(b) > 0 {
+		v, n := protowire.ConsumeFixed64(b)
+		require.GreaterOrEqual(t, n, 0)
+
+		values = append(values, v)
+		b = b[n:]
+	}
+
+	return values
+}
+
+func TestOTLPExporter(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	collector := NewMetricsCollector("test")
+
+	requests := collector.Counter("requests_total", WithDescription("Requests served."),
+		WithConstLabels(map[string]string{"service": "api"}))
+	requests.AddWithExemplar(3, Exemplar{Value: 3, TraceID: traceID, SpanID: spanID})
+
+	collector.Gauge("temperature", WithUnit("celsius")).Set(21.5)
+
+	histogram := collector.Histogram("size", WithBuckets(10, 100))
+	histogram.Observe(5)
+	histogram.Observe(50)
+	histogram.Observe(500)
+
+	var (
+		body   []byte
+		header http.Header
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		header = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL,
+		WithOTLPHeader("Authorization", "Bearer token"),
+		WithOTLPResource(map[string]string{"service.name": "checkout"}),
+	)
+
+	payload, err := exporter.Export(collector.ListMetrics())
+	require.NoError(t, err)
+	assert.Equal(t, body, payload)
+	assert.Equal(t, "application/x-protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+
+	request := decodeProto(t, body)
+	resourceMetrics := request.message(t, 1)
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, resourceMetrics.message(t, 1).attributes(t, 1))
+
+	scopeMetrics := resourceMetrics.message(t, 2)
+	assert.Equal(t, otlpScopeName, scopeMetrics.message(t, 1).str(1))
+
+	byName := make(map[string]protoMessage)
+	for _, metric := range scopeMetrics.messages(t, 2) {
+		byName[metric.str(1)] = metric
+	}
+
+	require.Len(t, byName, 3)
+
+	// Counter: monotonic cumulative sum with the exemplar's trace and span IDs
+	requestsMetric := byName["requests_total"]
+	assert.Equal(t, "Requests served.", requestsMetric.str(2))
+
+	sum := requestsMetric.message(t, 7)
+	assert.Equal(t, uint64(otlpTemporalityCumulative), sum[2][0].num)
+	assert.Equal(t, uint64(1), sum[3][0].num)
+
+	point := sum.message(t, 1)
+	assert.InDelta(t, 3.0, point.double(4), 0)
+	assert.Equal(t, map[string]string{"service": "api"}, point.attributes(t, 7))
+
+	exemplar := point.message(t, 5)
+	assert.Equal(t, traceID, hex.EncodeToString(exemplar[5][0].bytes))
+	assert.Equal(t, spanID, hex.EncodeToString(exemplar[4][0].bytes))
+	assert.InDelta(t, 3.0, exemplar.double(3), 0)
+
+	// Gauge
+	temperature := byName["temperature"]
+	assert.Equal(t, "celsius", temperature.str(3))
+	assert.InDelta(t, 21.5, temperature.message(t, 5).message(t, 1).double(4), 0)
+
+	// Histogram: bounds and per-bucket counts, including the overflow bucket
+	hist := byName["size"].message(t, 9)
+	assert.Equal(t, uint64(otlpTemporalityCumulative), hist[2][0].num)
+
+	hp := hist.message(t, 1)
+	assert.Equal(t, uint64(3), hp[4][0].num)
+	assert.InDelta(t, 555.0, hp.double(5), 0)
+	assert.Equal(t, []uint64{1, 1, 1}, hp.packedFixed64(t, 6))
+	assert.Equal(t, []uint64{math.Float64bits(10), math.Float64bits(100)}, hp.packedFixed64(t, 7))
+	assert.InDelta(t, 5.0, hp.double(11), 0)
+	assert.InDelta(t, 500.0, hp.double(12), 0)
+
+	stats := exporter.Stats()
+	assert.Equal(t, OTLPFormat, stats.Format)
+	assert.Equal(t, int64(1), stats.SuccessCount)
+	assert.Equal(t, int64(len(payload)), stats.BytesExported)
+}
+
+func TestOTLPExporter_TimerAndSummary(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Timer("query", WithBuckets(100)).RecordWithExemplar(50*time.Millisecond, Exemplar{Value: 50, TraceID: "not-hex"})
+
+	summary := collector.Summary("latency", WithPercentiles(0.5))
+	summary.Observe(1)
+	summary.Observe(3)
+
+	metric := decodeProto(t, encodeOTLPRequest(collector.ListMetrics(), nil, time.Now())).
+		message(t, 1).message(t, 2).messages(t, 2)
+	require.Len(t, metric, 2)
+
+	byName := map[string]protoMessage{metric[0].str(1): metric[0], metric[1].str(1): metric[1]}
+
+	query := byName["query"]
+	assert.Equal(t, "s", query.str(3))
+
+	hp := query.message(t, 9).message(t, 1)
+	assert.InDelta(t, 0.05, hp.double(5), 1e-9)
+	assert.Equal(t, []uint64{math.Float64bits(0.1)}, hp.packedFixed64(t, 7))
+	assert.Equal(t, map[string]string{"trace_id": "not-hex"}, hp.message(t, 8).attributes(t, 7))
+
+	sp := byName["latency"].message(t, 11).message(t, 1)
+	assert.Equal(t, uint64(2), sp[4][0].num)
+	assert.InDelta(t, 4.0, sp.double(5), 0)
+
+	quantile := sp.message(t, 6)
+	assert.InDelta(t, 0.5, quantile.double(1), 0)
+	assert.InDelta(t, summary.Quantile(0.5), quantile.double(2), 0)
+}
+
+func TestOTLPExporter_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL)
+
+	_, err := exporter.Export(map[string]any{"c": NewCounter("c")})
+	require.ErrorIs(t, err, ErrOTLPExportFailed)
+
+	stats := exporter.Stats()
+	assert.Equal(t, int64(1), stats.ErrorCount)
+	assert.Zero(t, stats.SuccessCount)
+	assert.Zero(t, stats.SuccessRate)
+}
@@ -0,0 +1,457 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// PROMETHEUS EXPORT
+// =============================================================================
+
+// prometheusLabelEscaper escapes label values for the Prometheus text format.
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// prometheusHelpEscaper escapes HELP text for the Prometheus text format.
+var prometheusHelpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// exportPrometheus encodes the current state of all metrics in the Prometheus
+// text exposition format. Metrics are written under their fully qualified
+// name, sorted by name and labels so scrapes are stable. Label variants of a
+// metric are written as series of one family under a single HELP and TYPE
+// line, the HELP text being the metric's description.
+//
+// Names are sanitized for Prometheus: invalid characters become "_" and a
+// leading digit is prefixed with "_", so "api.requests-total" is exported as
+// "api_requests_total". Families whose names collide after sanitization get a
+// numeric suffix ("_2", "_3", ...) in export order.
+//
+// Active custom collectors follow the registered metrics. Metric families
+// such as CounterVec, GaugeVec, TimerVec and StatusCounter are written as one
+// labeled family of their own type, e.g. http_requests_total{method="GET"}.
+// For other collectors every numeric value of their Collect map is written as
+// a gauge named after the collector and its key, e.g. "db_pool" reporting
+// {"open": 4} is exported as db_pool_open. Values of other types, such as
+// strings and nested maps, are skipped.
+func (mc *metricsCollector) exportPrometheus(ctx context.Context) ([]byte, error) {
+	var failures []exportFailure
+
+	data, err := mc.renderPrometheus(ctx, &failures)
+	mc.recordExportFailures(failures)
+
+	return data, err
+}
+
+// renderPrometheus implements exportPrometheus. A metric that panics while
+// being written is left out, HELP and TYPE lines included, and added to
+// failures, so the rest of the scrape is still served. Custom collectors are
+// read after mc.mu is released, since a collector may call back into the
+// collector.
+func (mc *metricsCollector) renderPrometheus(ctx context.Context, failures *[]exportFailure) ([]byte, error) {
+	var buf bytes.Buffer
+
+	names := newPrometheusNamer()
+
+	if err := mc.renderPrometheusMetrics(ctx, &buf, names, failures); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, c := range mc.activeCollectors() {
+		renderSafely(failures, c.name, func() {
+			var series bytes.Buffer
+
+			if !writePrometheusFamilyCollector(&series, names, c.collector) {
+				writePrometheusCollector(&series, names, c.name, c.collector)
+			}
+
+			buf.Write(series.Bytes())
+		})
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderPrometheusMetrics writes the registered metrics to buf under mc.mu.
+func (mc *metricsCollector) renderPrometheusMetrics(ctx context.Context, buf *bytes.Buffer, names *prometheusNamer, failures *[]exportFailure) error {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	var lastFamily string
+
+	// render writes the series of m with write, preceded by the HELP and
+	// TYPE lines when m starts a new family. The output of m is buffered
+	// until write returns, so a panicking metric writes nothing.
+	render := func(m *metricCore, metricType string, write func(w *bytes.Buffer, name string)) {
+		renderSafely(failures, m.fullName(), func() {
+			var series bytes.Buffer
+
+			family := m.fullName() + " " + metricType
+			name := names.name(family, m.fullName())
+
+			if family != lastFamily {
+				writePrometheusHelp(&series, name, m.description)
+				writePrometheusType(&series, name, metricType)
+			}
+
+			write(&series, name)
+
+			buf.Write(series.Bytes())
+			lastFamily = family
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, counter := range sortedByFullName(mc.counters) {
+		render(counter.core(), "counter", func(w *bytes.Buffer, name string) {
+			writePrometheusSample(w, name, counter.exportLabels(), "", "", counter.Value())
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, gauge := range sortedByFullName(mc.gauges) {
+		render(gauge.core(), "gauge", func(w *bytes.Buffer, name string) {
+			writePrometheusSample(w, name, gauge.exportLabels(), "", "", gauge.Value())
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, histogram := range sortedByFullName(mc.histograms) {
+		render(histogram.core(), "histogram", func(w *bytes.Buffer, name string) {
+			writePrometheusHistogram(w, name, histogram, 1)
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, summary := range sortedByFullName(mc.summaries) {
+		render(summary.core(), "summary", func(w *bytes.Buffer, name string) {
+			labels := summary.exportLabels()
+
+			for _, q := range slices.Sorted(maps.Keys(summary.objectives)) {
+				writePrometheusSample(w, name, labels, "quantile", formatPrometheusValue(q), summary.Quantile(q))
+			}
+
+			writePrometheusSample(w, name+"_sum", labels, "", "", summary.Sum())
+			writePrometheusSample(w, name+"_count", labels, "", "", float64(summary.Count()))
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Timers record in their own unit; Prometheus convention is seconds.
+	for _, timer := range sortedByFullName(mc.timers) {
+		render(timer.core(), "histogram", func(w *bytes.Buffer, name string) {
+			writePrometheusHistogram(w, name, timer.histogram, timer.unit.Seconds())
+		})
+	}
+
+	return nil
+}
+
+// writePrometheusCollector writes every numeric value of a custom collector's
+// Collect map as a gauge named after the collector and its key.
+func writePrometheusCollector(buf *bytes.Buffer, names *prometheusNamer, collectorName string, collector CustomCollector) {
+	values := collector.Collect()
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		value, ok := collectorValue(values[key])
+		if !ok {
+			continue
+		}
+
+		// Keyed apart from metric families so that a collector value
+		// named like a registered gauge gets a suffix instead of
+		// merging into its family.
+		raw := collectorName + "_" + key
+		name := names.name("collector "+raw, raw)
+
+		writePrometheusType(buf, name, "gauge")
+		writePrometheusSample(buf, name, nil, "", "", value)
+	}
+}
+
+// writePrometheusFamilyCollector writes a metric family collector, such as a
+// CounterVec, as one labeled family of its own type. It reports false for
+// collectors that are not metric families.
+func writePrometheusFamilyCollector(buf *bytes.Buffer, names *prometheusNamer, collector CustomCollector) bool {
+	switch c := collector.(type) {
+	case *StatusCounter:
+		return writePrometheusFamilyCollector(buf, names, c.vec)
+	case *CounterVec:
+		writePrometheusFamily(buf, names, "counter", c.sortedChildren(), func(w *bytes.Buffer, name string, counter *counterImpl) {
+			writePrometheusSample(w, name, counter.exportLabels(), "", "", counter.Value())
+		})
+	case *GaugeVec:
+		writePrometheusFamily(buf, names, "gauge", c.sortedChildren(), func(w *bytes.Buffer, name string, gauge *gaugeImpl) {
+			writePrometheusSample(w, name, gauge.exportLabels(), "", "", gauge.Value())
+		})
+	case *TimerVec:
+		writePrometheusFamily(buf, names, "histogram", c.sortedChildren(), func(w *bytes.Buffer, name string, timer *timerImpl) {
+			writePrometheusHistogram(w, name, timer.histogram, timer.unit.Seconds())
+		})
+	default:
+		return false
+	}
+
+	return true
+}
+
+// writePrometheusFamily writes the children of a metric family collector
+// under a single HELP and TYPE line. The children share the family's name
+// and differ only in labels. Nothing is written for an empty family.
+func writePrometheusFamily[T interface{ core() *metricCore }](buf *bytes.Buffer, names *prometheusNamer, metricType string, children []T, write func(w *bytes.Buffer, name string, child T)) {
+	if len(children) == 0 {
+		return
+	}
+
+	family := children[0].core()
+
+	// Keyed apart from registered metrics, whose family of the same name
+	// was already written, so the collector gets a suffix instead.
+	name := names.name("collector "+family.fullName()+" "+metricType, family.fullName())
+
+	writePrometheusHelp(buf, name, family.description)
+	writePrometheusType(buf, name, metricType)
+
+	for _, child := range children {
+		write(buf, name, child)
+	}
+}
+
+// collectorValue converts a value reported by a custom collector to a sample
+// value. Only integer and floating-point values, including named types such
+// as time.Duration, are numeric.
+func collectorValue(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// writePrometheusHistogram writes the cumulative buckets, sum and count of h.
+// The caller writes the TYPE line.
+// Bucket boundaries and the sum are multiplied by scale.
+func writePrometheusHistogram(buf *bytes.Buffer, name string, h *histogramImpl, scale float64) {
+	labels := h.exportLabels()
+
+	// The text format has no representation for native buckets, so the
+	// populated exponential buckets are rendered as classic le buckets here.
+	// NewPrometheusBridge reports them as native histograms.
+	if h.native != nil {
+		cumulative := uint64(0)
+		for _, span := range h.native.spans() {
+			cumulative += span.count
+			writePrometheusSample(buf, name+"_bucket", labels, "le", formatPrometheusValue(span.upper*scale), float64(cumulative))
+		}
+	}
+
+	// Unlock even if writing panics, since exports recover from panics
+	func() {
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+
+		cumulative := uint64(0)
+		for i, boundary := range h.buckets {
+			cumulative += h.counts[i].Load()
+			writePrometheusSample(buf, name+"_bucket", labels, "le", formatPrometheusValue(boundary*scale), float64(cumulative))
+		}
+	}()
+
+	writePrometheusSample(buf, name+"_bucket", labels, "le", "+Inf", float64(h.Count()))
+	writePrometheusSample(buf, name+"_sum", labels, "", "", h.Sum()*scale)
+	writePrometheusSample(buf, name+"_count", labels, "", "", float64(h.Count()))
+}
+
+// writePrometheusHelp writes the HELP comment line of a metric family. Nothing
+// is written when help is empty.
+func writePrometheusHelp(buf *bytes.Buffer, name, help string) {
+	if help == "" {
+		return
+	}
+
+	buf.WriteString("# HELP ")
+	buf.WriteString(name)
+	buf.WriteByte(' ')
+	buf.WriteString(prometheusHelpEscaper.Replace(help))
+	buf.WriteByte('\n')
+}
+
+// writePrometheusType writes the TYPE comment line of a metric family.
+func writePrometheusType(buf *bytes.Buffer, name, metricType string) {
+	buf.WriteString("# TYPE ")
+	buf.WriteString(name)
+	buf.WriteByte(' ')
+	buf.WriteString(metricType)
+	buf.WriteByte('\n')
+}
+
+// writePrometheusSample writes a single sample line. extraName and extraValue
+// add a label such as "le" or "quantile" after the metric's own labels.
+func writePrometheusSample(buf *bytes.Buffer, name string, labels map[string]string, extraName, extraValue string, value float64) {
+	buf.WriteString(name)
+
+	if len(labels) > 0 || extraName != "" {
+		buf.WriteByte('{')
+
+		first := true
+		writeLabel := func(k, v string) {
+			if !first {
+				buf.WriteByte(',')
+			}
+
+			first = false
+
+			buf.WriteString(sanitizePrometheusName(k, false))
+			buf.WriteString(`="`)
+			buf.WriteString(prometheusLabelEscaper.Replace(v))
+			buf.WriteByte('"')
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(labels)) {
+			writeLabel(k, labels[k])
+		}
+
+		if extraName != "" {
+			writeLabel(extraName, extraValue)
+		}
+
+		buf.WriteByte('}')
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(formatPrometheusValue(value))
+	buf.WriteByte('\n')
+}
+
+// formatPrometheusValue formats a sample value, spelling out special values
+// the way the text format expects.
+func formatPrometheusValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// sortedByFullName returns the metrics of m ordered by fully qualified name,
+// then by labels, so the variants of a family are adjacent.
+func sortedByFullName[T interface {
+	fullName() string
+	exportLabels() map[string]string
+}](m map[string]T) []T {
+	metrics := slices.Collect(maps.Values(m))
+	slices.SortFunc(metrics, func(a, b T) int {
+		if c := strings.Compare(a.fullName(), b.fullName()); c != 0 {
+			return c
+		}
+
+		return strings.Compare(TagsToString(a.exportLabels()), TagsToString(b.exportLabels()))
+	})
+
+	return metrics
+}
+
+// prometheusNamer assigns each metric family a sanitized name that is unique
+// within one export.
+type prometheusNamer struct {
+	families map[string]string // family key -> exported name
+	taken    map[string]struct{}
+}
+
+func newPrometheusNamer() *prometheusNamer {
+	return &prometheusNamer{
+		families: make(map[string]string),
+		taken:    make(map[string]struct{}),
+	}
+}
+
+// name returns the exported name of the family identified by family, whose
+// metrics are named raw. The first family to claim a sanitized name keeps it;
+// later ones get the first free numeric suffix.
+func (n *prometheusNamer) name(family, raw string) string {
+	if name, ok := n.families[family]; ok {
+		return name
+	}
+
+	base := sanitizePrometheusName(raw, true)
+
+	name := base
+	for i := 2; ; i++ {
+		if _, ok := n.taken[name]; !ok {
+			break
+		}
+
+		name = base + "_" + strconv.Itoa(i)
+	}
+
+	n.families[family] = name
+	n.taken[name] = struct{}{}
+
+	return name
+}
+
+// sanitizePrometheusName replaces every character that is not valid in a
+// Prometheus metric name ([a-zA-Z_:][a-zA-Z0-9_:]*) with "_", and prefixes a
+// leading digit with "_". Label names follow the same rules but do not allow
+// colons, which is what allowColon controls.
+func sanitizePrometheusName(name string, allowColon bool) string {
+	if name == "" {
+		return "_"
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(name) + 1)
+
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':' && allowColon:
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,228 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// =============================================================================
+// PROMETHEUS CLIENT BRIDGE
+// =============================================================================
+
+// prometheusBridge reports the metrics of a Metrics instance to a
+// client_golang registry.
+type prometheusBridge struct {
+	metrics Metrics
+}
+
+// NewPrometheusBridge returns a prometheus.Collector reporting the metrics of
+// m, so they can be scraped through a client_golang registry:
+//
+//	prometheus.MustRegister(metrics.NewPrometheusBridge(m))
+//
+// Counters, gauges, histograms and summaries map to their client_golang
+// counterparts and timers to histograms in seconds. Histograms and timers
+// created with WithNativeBuckets are reported as native histograms, which
+// registries expose through the protobuf exposition format. Names are sanitized as in
+// the Prometheus export, the description from Describe becomes the help
+// text, and const labels and labels become labels. Label variants created
+// with WithLabels are reported as one family; a label that only some variants
+// carry is reported as empty on the others.
+//
+// Metrics may be created after the bridge is registered, so it is an
+// unchecked collector: Describe sends no descriptors. Custom collectors are
+// not reported.
+func NewPrometheusBridge(m Metrics) prometheus.Collector {
+	return &prometheusBridge{metrics: m}
+}
+
+// Describe implements prometheus.Collector. It sends nothing, which registers
+// the bridge as an unchecked collector.
+func (b *prometheusBridge) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (b *prometheusBridge) Collect(ch chan<- prometheus.Metric) {
+	names := newPrometheusNamer()
+
+	for _, family := range bridgeFamilies(b.metrics.ListMetrics()) {
+		family.collect(ch, names.name(family.key, family.name))
+	}
+}
+
+// bridgeFamily is the set of label variants reported under one name.
+type bridgeFamily struct {
+	key    string
+	name   string
+	help   string
+	unit   string
+	series []bridgeSeries
+}
+
+// bridgeSeries is a single metric of a family with its labels, keyed by
+// sanitized label name.
+type bridgeSeries struct {
+	metric  any
+	labels  map[string]string
+	created time.Time
+}
+
+// bridgeFamilies groups metrics into families by name and type, ordered by
+// name so sanitized name collisions are resolved the same way on every
+// scrape. Values of unknown types are skipped.
+func bridgeFamilies(metrics map[string]any) []*bridgeFamily {
+	families := make(map[string]*bridgeFamily)
+
+	for _, metric := range metrics {
+		var meta MetricMetadata
+
+		switch m := metric.(type) {
+		case Counter:
+			meta = m.Describe()
+		case Gauge:
+			meta = m.Describe()
+		case Histogram:
+			meta = m.Describe()
+		case Summary:
+			meta = m.Describe()
+		case Timer:
+			meta = m.Describe()
+		default:
+			continue
+		}
+
+		key := meta.Name + " " + string(meta.Type)
+
+		family, ok := families[key]
+		if !ok {
+			family = &bridgeFamily{key: key, name: meta.Name, help: meta.Description, unit: meta.Unit}
+			families[key] = family
+		}
+
+		labels := make(map[string]string, len(meta.ConstLabels)+len(meta.Labels))
+		for k, v := range meta.ConstLabels {
+			labels[sanitizePrometheusName(k, false)] = v
+		}
+
+		for k, v := range meta.Labels {
+			labels[sanitizePrometheusName(k, false)] = v
+		}
+
+		family.series = append(family.series, bridgeSeries{metric: metric, labels: labels, created: meta.Created})
+	}
+
+	sorted := slices.Collect(maps.Values(families))
+	slices.SortFunc(sorted, func(a, b *bridgeFamily) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	return sorted
+}
+
+// collect sends every series of the family under name.
+func (f *bridgeFamily) collect(ch chan<- prometheus.Metric, name string) {
+	labelNames := make(map[string]struct{})
+	for _, series := range f.series {
+		for k := range series.labels {
+			labelNames[k] = struct{}{}
+		}
+	}
+
+	sortedNames := slices.Sorted(maps.Keys(labelNames))
+	desc := prometheus.NewDesc(name, f.help, sortedNames, nil)
+
+	for _, series := range f.series {
+		values := make([]string, len(sortedNames))
+		for i, k := range sortedNames {
+			values[i] = series.labels[k]
+		}
+
+		metric, err := bridgeMetric(desc, series, values)
+		if err != nil {
+			metric = prometheus.NewInvalidMetric(desc, err)
+		}
+
+		ch <- metric
+	}
+}
+
+// bridgeMetric converts the metric of series to a client_golang const metric.
+func bridgeMetric(desc *prometheus.Desc, series bridgeSeries, labelValues []string) (prometheus.Metric, error) {
+	switch m := series.metric.(type) {
+	case Counter:
+		return prometheus.NewConstMetric(desc, prometheus.CounterValue, m.Value(), labelValues...)
+	case Gauge:
+		return prometheus.NewConstMetric(desc, prometheus.GaugeValue, m.Value(), labelValues...)
+	case Histogram:
+		if native := m.NativeBuckets(); native != nil {
+			return bridgeNativeHistogram(desc, native, m.Sum(), series.created, labelValues)
+		}
+
+		return prometheus.NewConstHistogram(desc, m.Count(), m.Sum(), cumulativeBuckets(m.Buckets()), labelValues...)
+	case Summary:
+		quantiles := make(map[float64]float64)
+
+		if s, ok := m.(interface{ objectiveQuantiles() []float64 }); ok {
+			for _, q := range s.objectiveQuantiles() {
+				quantiles[q] = m.Quantile(q)
+			}
+		}
+
+		return prometheus.NewConstSummary(desc, m.Count(), m.Sum(), quantiles, labelValues...)
+	default:
+		t := m.(Timer) //nolint:forcetypeassert // bridgeFamilies only admits the five metric types
+
+		if n, ok := t.(interface{ nativeSeconds() *NativeHistogram }); ok {
+			if native := n.nativeSeconds(); native != nil {
+				return bridgeNativeHistogram(desc, native, t.Sum().Seconds(), series.created, labelValues)
+			}
+		}
+
+		buckets := make(map[float64]uint64)
+		for boundary, count := range t.CumulativeBuckets() {
+			buckets[boundary.Seconds()] = count
+		}
+
+		return prometheus.NewConstHistogram(desc, t.Count(), t.Sum().Seconds(), buckets, labelValues...)
+	}
+}
+
+// bridgeNativeHistogram converts native buckets to a client_golang const
+// native histogram. Bucket indexes follow the same convention, index 0 having
+// an upper bound of 1. The count is taken from the buckets rather than the
+// histogram, since client_golang rejects a count that does not match them.
+func bridgeNativeHistogram(desc *prometheus.Desc, native *NativeHistogram, sum float64, created time.Time, labelValues []string) (prometheus.Metric, error) {
+	count := native.ZeroCount
+
+	positive := make(map[int]int64, len(native.Positive))
+	for idx, n := range native.Positive {
+		positive[idx] = int64(n)
+		count += n
+	}
+
+	negative := make(map[int]int64, len(native.Negative))
+	for idx, n := range native.Negative {
+		negative[idx] = int64(n)
+		count += n
+	}
+
+	return prometheus.NewConstNativeHistogram(desc, count, sum, positive, negative, native.ZeroCount,
+		int32(native.Schema), native.ZeroThreshold, created, labelValues...)
+}
+
+// cumulativeBuckets converts per-bucket counts keyed by upper bound into the
+// cumulative counts client_golang expects.
+func cumulativeBuckets(buckets map[float64]uint64) map[float64]uint64 {
+	cumulative := make(map[float64]uint64, len(buckets))
+
+	total := uint64(0)
+	for _, boundary := range slices.Sorted(maps.Keys(buckets)) {
+		total += buckets[boundary]
+		cumulative[boundary] = total
+	}
+
+	return cumulative
+}
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusBridge(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	requests := collector.Counter("requests_total", WithDescription("Requests served."), WithConstLabels(map[string]string{"service": "api"}))
+	requests.WithLabels(map[string]string{"method": "GET"}).Add(3)
+	requests.WithLabels(map[string]string{"method": "POST"}).Add(1)
+
+	collector.Gauge("temperature", WithNamespace("room")).Set(21.5)
+
+	histogram := collector.Histogram("size", WithBuckets(10, 100))
+	histogram.Observe(5)
+	histogram.Observe(50)
+	histogram.Observe(500)
+
+	collector.Timer("query", WithBuckets(100)).Record(50 * time.Millisecond)
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusBridge(collector)))
+
+	expected := `
+# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{method="",service="api"} 0
+requests_total{method="GET",service="api"} 3
+requests_total{method="POST",service="api"} 1
+# HELP room_temperature
+# TYPE room_temperature gauge
+room_temperature 21.5
+# HELP size
+# TYPE size histogram
+size_bucket{le="10"} 1
+size_bucket{le="100"} 2
+size_bucket{le="+Inf"} 3
+size_sum 555
+size_count 3
+# HELP query
+# TYPE query histogram
+query_bucket{le="0.1"} 1
+query_bucket{le="+Inf"} 1
+query_sum 0.05
+query_count 1
+`
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"requests_total", "room_temperature", "size", "query"))
+}
+
+func TestPrometheusBridge_Summary(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	summary := collector.Summary("latency", WithPercentiles(0.5, 0.9))
+	for i := 1; i <= 10; i++ {
+		summary.Observe(float64(i))
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusBridge(collector)))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+
+	metric := families[0].GetMetric()[0].GetSummary()
+	assert.Equal(t, uint64(10), metric.GetSampleCount())
+	assert.InDelta(t, 55, metric.GetSampleSum(), 0)
+	require.Len(t, metric.GetQuantile(), 2)
+	assert.InDelta(t, 0.5, metric.GetQuantile()[0].GetQuantile(), 0)
+	assert.InDelta(t, summary.Quantile(0.5), metric.GetQuantile()[0].GetValue(), 0)
+}
+
+func TestPrometheusBridge_NativeHistogram(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	histogram := collector.Histogram("latency", WithNativeBuckets(0))
+	for _, v := range []float64{0, 1, 3, 3, -3} {
+		histogram.Observe(v)
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusBridge(collector)))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "HISTOGRAM", families[0].GetType().String())
+
+	metric := families[0].GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(5), metric.GetSampleCount())
+	assert.InDelta(t, 4, metric.GetSampleSum(), 0)
+	assert.Equal(t, int32(0), metric.GetSchema())
+	assert.Equal(t, uint64(1), metric.GetZeroCount())
+	assert.InDelta(t, DefaultNativeZeroThreshold, metric.GetZeroThreshold(), 0)
+	assert.Empty(t, metric.GetBucket(), "native histograms carry no classic buckets")
+
+	// Positive buckets 0 (upper bound 1) and 2 (upper bound 4) in one span
+	// bridging the empty bucket 1, delta encoded
+	require.Len(t, metric.GetPositiveSpan(), 1)
+	assert.Equal(t, int32(0), metric.GetPositiveSpan()[0].GetOffset())
+	assert.Equal(t, uint32(3), metric.GetPositiveSpan()[0].GetLength())
+	assert.Equal(t, []int64{1, -1, 2}, metric.GetPositiveDelta())
+
+	require.Len(t, metric.GetNegativeSpan(), 1)
+	assert.Equal(t, int32(2), metric.GetNegativeSpan()[0].GetOffset())
+	assert.Equal(t, []int64{1}, metric.GetNegativeDelta())
+}
+
+func TestPrometheusBridge_NativeTimer(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	timer := collector.Timer("request", WithNativeBuckets(0))
+	timer.Record(500 * time.Millisecond)
+	timer.Record(3 * time.Second)
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusBridge(collector)))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+
+	metric := families[0].GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(2), metric.GetSampleCount())
+	assert.InDelta(t, 3.5, metric.GetSampleSum(), 0.001)
+	assert.Empty(t, metric.GetBucket(), "native histograms carry no classic buckets")
+
+	// In seconds: 0.5 falls in bucket -1, (0.25, 0.5], and 3 in bucket 2, (2, 4]
+	require.NotEmpty(t, metric.GetPositiveSpan())
+	assert.Equal(t, int32(-1), metric.GetPositiveSpan()[0].GetOffset())
+
+	var (
+		idx     = metric.GetPositiveSpan()[0].GetOffset()
+		count   int64
+		buckets = make(map[int32]int64)
+	)
+
+	for i, span := range metric.GetPositiveSpan() {
+		if i > 0 {
+			idx += span.GetOffset()
+		}
+
+		for range span.GetLength() {
+			count += metric.GetPositiveDelta()[len(buckets)]
+			buckets[idx] = count
+			idx++
+		}
+	}
+
+	assert.Equal(t, int64(1), buckets[-1])
+	assert.Equal(t, int64(1), buckets[2])
+}
+
+func TestPrometheusBridge_SanitizesNames(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("api.requests-total", WithLabel("status_class", "2xx")).Add(2)
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusBridge(collector)))
+
+	expected := `
+# HELP api_requests_total
+# TYPE api_requests_total counter
+api_requests_total{status_class="2xx"} 2
+`
+
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+}
+
+func TestPrometheusBridge_MetricsCreatedAfterRegister(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusBridge(collector)))
+
+	count, err := testutil.GatherAndCount(registry)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	collector.Counter("late_total").Inc()
+
+	count, err = testutil.GatherAndCount(registry, "late_total")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
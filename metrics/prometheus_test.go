@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPrometheus(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	collector.Counter("requests_total", WithLabel("method", "GET")).Add(5)
+	collector.Gauge("temperature", WithNamespace("room")).Set(21.5)
+
+	histogram := collector.Histogram("size", WithBuckets(10, 100))
+	histogram.Observe(5)
+	histogram.Observe(50)
+	histogram.Observe(500)
+
+	collector.Summary("latency").Observe(1)
+	collector.Timer("query", WithBuckets(100)).Record(50 * time.Millisecond)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	out := string(data)
+
+	assert.Contains(t, out, "# TYPE requests_total counter\n")
+	assert.Contains(t, out, `requests_total{method="GET"} 5`+"\n")
+
+	assert.Contains(t, out, "# TYPE room_temperature gauge\n")
+	assert.Contains(t, out, "room_temperature 21.5\n")
+
+	assert.Contains(t, out, "# TYPE size histogram\n")
+	assert.Contains(t, out, `size_bucket{le="10"} 1`+"\n")
+	assert.Contains(t, out, `size_bucket{le="100"} 2`+"\n")
+	assert.Contains(t, out, `size_bucket{le="+Inf"} 3`+"\n")
+	assert.Contains(t, out, "size_sum 555\n")
+	assert.Contains(t, out, "size_count 3\n")
+
+	assert.Contains(t, out, "# TYPE latency summary\n")
+	assert.Contains(t, out, `latency{quantile="0.5"} 1`+"\n")
+	assert.Contains(t, out, "latency_count 1\n")
+
+	assert.Contains(t, out, "# TYPE query histogram\n")
+	assert.Contains(t, out, `query_bucket{le="0.1"} 1`+"\n")
+	assert.Contains(t, out, "query_sum 0.05\n")
+}
+
+func TestExportPrometheus_EscapesLabelValues(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("events", WithLabel("path", "a\"b\\c\nd")).Inc()
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), `events{path="a\"b\\c\nd"} 1`+"\n")
+}
+
+func TestExportPrometheus_SanitizesNames(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("api.requests-total", WithLabel("status_class", "2xx")).Add(3)
+	collector.Gauge("5xx.rate").Set(1)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	out := string(data)
+
+	assert.Contains(t, out, "# TYPE api_requests_total counter\n")
+	assert.Contains(t, out, `api_requests_total{status_class="2xx"} 3`+"\n")
+	assert.Contains(t, out, "# TYPE _5xx_rate gauge\n")
+	assert.Contains(t, out, "_5xx_rate 1\n")
+	assert.NotContains(t, out, "api.requests-total")
+}
+
+func TestExportPrometheus_DeduplicatesSanitizedNames(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("cache.hits").Add(1)
+	collector.Counter("cache-hits").Add(2)
+	collector.Counter("cache_hits").Add(3)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	out := string(data)
+
+	// Families are named in sorted order: "cache-hits", "cache.hits", "cache_hits"
+	assert.Contains(t, out, "cache_hits 2\n")
+	assert.Contains(t, out, "cache_hits_2 1\n")
+	assert.Contains(t, out, "cache_hits_3 3\n")
+	assert.Equal(t, 3, strings.Count(out, "# TYPE cache_hits"))
+}
+
+func TestExportPrometheus_Help(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("jobs_total", WithDescription("Jobs processed.\nIncludes retries."), WithLabel("queue", "a")).Inc()
+	collector.Counter("jobs_total", WithDescription("Jobs processed.\nIncludes retries."), WithLabel("queue", "b")).Inc()
+	collector.Gauge("queue_depth").Set(4)
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	out := string(data)
+
+	assert.Contains(t, out, "# HELP jobs_total Jobs processed.\\nIncludes retries.\n# TYPE jobs_total counter\n")
+	assert.Equal(t, 1, strings.Count(out, "# HELP jobs_total"))
+	assert.NotContains(t, out, "# HELP queue_depth")
+}
+
+// mapCollector is a CustomCollector reporting a fixed map.
+type mapCollector struct {
+	name   string
+	values map[string]any
+}
+
+func (c mapCollector) Name() string            { return c.name }
+func (c mapCollector) Collect() map[string]any { return c.values }
+func (c mapCollector) Reset() error            { return nil }
+
+func TestExportPrometheus_CustomCollectors(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Gauge("db_pool_open").Set(1)
+
+	require.NoError(t, collector.RegisterCollector(mapCollector{
+		name: "db_pool",
+		values: map[string]any{
+			"open":     4,
+			"idle":     uint32(2),
+			"wait.ms":  12.5,
+			"driver":   "postgres",
+			"settings": map[string]any{"max": 10},
+			"closed":   nil,
+		},
+	}))
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	out := string(data)
+
+	assert.Contains(t, out, "# TYPE db_pool_idle gauge\ndb_pool_idle 2\n")
+	assert.Contains(t, out, "# TYPE db_pool_wait_ms gauge\ndb_pool_wait_ms 12.5\n")
+
+	// The registered gauge keeps its name; the collector value is suffixed
+	assert.Contains(t, out, "db_pool_open 1\n")
+	assert.Contains(t, out, "# TYPE db_pool_open_2 gauge\ndb_pool_open_2 4\n")
+
+	assert.NotContains(t, out, "driver")
+	assert.NotContains(t, out, "settings")
+	assert.NotContains(t, out, "closed")
+}
+
+func TestExportPrometheus_CustomCollectorDisabled(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	require.NoError(t, collector.RegisterCollector(mapCollector{name: "cache", values: map[string]any{"size": 3}}))
+	require.NoError(t, collector.DisableCollector("cache"))
+
+	data, err := collector.Export(ExportFormatPrometheus)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "cache_size")
+}
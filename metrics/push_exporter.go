@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// HTTP PUSH EXPORTER BASE
+// =============================================================================
+
+// pushExporter holds the HTTP delivery and statistics shared by exporters
+// that POST an encoded payload to an endpoint.
+type pushExporter struct {
+	endpoint string
+	client   *http.Client
+	timeout  time.Duration
+	headers  map[string]string
+
+	username string
+	password string
+	useAuth  bool
+
+	mu    sync.Mutex
+	stats ExporterStats
+}
+
+// newPushExporter returns a pushExporter with the default client and a 30
+// second timeout.
+func newPushExporter(endpoint, format string) pushExporter {
+	return pushExporter{
+		endpoint: endpoint,
+		client:   http.DefaultClient,
+		timeout:  30 * time.Second,
+		headers:  make(map[string]string),
+		stats:    ExporterStats{Format: format},
+	}
+}
+
+// exportContext returns the context an Export call is bounded by.
+func (p *pushExporter) exportContext() (context.Context, context.CancelFunc) {
+	if p.timeout > 0 {
+		return context.WithTimeout(context.Background(), p.timeout)
+	}
+
+	return context.WithCancel(context.Background())
+}
+
+// push POSTs payload with the protocol headers, then the custom headers, and
+// records the attempt. A non-2xx response is reported as rejected, wrapping
+// the start of the response body.
+func (p *pushExporter) push(ctx context.Context, payload []byte, protocol http.Header, rejected error) error {
+	start := time.Now()
+
+	err := p.send(ctx, payload, protocol, rejected)
+	p.record(start, len(payload), err)
+
+	return err
+}
+
+func (p *pushExporter) send(ctx context.Context, payload []byte, protocol http.Header, rejected error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.stats.Format, err)
+	}
+
+	for key, values := range protocol {
+		req.Header[key] = values
+	}
+
+	for key, value := range p.headers {
+		req.Header.Set(key, value)
+	}
+
+	if p.useAuth {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.stats.Format, err)
+	}
+	defer resp.Body.Close()
+
+	// Receivers explain rejections in the body; keep the start of it
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s: %s", rejected, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// record updates the exporter statistics after an export attempt.
+func (p *pushExporter) record(start time.Time, size int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	recordExport(&p.stats, start, size, err)
+}
+
+// Stats returns the export statistics. Bytes are counted for successful
+// exports, as encoded payload size.
+func (p *pushExporter) Stats() ExporterStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.stats
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"sync"
+)
+
+// =============================================================================
+// METRIC REGISTRY
+// =============================================================================
+
+// Registry caches the metrics resolved from a Metrics instance, so hot paths
+// can look a metric up by name without taking the collector's lock on every
+// call:
+//
+//	reg := metrics.NewRegistry(m)
+//	reg.Counter("requests_total").Inc()
+//
+// Metrics are keyed by type and name. The options passed on the first call for
+// a key are used to create the metric; options on later calls are ignored.
+// Label variants should be cached by the caller or created with WithLabels on
+// the cached metric. A Registry is safe for concurrent use.
+type Registry struct {
+	metrics Metrics
+	cache   sync.Map // registryKey -> metric
+}
+
+// registryKey identifies a cached metric.
+type registryKey struct {
+	metricType MetricType
+	name       string
+}
+
+// NewRegistry returns a Registry resolving metrics from m.
+func NewRegistry(m Metrics) *Registry {
+	return &Registry{metrics: m}
+}
+
+// Counter returns the cached counter with the given name, creating it on
+// first use.
+func (r *Registry) Counter(name string, opts ...MetricOption) Counter {
+	return resolve(r, MetricTypeCounter, name, func() Counter {
+		return r.metrics.Counter(name, opts...)
+	})
+}
+
+// Gauge returns the cached gauge with the given name, creating it on first
+// use.
+func (r *Registry) Gauge(name string, opts ...MetricOption) Gauge {
+	return resolve(r, MetricTypeGauge, name, func() Gauge {
+		return r.metrics.Gauge(name, opts...)
+	})
+}
+
+// Histogram returns the cached histogram with the given name, creating it on
+// first use.
+func (r *Registry) Histogram(name string, opts ...MetricOption) Histogram {
+	return resolve(r, MetricTypeHistogram, name, func() Histogram {
+		return r.metrics.Histogram(name, opts...)
+	})
+}
+
+// Summary returns the cached summary with the given name, creating it on
+// first use.
+func (r *Registry) Summary(name string, opts ...MetricOption) Summary {
+	return resolve(r, MetricTypeSummary, name, func() Summary {
+		return r.metrics.Summary(name, opts...)
+	})
+}
+
+// Timer returns the cached timer with the given name, creating it on first
+// use.
+func (r *Registry) Timer(name string, opts ...MetricOption) Timer {
+	return resolve(r, MetricTypeTimer, name, func() Timer {
+		return r.metrics.Timer(name, opts...)
+	})
+}
+
+// Forget drops every cached metric with the given name, so the next lookup
+// resolves it from the underlying Metrics again.
+func (r *Registry) Forget(name string) {
+	for _, metricType := range []MetricType{
+		MetricTypeCounter, MetricTypeGauge, MetricTypeHistogram, MetricTypeSummary, MetricTypeTimer,
+	} {
+		r.cache.Delete(registryKey{metricType: metricType, name: name})
+	}
+}
+
+// resolve returns the cached metric for the key, creating and storing it
+// with create on a miss. Concurrent misses may both call create; the first
+// stored metric wins, which is harmless since the collector hands out the
+// same instance for the same name.
+func resolve[M any](r *Registry, metricType MetricType, name string, create func() M) M {
+	key := registryKey{metricType: metricType, name: name}
+
+	if cached, ok := r.cache.Load(key); ok {
+		return cached.(M) //nolint:forcetypeassert // keys are typed by metric type
+	}
+
+	actual, _ := r.cache.LoadOrStore(key, create())
+
+	return actual.(M) //nolint:forcetypeassert // keys are typed by metric type
+}
+
+// MustCounter returns the counter with the given name from m. It panics if the
+// name is empty or a label key is invalid, where the factory would only
+// record an error and return an unregistered metric. Use it for metrics
+// declared at package or startup level, where a bad name is a programming
+// error.
+func MustCounter(m Metrics, name string, opts ...MetricOption) Counter {
+	return must(name, opts, m.Counter)
+}
+
+// MustGauge is like MustCounter for gauges.
+func MustGauge(m Metrics, name string, opts ...MetricOption) Gauge {
+	return must(name, opts, m.Gauge)
+}
+
+// MustHistogram is like MustCounter for histograms.
+func MustHistogram(m Metrics, name string, opts ...MetricOption) Histogram {
+	return must(name, opts, m.Histogram)
+}
+
+// MustSummary is like MustCounter for summaries.
+func MustSummary(m Metrics, name string, opts ...MetricOption) Summary {
+	return must(name, opts, m.Summary)
+}
+
+// MustTimer is like MustCounter for timers.
+func MustTimer(m Metrics, name string, opts ...MetricOption) Timer {
+	return must(name, opts, m.Timer)
+}
+
+// must validates name and opts, panicking on error, and creates the metric.
+func must[M any](name string, opts []MetricOption, create func(string, ...MetricOption) M) M {
+	if err := checkMetric(name, opts); err != nil {
+		panic("metrics: " + name + ": " + err.Error())
+	}
+
+	return create(name, opts...)
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_ReturnsCollectorMetrics(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	registry := NewRegistry(collector)
+
+	registry.Counter("requests_total").Add(2)
+	registry.Counter("requests_total").Inc()
+	registry.Gauge("in_flight").Set(4)
+	registry.Histogram("size").Observe(10)
+	registry.Summary("latency").Observe(1)
+	registry.Timer("query").Record(0)
+
+	assert.Same(t, registry.Counter("requests_total"), registry.Counter("requests_total"))
+	assert.InDelta(t, 3, collector.Counter("requests_total").Value(), 0)
+	assert.InDelta(t, 4, collector.Gauge("in_flight").Value(), 0)
+	assert.Equal(t, uint64(1), collector.Histogram("size").Count())
+	assert.Equal(t, uint64(1), collector.Summary("latency").Count())
+	assert.Equal(t, uint64(1), collector.Timer("query").Count())
+}
+
+func TestRegistry_KeysByType(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	registry := NewRegistry(collector)
+
+	registry.Counter("shared").Inc()
+	registry.Gauge("shared").Set(5)
+
+	assert.Same(t, registry.Counter("shared"), registry.Counter("shared"))
+	assert.Same(t, registry.Gauge("shared"), registry.Gauge("shared"))
+	assert.InDelta(t, 1, registry.Counter("shared").Value(), 0)
+	assert.InDelta(t, 5, registry.Gauge("shared").Value(), 0)
+}
+
+func TestRegistry_CallsFactoryOnce(t *testing.T) {
+	mock := NewMockMetrics()
+	registry := NewRegistry(mock)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			registry.Counter("requests_total").Inc()
+		})
+	}
+
+	wg.Wait()
+
+	assert.LessOrEqual(t, mock.CounterCalls, 50)
+	calls := mock.CounterCalls
+
+	registry.Counter("requests_total").Inc()
+	assert.Equal(t, calls, mock.CounterCalls)
+}
+
+func TestRegistry_Forget(t *testing.T) {
+	mock := NewMockMetrics()
+	registry := NewRegistry(mock)
+
+	registry.Counter("requests_total")
+	registry.Counter("requests_total")
+	assert.Equal(t, 1, mock.CounterCalls)
+
+	registry.Forget("requests_total")
+	registry.Counter("requests_total")
+	assert.Equal(t, 2, mock.CounterCalls)
+}
+
+func TestMustCounter(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	MustCounter(collector, "requests_total", WithLabel("method", "GET")).Inc()
+	assert.InDelta(t, 1, collector.Counter("requests_total", WithLabel("method", "GET")).Value(), 0)
+
+	assert.NotNil(t, MustGauge(collector, "in_flight"))
+	assert.NotNil(t, MustHistogram(collector, "size"))
+	assert.NotNil(t, MustSummary(collector, "latency"))
+	assert.NotNil(t, MustTimer(collector, "query"))
+}
+
+func TestMustCounter_PanicsOnInvalidMetric(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	assert.Panics(t, func() { MustCounter(collector, "") })
+	assert.Panics(t, func() { MustGauge(collector, "temp", WithLabel("bad-key", "x")) })
+	assert.Panics(t, func() { MustTimer(collector, "query", WithConstLabels(map[string]string{"0bad": "x"})) })
+}
@@ -0,0 +1,264 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodedSample is a remote write sample with its series labels.
+type decodedSample struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes a WriteRequest protobuf message.
+func decodeWriteRequest(t *testing.T, data []byte) []decodedSample {
+	t.Helper()
+
+	var samples []decodedSample
+
+	forEachField(t, data, func(num protowire.Number, series []byte) {
+		require.Equal(t, protowire.Number(1), num)
+
+		labels := make(map[string]string)
+
+		var sample []byte
+
+		forEachField(t, series, func(num protowire.Number, msg []byte) {
+			switch num {
+			case 1:
+				var name, value string
+
+				forEachField(t, msg, func(num protowire.Number, field []byte) {
+					if num == 1 {
+						name = string(field)
+					} else {
+						value = string(field)
+					}
+				})
+
+				labels[name] = value
+			case 2:
+				sample = msg
+			}
+		})
+
+		s := decodedSample{labels: labels}
+
+		for len(sample) > 0 {
+			num, typ, n := protowire.ConsumeTag(sample)
+			require.GreaterOrEqual(t, n, 0)
+
+			sample = sample[n:]
+
+			switch {
+			case num == 1 && typ == protowire.Fixed64Type:
+				v, n := protowire.ConsumeFixed64(sample)
+				s.value = math.Float64frombits(v)
+				sample = sample[n:]
+			case num == 2 && typ == protowire.VarintType:
+				v, n := protowire.ConsumeVarint(sample)
+				s.timestamp = int64(v) //nolint:gosec // two's complement int64
+				sample = sample[n:]
+			default:
+				t.Fatalf("unexpected sample field %d", num)
+			}
+		}
+
+		samples = append(samples, s)
+	})
+
+	return samples
+}
+
+// forEachField calls fn for every length-delimited field of msg.
+func forEachField(t *testing.T, msg []byte, fn func(num protowire.Number, value []byte)) {
+	t.Helper()
+
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		require.GreaterOrEqual(t, n, 0)
+		require.Equal(t, protowire.BytesType, typ)
+
+		msg = msg[n:]
+
+		value, n := protowire.ConsumeBytes(msg)
+		require.GreaterOrEqual(t, n, 0)
+
+		msg = msg[n:]
+
+		fn(num, value)
+	}
+}
+
+// sampleKey renders labels as name{k="v",...} for lookups.
+func sampleKey(labels map[string]string) string {
+	var pairs []string
+
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		if k != "__name__" {
+			pairs = append(pairs, k+`="`+labels[k]+`"`)
+		}
+	}
+
+	return labels["__name__"] + "{" + strings.Join(pairs, ",") + "}"
+}
+
+func TestRemoteWriteExporter(t *testing.T) {
+	collector := NewMetricsCollector("test")
+
+	requests := collector.Counter("requests_total", WithConstLabels(map[string]string{"service": "api"}))
+	requests.WithLabels(map[string]string{"method": "GET"}).Add(3)
+
+	collector.Gauge("temperature", WithNamespace("room")).Set(21.5)
+
+	histogram := collector.Histogram("size", WithBuckets(10, 100))
+	histogram.Observe(5)
+	histogram.Observe(50)
+	histogram.Observe(500)
+
+	collector.Timer("query", WithBuckets(100)).Record(50 * time.Millisecond)
+
+	var (
+		received []decodedSample
+		header   http.Header
+		user     string
+		password string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		decoded, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+
+		received = decodeWriteRequest(t, decoded)
+		header = r.Header.Clone()
+		user, password, _ = r.BasicAuth()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	exporter := NewRemoteWriteExporter(server.URL,
+		WithRemoteWriteBasicAuth("prom", "secret"),
+		WithRemoteWriteHeader("X-Scope-OrgID", "tenant-1"),
+	)
+
+	before := time.Now().UnixMilli()
+	payload, err := exporter.Export(collector.ListMetrics())
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/x-protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "snappy", header.Get("Content-Encoding"))
+	assert.Equal(t, "0.1.0", header.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, "tenant-1", header.Get("X-Scope-OrgID"))
+	assert.Equal(t, "prom", user)
+	assert.Equal(t, "secret", password)
+
+	values := make(map[string]float64)
+	for _, s := range received {
+		values[sampleKey(s.labels)] = s.value
+
+		assert.GreaterOrEqual(t, s.timestamp, before)
+		assert.LessOrEqual(t, s.timestamp, time.Now().UnixMilli())
+	}
+
+	assert.Equal(t, map[string]float64{
+		`requests_total{service="api"}`:              0,
+		`requests_total{method="GET",service="api"}`: 3,
+		`room_temperature{}`:                         21.5,
+		`size_bucket{le="10"}`:                       1,
+		`size_bucket{le="100"}`:                      2,
+		`size_bucket{le="+Inf"}`:                     3,
+		`size_sum{}`:                                 555,
+		`size_count{}`:                               3,
+		`query_bucket{le="0.1"}`:                     1,
+		`query_bucket{le="+Inf"}`:                    1,
+		`query_sum{}`:                                0.05,
+		`query_count{}`:                              1,
+	}, values)
+
+	stats := exporter.Stats()
+	assert.Equal(t, RemoteWriteFormat, stats.Format)
+	assert.Equal(t, int64(1), stats.ExportCount)
+	assert.Equal(t, int64(1), stats.SuccessCount)
+	assert.Equal(t, int64(len(payload)), stats.BytesExported)
+	assert.InDelta(t, 1.0, stats.SuccessRate, 0)
+}
+
+func TestRemoteWriteExporter_LabelsSorted(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("jobs_total", WithLabel("zone", "b"), WithLabel("Region", "eu")).Inc()
+
+	series := toRemoteWriteSeries(collector.ListMetrics())
+	require.Len(t, series, 1)
+
+	names := make([]string, 0, len(series[0].labels))
+	for _, l := range series[0].labels {
+		names = append(names, l.name)
+	}
+
+	assert.Equal(t, []string{"Region", "__name__", "zone"}, names)
+}
+
+func TestRemoteWriteExporter_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter := NewRemoteWriteExporter(server.URL)
+
+	_, err := exporter.Export(map[string]any{"c": NewCounter("c")})
+	require.ErrorIs(t, err, ErrRemoteWriteFailed)
+	assert.Contains(t, err.Error(), "out of order sample")
+
+	stats := exporter.Stats()
+	assert.Equal(t, int64(1), stats.ErrorCount)
+	assert.Equal(t, int64(1), stats.ConsecutiveErrors)
+	assert.Zero(t, stats.BytesExported)
+	assert.Contains(t, stats.LastError, "400")
+}
+
+func TestRemoteWriteExporter_ContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	exporter := NewRemoteWriteExporter(server.URL)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := exporter.ExportContext(ctx, map[string]any{"c": NewCounter("c")})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	exporter = NewRemoteWriteExporter(server.URL, WithRemoteWriteTimeout(50*time.Millisecond))
+
+	_, err = exporter.Export(map[string]any{"c": NewCounter("c")})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(1), exporter.Stats().ErrorCount)
+}
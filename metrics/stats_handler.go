@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// =============================================================================
+// STATS ENDPOINT HELPERS
+// =============================================================================
+
+// WriteStats writes the collector statistics of m as indented JSON.
+// Uptime is recomputed at write time and the statistics are copied so the
+// response never shares maps or slices with the collector. Entries in
+// ExporterStats that are exporters are replaced by their ExporterStats.
+func WriteStats(w http.ResponseWriter, m Metrics) error {
+	return writeIndentedJSON(w, http.StatusOK, statsForExport(m.Stats()))
+}
+
+// WriteExporterStats writes the statistics of each exporter as indented JSON,
+// keyed by exporter format.
+func WriteExporterStats(w http.ResponseWriter, exporters ...Exporter) error {
+	stats := make(map[string]ExporterStats, len(exporters))
+	for _, exporter := range exporters {
+		stats[exporter.Format()] = exporter.Stats()
+	}
+
+	return writeIndentedJSON(w, http.StatusOK, stats)
+}
+
+// StatsHandler returns an HTTP handler that serves WriteStats for m.
+func StatsHandler(m Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := WriteStats(w, m); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// ExporterStatsHandler returns an HTTP handler that serves WriteExporterStats
+// for the given exporters.
+func ExporterStatsHandler(exporters ...Exporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := WriteExporterStats(w, exporters...); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// statsForExport returns a copy of stats that is safe to serialize.
+func statsForExport(stats CollectorStats) CollectorStats {
+	if !stats.StartTime.IsZero() {
+		stats.Uptime = time.Since(stats.StartTime)
+	}
+
+	stats.MetricsByType = maps.Clone(stats.MetricsByType)
+	stats.Errors = slices.Clone(stats.Errors)
+
+	if stats.ExporterStats != nil {
+		exporterStats := make(map[string]any, len(stats.ExporterStats))
+
+		for name, value := range stats.ExporterStats {
+			switch v := value.(type) {
+			case Exporter:
+				exporterStats[name] = v.Stats()
+			case *ExporterStats:
+				if v != nil {
+					exporterStats[name] = *v
+				}
+			default:
+				exporterStats[name] = v
+			}
+		}
+
+		stats.ExporterStats = exporterStats
+	}
+
+	return stats
+}
+
+// writeIndentedJSON encodes v before writing so encoding errors can still be
+// reported with an error status.
+func writeIndentedJSON(w http.ResponseWriter, status int, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testExporter struct {
+	format string
+	stats  ExporterStats
+}
+
+func (e *testExporter) Export(metrics map[string]any) ([]byte, error) { return nil, nil }
+func (e *testExporter) Format() string                                { return e.format }
+func (e *testExporter) Stats() ExporterStats                          { return e.stats }
+
+func TestStatsHandler(t *testing.T) {
+	collector := NewMetricsCollector("test")
+	collector.Counter("requests_total").Inc()
+	collector.Gauge("queue_depth").Set(1)
+
+	rec := httptest.NewRecorder()
+	StatsHandler(collector).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics/stats", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "\n  \"name\"")
+
+	var stats CollectorStats
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.Equal(t, "test", stats.Name)
+	assert.Equal(t, 2, stats.ActiveMetrics)
+	assert.Equal(t, 1, stats.MetricsByType[MetricTypeCounter])
+}
+
+func TestWriteStats_FreshUptimeAndExporters(t *testing.T) {
+	mock := NewMockMetrics()
+	exporter := &testExporter{format: "statsd", stats: ExporterStats{Format: "statsd", ExportCount: 3}}
+	start := time.Now().Add(-time.Hour)
+
+	mock.StatsFunc = func() CollectorStats {
+		return CollectorStats{
+			Name:      "mock",
+			StartTime: start,
+			Uptime:    time.Second,
+			ExporterStats: map[string]any{
+				"statsd": exporter,
+				"json":   &ExporterStats{Format: "json", ErrorCount: 1},
+			},
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteStats(rec, mock))
+
+	var stats struct {
+		Uptime        time.Duration            `json:"uptime"`
+		ExporterStats map[string]ExporterStats `json:"exporter_stats"`
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.GreaterOrEqual(t, stats.Uptime, time.Hour)
+	assert.Equal(t, int64(3), stats.ExporterStats["statsd"].ExportCount)
+	assert.Equal(t, int64(1), stats.ExporterStats["json"].ErrorCount)
+}
+
+func TestExporterStatsHandler(t *testing.T) {
+	handler := ExporterStatsHandler(
+		&testExporter{format: "prometheus", stats: ExporterStats{Format: "prometheus", SuccessCount: 5}},
+		&testExporter{format: "json", stats: ExporterStats{Format: "json", ErrorCount: 2}},
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics/exporters", nil))
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var stats map[string]ExporterStats
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.Equal(t, int64(5), stats["prometheus"].SuccessCount)
+	assert.Equal(t, int64(2), stats["json"].ErrorCount)
+}
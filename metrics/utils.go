@@ -350,6 +350,28 @@ func (lc *LabelCardinality) Record(metricName string, labels map[string]string)
 	return nil
 }
 
+// SetMaxCardinality changes the limit for new label combinations.
+// Combinations already recorded are kept even if they exceed the new limit.
+// A non-positive limit resets it to MaxLabelCardinality.
+func (lc *LabelCardinality) SetMaxCardinality(maxCardinality int) {
+	if maxCardinality <= 0 {
+		maxCardinality = MaxLabelCardinality
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.maxCardinality = maxCardinality
+}
+
+// MaxCardinality returns the current limit for label combinations.
+func (lc *LabelCardinality) MaxCardinality() int {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	return lc.maxCardinality
+}
+
 // GetCardinality returns current cardinality count.
 func (lc *LabelCardinality) GetCardinality() int {
 	lc.mu.RLock()
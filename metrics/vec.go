@@ -0,0 +1,471 @@
+package metrics
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// COUNTER VEC
+// =============================================================================
+
+// CounterVec is a family of counters that share a name and are distinguished
+// by the values of a fixed set of labels. Each distinct combination of label
+// values gets its own counter, created on first use.
+//
+// CounterVec implements CustomCollector so a family can be registered with a
+// Metrics collector and included in exports.
+type CounterVec struct {
+	name       string
+	labelNames []string
+	opts       []MetricOption
+
+	mu       sync.RWMutex
+	children map[string]*counterImpl
+}
+
+// NewCounterVec creates a counter family with the given label names.
+// The options are applied to every counter in the family.
+func NewCounterVec(name string, labelNames []string, opts ...MetricOption) *CounterVec {
+	return &CounterVec{
+		name:       name,
+		labelNames: append([]string(nil), labelNames...),
+		opts:       opts,
+		children:   make(map[string]*counterImpl),
+	}
+}
+
+// Name returns the family name.
+func (v *CounterVec) Name() string {
+	return v.name
+}
+
+// LabelNames returns the label names of the family in order.
+func (v *CounterVec) LabelNames() []string {
+	return append([]string(nil), v.labelNames...)
+}
+
+// WithLabelValues returns the counter for the given label values, given in the
+// same order as the label names. It panics if the number of values does not
+// match the number of label names, since that is a programming error that
+// would otherwise silently split the family.
+func (v *CounterVec) WithLabelValues(values ...string) Counter {
+	if len(values) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: counter vec %q expects %d label values, got %d",
+			v.name, len(v.labelNames), len(values)))
+	}
+
+	key := labelValuesKey(values)
+
+	v.mu.RLock()
+	counter, ok := v.children[key]
+	v.mu.RUnlock()
+
+	if ok {
+		return counter
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if counter, ok := v.children[key]; ok {
+		return counter
+	}
+
+	counter = NewCounter(v.name, vecOptions(v.opts, v.labelNames, values)...)
+	v.children[key] = counter
+
+	return counter
+}
+
+// With returns the counter for the given labels. Labels that are not part of
+// the family are ignored and missing labels are treated as empty.
+func (v *CounterVec) With(labels map[string]string) Counter {
+	return v.WithLabelValues(labelValues(v.labelNames, labels)...)
+}
+
+// Observe increments the counter for the given label values by 1.
+// Shortcut for WithLabelValues(values...).Inc().
+func (v *CounterVec) Observe(values ...string) {
+	v.WithLabelValues(values...).Inc()
+}
+
+// Collect returns the current value of every counter in the family, keyed by
+// its labels formatted as "key=value" pairs.
+func (v *CounterVec) Collect() map[string]any {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	result := make(map[string]any, len(v.children))
+	for _, counter := range v.children {
+		result[TagsToString(counter.labels)] = counter.Value()
+	}
+
+	return result
+}
+
+// sortedChildren returns the children of the family ordered by labels.
+func (v *CounterVec) sortedChildren() []*counterImpl {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return sortedByFullName(v.children)
+}
+
+// Reset resets every counter in the family to zero.
+func (v *CounterVec) Reset() error {
+	v.mu.RLock()
+	children := maps.Clone(v.children)
+	v.mu.RUnlock()
+
+	for _, counter := range children {
+		if err := counter.Reset(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// =============================================================================
+// GAUGE VEC
+// =============================================================================
+
+// GaugeVec is a family of gauges that share a name and are distinguished by
+// the values of a fixed set of labels. Each distinct combination of label
+// values gets its own gauge, created on first use.
+//
+// GaugeVec implements CustomCollector so a family can be registered with a
+// Metrics collector and included in exports.
+type GaugeVec struct {
+	name       string
+	labelNames []string
+	opts       []MetricOption
+
+	mu       sync.RWMutex
+	children map[string]*gaugeImpl
+}
+
+// NewGaugeVec creates a gauge family with the given label names.
+// The options are applied to every gauge in the family.
+func NewGaugeVec(name string, labelNames []string, opts ...MetricOption) *GaugeVec {
+	return &GaugeVec{
+		name:       name,
+		labelNames: append([]string(nil), labelNames...),
+		opts:       opts,
+		children:   make(map[string]*gaugeImpl),
+	}
+}
+
+// Name returns the family name.
+func (v *GaugeVec) Name() string {
+	return v.name
+}
+
+// LabelNames returns the label names of the family in order.
+func (v *GaugeVec) LabelNames() []string {
+	return append([]string(nil), v.labelNames...)
+}
+
+// WithLabelValues returns the gauge for the given label values, given in the
+// same order as the label names. It panics if the number of values does not
+// match the number of label names.
+func (v *GaugeVec) WithLabelValues(values ...string) Gauge {
+	if len(values) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: gauge vec %q expects %d label values, got %d",
+			v.name, len(v.labelNames), len(values)))
+	}
+
+	key := labelValuesKey(values)
+
+	v.mu.RLock()
+	gauge, ok := v.children[key]
+	v.mu.RUnlock()
+
+	if ok {
+		return gauge
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if gauge, ok := v.children[key]; ok {
+		return gauge
+	}
+
+	gauge = NewGauge(v.name, vecOptions(v.opts, v.labelNames, values)...)
+	v.children[key] = gauge
+
+	return gauge
+}
+
+// With returns the gauge for the given labels. Labels that are not part of
+// the family are ignored and missing labels are treated as empty.
+func (v *GaugeVec) With(labels map[string]string) Gauge {
+	return v.WithLabelValues(labelValues(v.labelNames, labels)...)
+}
+
+// DeleteLabelValues removes the gauge for the given label values, given in
+// the same order as the label names, and reports whether it existed.
+func (v *GaugeVec) DeleteLabelValues(values ...string) bool {
+	if len(values) != len(v.labelNames) {
+		return false
+	}
+
+	key := labelValuesKey(values)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.children[key]; !ok {
+		return false
+	}
+
+	delete(v.children, key)
+
+	return true
+}
+
+// Delete removes the gauge for the given labels and reports whether it
+// existed. Labels are matched as in With.
+func (v *GaugeVec) Delete(labels map[string]string) bool {
+	return v.DeleteLabelValues(labelValues(v.labelNames, labels)...)
+}
+
+// Collect returns the current value of every gauge in the family, keyed by
+// its labels formatted as "key=value" pairs.
+func (v *GaugeVec) Collect() map[string]any {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	result := make(map[string]any, len(v.children))
+	for _, gauge := range v.children {
+		result[TagsToString(gauge.labels)] = gauge.Value()
+	}
+
+	return result
+}
+
+// sortedChildren returns the children of the family ordered by labels.
+func (v *GaugeVec) sortedChildren() []*gaugeImpl {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return sortedByFullName(v.children)
+}
+
+// Reset resets every gauge in the family to zero.
+func (v *GaugeVec) Reset() error {
+	v.mu.RLock()
+	children := maps.Clone(v.children)
+	v.mu.RUnlock()
+
+	for _, gauge := range children {
+		if err := gauge.Reset(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// =============================================================================
+// TIMER VEC
+// =============================================================================
+
+// TimerVec is a family of timers that share a name and are distinguished by
+// the values of a fixed set of labels. Each distinct combination of label
+// values gets its own timer, created on first use.
+//
+// TimerVec implements CustomCollector so a family can be registered with a
+// Metrics collector and included in exports.
+type TimerVec struct {
+	name       string
+	labelNames []string
+	opts       []MetricOption
+
+	mu       sync.RWMutex
+	children map[string]*timerImpl
+}
+
+// NewTimerVec creates a timer family with the given label names.
+// The options are applied to every timer in the family.
+func NewTimerVec(name string, labelNames []string, opts ...MetricOption) *TimerVec {
+	return &TimerVec{
+		name:       name,
+		labelNames: append([]string(nil), labelNames...),
+		opts:       opts,
+		children:   make(map[string]*timerImpl),
+	}
+}
+
+// Name returns the family name.
+func (v *TimerVec) Name() string {
+	return v.name
+}
+
+// LabelNames returns the label names of the family in order.
+func (v *TimerVec) LabelNames() []string {
+	return append([]string(nil), v.labelNames...)
+}
+
+// WithLabelValues returns the timer for the given label values, given in the
+// same order as the label names. It panics if the number of values does not
+// match the number of label names.
+func (v *TimerVec) WithLabelValues(values ...string) Timer {
+	if len(values) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: timer vec %q expects %d label values, got %d",
+			v.name, len(v.labelNames), len(values)))
+	}
+
+	key := labelValuesKey(values)
+
+	v.mu.RLock()
+	timer, ok := v.children[key]
+	v.mu.RUnlock()
+
+	if ok {
+		return timer
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if timer, ok := v.children[key]; ok {
+		return timer
+	}
+
+	timer = NewTimer(v.name, vecOptions(v.opts, v.labelNames, values)...)
+	v.children[key] = timer
+
+	return timer
+}
+
+// With returns the timer for the given labels. Labels that are not part of
+// the family are ignored and missing labels are treated as empty.
+func (v *TimerVec) With(labels map[string]string) Timer {
+	return v.WithLabelValues(labelValues(v.labelNames, labels)...)
+}
+
+// Collect returns the count, sum and mean of every timer in the family, keyed
+// by its labels formatted as "key=value" pairs. Durations are in
+// milliseconds.
+func (v *TimerVec) Collect() map[string]any {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	result := make(map[string]any, len(v.children))
+	for _, timer := range v.children {
+		result[TagsToString(timer.labels)] = map[string]any{
+			"count":   timer.Count(),
+			"sum_ms":  durationToMs(timer.Sum()),
+			"mean_ms": durationToMs(timer.Mean()),
+		}
+	}
+
+	return result
+}
+
+// sortedChildren returns the children of the family ordered by labels.
+func (v *TimerVec) sortedChildren() []*timerImpl {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return sortedByFullName(v.children)
+}
+
+// Reset resets every timer in the family.
+func (v *TimerVec) Reset() error {
+	v.mu.RLock()
+	children := maps.Clone(v.children)
+	v.mu.RUnlock()
+
+	for _, timer := range children {
+		if err := timer.Reset(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// labelValuesKey builds the child lookup key for a set of label values.
+func labelValuesKey(values []string) string {
+	return strings.Join(values, "\xff")
+}
+
+// labelValues returns the values of labels in the order of names.
+func labelValues(names []string, labels map[string]string) []string {
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = labels[name]
+	}
+
+	return values
+}
+
+// vecOptions returns the family options followed by the child's labels.
+func vecOptions(opts []MetricOption, names, values []string) []MetricOption {
+	labels := make(map[string]string, len(names))
+	for i, name := range names {
+		labels[name] = values[i]
+	}
+
+	return append(append([]MetricOption(nil), opts...), WithLabels(labels))
+}
+
+// =============================================================================
+// STATUS COUNTER
+// =============================================================================
+
+// StatusCounter counts HTTP responses by status code and status class.
+// Each observation increments the counter labeled {status="404", class="4xx"},
+// so status classes are derived consistently everywhere.
+type StatusCounter struct {
+	vec *CounterVec
+}
+
+// NewStatusCounter creates a counter family labeled by "status" and "class".
+func NewStatusCounter(name string, opts ...MetricOption) *StatusCounter {
+	return &StatusCounter{
+		vec: NewCounterVec(name, []string{"status", "class"}, opts...),
+	}
+}
+
+// Observe increments the counter for the given HTTP status code.
+func (s *StatusCounter) Observe(status int) {
+	s.Counter(status).Inc()
+}
+
+// Counter returns the counter for the given HTTP status code.
+func (s *StatusCounter) Counter(status int) Counter {
+	return s.vec.WithLabelValues(strconv.Itoa(status), StatusClass(status))
+}
+
+// Name returns the family name.
+func (s *StatusCounter) Name() string {
+	return s.vec.Name()
+}
+
+// Collect returns the current value of every status counter.
+func (s *StatusCounter) Collect() map[string]any {
+	return s.vec.Collect()
+}
+
+// Reset resets every status counter to zero.
+func (s *StatusCounter) Reset() error {
+	return s.vec.Reset()
+}
+
+// StatusClass returns the class of an HTTP status code ("1xx" through "5xx"),
+// or "unknown" for codes outside the 100-599 range.
+func StatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+
+	return strconv.Itoa(status/100) + "xx"
+}
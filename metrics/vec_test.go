@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterVec(t *testing.T) {
+	vec := NewCounterVec("jobs_total", []string{"queue", "result"}, WithNamespace("app"))
+
+	vec.Observe("email", "ok")
+	vec.Observe("email", "ok")
+	vec.WithLabelValues("email", "failed").Add(3)
+	vec.With(map[string]string{"queue": "sms", "result": "ok", "extra": "ignored"}).Inc()
+
+	assert.InDelta(t, 2.0, vec.WithLabelValues("email", "ok").Value(), 0)
+	assert.Same(t, vec.WithLabelValues("email", "ok"), vec.WithLabelValues("email", "ok"))
+	assert.Equal(t, "app_jobs_total", vec.WithLabelValues("sms", "ok").Describe().Name)
+
+	assert.Equal(t, map[string]any{
+		"queue=email,result=ok":     2.0,
+		"queue=email,result=failed": 3.0,
+		"queue=sms,result=ok":       1.0,
+	}, vec.Collect())
+
+	require.NoError(t, vec.Reset())
+	assert.InDelta(t, 0.0, vec.WithLabelValues("email", "failed").Value(), 0)
+
+	assert.Panics(t, func() { vec.Observe("email") })
+}
+
+func TestGaugeVec(t *testing.T) {
+	vec := NewGaugeVec("queue_depth", []string{"queue"})
+
+	vec.WithLabelValues("email").Set(5)
+	vec.With(map[string]string{"queue": "sms"}).Set(2)
+	vec.WithLabelValues("email").Dec()
+
+	assert.Equal(t, map[string]any{
+		"queue=email": 4.0,
+		"queue=sms":   2.0,
+	}, vec.Collect())
+	assert.Equal(t, []string{"queue"}, vec.LabelNames())
+
+	require.NoError(t, vec.Reset())
+	assert.InDelta(t, 0.0, vec.WithLabelValues("email").Value(), 0)
+
+	assert.True(t, vec.Delete(map[string]string{"queue": "sms"}))
+	assert.False(t, vec.DeleteLabelValues("sms"))
+	assert.False(t, vec.DeleteLabelValues())
+	assert.Equal(t, map[string]any{"queue=email": 0.0}, vec.Collect())
+
+	assert.Panics(t, func() { vec.WithLabelValues() })
+}
+
+func TestTimerVec(t *testing.T) {
+	vec := NewTimerVec("query_duration", []string{"table"})
+
+	vec.WithLabelValues("users").Record(10 * time.Millisecond)
+	vec.WithLabelValues("users").Record(30 * time.Millisecond)
+	vec.With(map[string]string{"table": "orders"}).Record(5 * time.Millisecond)
+
+	assert.Same(t, vec.WithLabelValues("users"), vec.WithLabelValues("users"))
+	assert.Equal(t, uint64(2), vec.WithLabelValues("users").Count())
+
+	collected := vec.Collect()
+	require.Contains(t, collected, "table=users")
+	assert.Equal(t, uint64(2), collected["table=users"].(map[string]any)["count"])
+	assert.InDelta(t, 20.0, collected["table=users"].(map[string]any)["mean_ms"], 0.001)
+
+	require.NoError(t, vec.Reset())
+	assert.Equal(t, uint64(0), vec.WithLabelValues("orders").Count())
+
+	assert.Panics(t, func() { vec.WithLabelValues() })
+}
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected string
+	}{
+		{status: 100, expected: "1xx"},
+		{status: 200, expected: "2xx"},
+		{status: 204, expected: "2xx"},
+		{status: 301, expected: "3xx"},
+		{status: 404, expected: "4xx"},
+		{status: 499, expected: "4xx"},
+		{status: 500, expected: "5xx"},
+		{status: 599, expected: "5xx"},
+		{status: 0, expected: "unknown"},
+		{status: 99, expected: "unknown"},
+		{status: 600, expected: "unknown"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, StatusClass(tt.status), "status %d", tt.status)
+	}
+}
+
+func TestStatusCounter(t *testing.T) {
+	counter := NewStatusCounter("http_responses_total")
+
+	counter.Observe(200)
+	counter.Observe(200)
+	counter.Observe(404)
+	counter.Observe(503)
+
+	assert.InDelta(t, 2.0, counter.Counter(200).Value(), 0)
+	assert.Equal(t, map[string]any{
+		"class=2xx,status=200": 2.0,
+		"class=4xx,status=404": 1.0,
+		"class=5xx,status=503": 1.0,
+	}, counter.Collect())
+
+	collector := NewMetricsCollector("test")
+	require.NoError(t, collector.RegisterCollector(counter))
+
+	data, err := collector.Export(ExportFormatJSON)
+	require.NoError(t, err)
+
+	snapshot, err := ParseJSONExport(data)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, snapshot.Collectors["http_responses_total"]["class=2xx,status=200"], 0)
+}
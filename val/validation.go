@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 )
 
@@ -164,6 +165,24 @@ func (ve *ValidationError) GetFieldErrors(field string) []ValidationFieldError {
 	return fieldErrors
 }
 
+// FieldMessages groups error messages by field, in the order they were added.
+// Repeated messages for the same field are reported once.
+func (ve *ValidationError) FieldMessages() map[string][]string {
+	if ve == nil {
+		return nil
+	}
+
+	messages := make(map[string][]string, len(ve.Errors))
+
+	for _, err := range ve.Errors {
+		if !slices.Contains(messages[err.Field], err.Message) {
+			messages[err.Field] = append(messages[err.Field], err.Message)
+		}
+	}
+
+	return messages
+}
+
 // HasFieldError checks if a specific field has validation errors.
 func (ve *ValidationError) HasFieldError(field string) bool {
 	return len(ve.GetFieldErrors(field)) > 0
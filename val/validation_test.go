@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -335,6 +336,29 @@ func TestValidationError_GetFieldErrors_Nil(t *testing.T) {
 	}
 }
 
+func TestValidationError_FieldMessages(t *testing.T) {
+	ve := NewValidationError()
+	ve.Add("email", "invalid format", "test@")
+	ve.Add("email", "too short", "a@b")
+	ve.Add("email", "invalid format", "x@")
+	ve.Add("age", "required", nil)
+
+	messages := ve.FieldMessages()
+
+	if !reflect.DeepEqual(messages["email"], []string{"invalid format", "too short"}) {
+		t.Errorf("messages[email] = %v, want [invalid format too short]", messages["email"])
+	}
+
+	if !reflect.DeepEqual(messages["age"], []string{"required"}) {
+		t.Errorf("messages[age] = %v, want [required]", messages["age"])
+	}
+
+	var nilErr *ValidationError
+	if nilErr.FieldMessages() != nil {
+		t.Error("FieldMessages() on nil should return nil")
+	}
+}
+
 func TestValidationError_HasFieldError(t *testing.T) {
 	ve := NewValidationError()
 	ve.Add("email", "invalid", nil)